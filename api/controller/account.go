@@ -1,7 +1,10 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"redifu-example/definition"
 	"redifu-example/internal/logger"
 	"redifu-example/pkg/account"
 )
@@ -31,6 +34,118 @@ func (ac *AccountController) CreateAccount(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusCreated)
 }
 
+func (ac *AccountController) PatchAccount(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+
+	var reqBody UpdateAccountRequest
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "A100", "PatchAccount.BodyParser")
+	}
+	if reqBody.AccountUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("account_uuid is required"), "A100", "PatchAccount.Validate")
+	}
+	if reqBody.Name == "" && reqBody.Email == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("name or email is required"), "A100", "PatchAccount.Validate")
+	}
+
+	errUpdate := ac.accountService.Update(mainCtx, reqBody.AccountUUID, reqBody.Name, reqBody.Email)
+	if errUpdate != nil {
+		if errors.Is(errUpdate, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errUpdate, "A404", "PatchAccount.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errUpdate, "A500", "PatchAccount.Update")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (ac *AccountController) DeleteAccount(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	accountUUID := c.Params("uuid")
+	if accountUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("uuid is empty"), "A100", "DeleteAccount.Params")
+	}
+
+	errDelete := ac.accountService.Delete(mainCtx, accountUUID)
+	if errDelete != nil {
+		if errors.Is(errDelete, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errDelete, "A404", "DeleteAccount.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errDelete, "A500", "DeleteAccount.Delete")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (ac *AccountController) GetAccount(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	randId := c.Params("randId")
+	if randId == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("randId is empty"), "A100", "GetAccount.Params")
+	}
+
+	account, isBlank, errFetch := ac.accountService.GetAccount(mainCtx, randId)
+	if errFetch != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errFetch, "A500", "GetAccount.Fetch")
+	}
+	if isBlank {
+		return logger.Error(c, fiber.StatusNotFound, fmt.Errorf("account not found"), "A404", "GetAccount.NotFound")
+	}
+	if account == nil {
+		errSeed := ac.accountService.SeedAccount(mainCtx, randId)
+		if errSeed != nil {
+			if errors.Is(errSeed, definition.NotFound) {
+				return logger.Error(c, fiber.StatusNotFound, errSeed, "A404", "GetAccount.NotFound")
+			}
+			return logger.Error(c, fiber.StatusInternalServerError, errSeed, "A500", "GetAccount.Seed")
+		}
+
+		account, isBlank, errFetch = ac.accountService.GetAccount(mainCtx, randId)
+		if errFetch != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "A500", "GetAccountAfterSeed.Fetch")
+		}
+		if account == nil || isBlank {
+			return logger.Error(c, fiber.StatusNotFound, fmt.Errorf("account not found"), "A404", "GetAccount.NotFound")
+		}
+	}
+
+	return c.JSON(account)
+}
+
+func (ac *AccountController) GetAccountByUUID(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	accountUUID := c.Params("uuid")
+	if accountUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("uuid is empty"), "A100", "GetAccountByUUID.Params")
+	}
+
+	// a missing pointer only means the account has not been seeded yet
+	account, errFetch := ac.accountService.GetAccountByUUID(mainCtx, accountUUID)
+	if errFetch != nil {
+		if !errors.Is(errFetch, definition.NotFound) {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "A500", "GetAccountByUUID.Fetch")
+		}
+
+		errSeed := ac.accountService.SeedAccountByUUID(mainCtx, accountUUID)
+		if errSeed != nil {
+			if errors.Is(errSeed, definition.NotFound) {
+				return logger.Error(c, fiber.StatusNotFound, errSeed, "A404", "GetAccountByUUID.NotFound")
+			}
+			return logger.Error(c, fiber.StatusInternalServerError, errSeed, "A500", "GetAccountByUUID.Seed")
+		}
+
+		account, errFetch = ac.accountService.GetAccountByUUID(mainCtx, accountUUID)
+		if errFetch != nil {
+			if errors.Is(errFetch, definition.NotFound) {
+				return logger.Error(c, fiber.StatusNotFound, errFetch, "A404", "GetAccountByUUID.NotFound")
+			}
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "A500", "GetAccountByUUIDAfterSeed.Fetch")
+		}
+	}
+
+	return c.JSON(account)
+}
+
 func NewAccountCUDController(accountService *account.AccountService) *AccountController {
 	return &AccountController{accountService: accountService}
 }
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/internal/model"
+	"redifu-example/pkg/apikey"
+	"time"
+)
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// APIKeyResponse leaves the secret hash out of admin listings.
+type APIKeyResponse struct {
+	UUID      string     `json:"uuid"`
+	RandId    string     `json:"randid"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+func newAPIKeyResponse(key *model.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		UUID:      key.GetUUID(),
+		RandId:    key.GetRandId(),
+		Name:      key.Name,
+		Scopes:    key.Scopes,
+		CreatedAt: key.GetCreatedAt(),
+		RevokedAt: key.RevokedAt,
+	}
+}
+
+type APIKeyController struct {
+	apiKeyService *apikey.APIKeyService
+}
+
+func (kc *APIKeyController) CreateAPIKey(c *fiber.Ctx) error {
+	var reqBody CreateAPIKeyRequest
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "K100", "CreateAPIKey.BodyParser")
+	}
+
+	key, plaintext, errCreate := kc.apiKeyService.Create(c.Context(), reqBody.Name, reqBody.Scopes)
+	if errCreate != nil {
+		if errors.Is(errCreate, apikey.InvalidAPIKey) {
+			return logger.Error(c, fiber.StatusBadRequest, errCreate, "K100", "CreateAPIKey.Validate")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errCreate, "K500", "CreateAPIKey.Create")
+	}
+
+	// the plaintext key is only ever returned here
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"api_key": newAPIKeyResponse(key),
+		"key":     plaintext,
+	})
+}
+
+func (kc *APIKeyController) GetAPIKeys(c *fiber.Ctx) error {
+	keys, errList := kc.apiKeyService.List(c.Context())
+	if errList != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errList, "K500", "GetAPIKeys.List")
+	}
+
+	response := make([]APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, newAPIKeyResponse(key))
+	}
+	return c.JSON(response)
+}
+
+func (kc *APIKeyController) RevokeAPIKey(c *fiber.Ctx) error {
+	apiKeyUUID := c.Params("uuid")
+	if apiKeyUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("uuid is empty"), "K100", "RevokeAPIKey.Params")
+	}
+
+	errRevoke := kc.apiKeyService.Revoke(c.Context(), apiKeyUUID)
+	if errRevoke != nil {
+		if errors.Is(errRevoke, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errRevoke, "K404", "RevokeAPIKey.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errRevoke, "K500", "RevokeAPIKey.Revoke")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func NewAPIKeyController(apiKeyService *apikey.APIKeyService) *APIKeyController {
+	return &APIKeyController{apiKeyService: apiKeyService}
+}
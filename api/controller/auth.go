@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/internal/model"
+	"redifu-example/pkg/account"
+	"redifu-example/pkg/apikey"
+	"redifu-example/pkg/auth"
+	"slices"
+	"strings"
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+const (
+	callerAccountKey = "callerAccount"
+	callerClaimsKey  = "callerClaims"
+	callerAPIKeyKey  = "callerAPIKey"
+)
+
+// userScopes are granted to every end user token, admins hold every scope.
+// Accounts carry emails, reading them takes an admin or an account:read key.
+var userScopes = []string{model.ScopeTicketRead, model.ScopeTicketWrite}
+
+// AuthMiddleware authenticates end users by bearer token and services by API
+// key. Every rejection has its own code:
+//
+//	U401 no bearer token, U402 invalid token, U419 expired token,
+//	U404 token subject has no account, U403 role lacks the scope,
+//	K401 unknown or malformed API key, K402 revoked API key,
+//	K403 API key lacks the scope, U500/K500 lookup failed
+type AuthMiddleware struct {
+	verifier       *auth.Verifier
+	accountService *account.AccountService
+	apiKeyService  *apikey.APIKeyService
+	seedHandler    TicketSeeder
+}
+
+// Authenticate admits end users only, it backs the admin endpoints.
+func (am *AuthMiddleware) Authenticate(c *fiber.Ctx) error {
+	if errAuthenticate := am.authenticateUser(c, ""); errAuthenticate != nil {
+		return errAuthenticate
+	}
+	return c.Next()
+}
+
+func (am *AuthMiddleware) RequireAdmin(c *fiber.Ctx) error {
+	if !CallerIsAdmin(c) {
+		return logger.Error(c, fiber.StatusForbidden, errors.New("admin role is required"), "U403", "RequireAdmin.Role")
+	}
+	return c.Next()
+}
+
+// Require admits API keys holding scope and end users whose role grants it.
+func (am *AuthMiddleware) Require(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var errAuthenticate error
+		if presented := c.Get(HeaderAPIKey); presented != "" {
+			errAuthenticate = am.authenticateAPIKey(c, presented, scope)
+		} else {
+			errAuthenticate = am.authenticateUser(c, scope)
+		}
+		if errAuthenticate != nil {
+			return errAuthenticate
+		}
+		return c.Next()
+	}
+}
+
+// authenticateUser returns a non-nil error once the response has been written.
+func (am *AuthMiddleware) authenticateUser(c *fiber.Ctx, scope string) error {
+	header := c.Get(fiber.HeaderAuthorization)
+	token, hasBearer := strings.CutPrefix(header, "Bearer ")
+	if !hasBearer || token == "" {
+		return logger.Error(c, fiber.StatusUnauthorized, errors.New("bearer token is required"), "U401", "Authenticate.Header")
+	}
+
+	claims, errVerify := am.verifier.Verify(token)
+	if errVerify != nil {
+		if errors.Is(errVerify, auth.ExpiredToken) {
+			return logger.Error(c, fiber.StatusUnauthorized, errVerify, "U419", "Authenticate.Expired")
+		}
+		return logger.Error(c, fiber.StatusUnauthorized, errVerify, "U402", "Authenticate.Verify")
+	}
+	if scope != "" && !claims.IsAdmin() && !slices.Contains(userScopes, scope) {
+		return logger.Error(c, fiber.StatusForbidden, errors.New("role lacks scope "+scope), "U403", "Authenticate.Scope")
+	}
+	c.Locals(callerClaimsKey, claims)
+
+	// GETTER nodes cannot seed accounts, their routes only need the verified claims
+	if am.accountService == nil {
+		return nil
+	}
+
+	caller, errResolve := am.accountService.ResolveAccount(c.Context(), claims.Subject)
+	if errResolve != nil {
+		if errors.Is(errResolve, definition.NotFound) {
+			return logger.Error(c, fiber.StatusUnauthorized, errResolve, "U404", "Authenticate.Account")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errResolve, "U500", "Authenticate.Account")
+	}
+	c.Locals(callerAccountKey, caller)
+
+	return nil
+}
+
+func (am *AuthMiddleware) authenticateAPIKey(c *fiber.Ctx, presented string, scope string) error {
+	mainCtx := c.Context()
+
+	randId, secret, errParse := apikey.ParseKey(presented)
+	if errParse != nil {
+		return logger.Error(c, fiber.StatusUnauthorized, errParse, "K401", "AuthenticateAPIKey.Parse")
+	}
+
+	key, isBlank, errFetch := am.apiKeyService.GetAPIKey(mainCtx, randId)
+	if errFetch != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errFetch, "K500", "AuthenticateAPIKey.Fetch")
+	}
+	if key == nil && !isBlank {
+		errSeed := am.seedHandler.SeedAPIKey(mainCtx, randId)
+		if errSeed != nil && !errors.Is(errSeed, definition.NotFound) {
+			return logger.Error(c, fiber.StatusInternalServerError, errSeed, "K500", "AuthenticateAPIKey.Seed")
+		}
+
+		key, _, errFetch = am.apiKeyService.GetAPIKey(mainCtx, randId)
+		if errFetch != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "K500", "AuthenticateAPIKey.Fetch")
+		}
+	}
+
+	if key == nil || !apikey.Matches(key, secret) {
+		return logger.Error(c, fiber.StatusUnauthorized, apikey.InvalidAPIKey, "K401", "AuthenticateAPIKey.Match")
+	}
+	if key.IsRevoked() {
+		return logger.Error(c, fiber.StatusUnauthorized, errors.New("api key is revoked"), "K402", "AuthenticateAPIKey.Revoked")
+	}
+	if !key.HasScope(scope) {
+		return logger.Error(c, fiber.StatusForbidden, errors.New("api key lacks scope "+scope), "K403", "AuthenticateAPIKey.Scope")
+	}
+
+	c.Locals(callerAPIKeyKey, key)
+	return nil
+}
+
+// CallerAccount is the end user behind the request, nil for API key callers
+// and on GETTER nodes.
+func CallerAccount(c *fiber.Ctx) *model.Account {
+	caller, _ := c.Locals(callerAccountKey).(*model.Account)
+	return caller
+}
+
+func CallerAPIKey(c *fiber.Ctx) *model.APIKey {
+	key, _ := c.Locals(callerAPIKeyKey).(*model.APIKey)
+	return key
+}
+
+func CallerIsAdmin(c *fiber.Ctx) bool {
+	claims, _ := c.Locals(callerClaimsKey).(*auth.Claims)
+	return claims != nil && claims.IsAdmin()
+}
+
+// NewAuthMiddleware takes a nil accountService on GETTER nodes.
+func NewAuthMiddleware(verifier *auth.Verifier, accountService *account.AccountService, apiKeyService *apikey.APIKeyService, seedHandler TicketSeeder) *AuthMiddleware {
+	return &AuthMiddleware{
+		verifier:       verifier,
+		accountService: accountService,
+		apiKeyService:  apiKeyService,
+		seedHandler:    seedHandler,
+	}
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type seedTask struct {
+	key  string
+	seed func(context.Context) error
+}
+
+// BackgroundSeeder runs cache seeds off the request path. Tasks are keyed the
+// same way CoalescingSeedHandler keys them, a key that is already queued or
+// running is not queued twice, and a full queue drops the task since the next
+// request on the same key will enqueue it again.
+type BackgroundSeeder struct {
+	queue   chan seedTask
+	timeout time.Duration
+	mu      sync.Mutex
+	pending map[string]struct{}
+	closed  bool
+	wg      sync.WaitGroup
+	stats   seedStats
+}
+
+type seedStats struct {
+	enqueued     atomic.Int64
+	deduplicated atomic.Int64
+	dropped      atomic.Int64
+	succeeded    atomic.Int64
+	failed       atomic.Int64
+	inFlight     atomic.Int64
+}
+
+// SeedQueueStats is the metrics snapshot served by GetterEndpoints.
+type SeedQueueStats struct {
+	Enqueued     int64 `json:"enqueued"`
+	Deduplicated int64 `json:"deduplicated"`
+	Dropped      int64 `json:"dropped"`
+	Succeeded    int64 `json:"succeeded"`
+	Failed       int64 `json:"failed"`
+	InFlight     int64 `json:"in_flight"`
+	Queued       int   `json:"queued"`
+	Capacity     int   `json:"capacity"`
+}
+
+// Enqueue reports whether the key is now queued or running, false means the
+// queue was full and the caller should seed inline.
+func (bs *BackgroundSeeder) Enqueue(key string, seed func(context.Context) error) bool {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if bs.closed {
+		return false
+	}
+	if _, exists := bs.pending[key]; exists {
+		bs.stats.deduplicated.Add(1)
+		return true
+	}
+
+	select {
+	case bs.queue <- seedTask{key: key, seed: seed}:
+		bs.pending[key] = struct{}{}
+		bs.stats.enqueued.Add(1)
+		return true
+	default:
+		bs.stats.dropped.Add(1)
+		return false
+	}
+}
+
+func (bs *BackgroundSeeder) Stats() SeedQueueStats {
+	return SeedQueueStats{
+		Enqueued:     bs.stats.enqueued.Load(),
+		Deduplicated: bs.stats.deduplicated.Load(),
+		Dropped:      bs.stats.dropped.Load(),
+		Succeeded:    bs.stats.succeeded.Load(),
+		Failed:       bs.stats.failed.Load(),
+		InFlight:     bs.stats.inFlight.Load(),
+		Queued:       len(bs.queue),
+		Capacity:     cap(bs.queue),
+	}
+}
+
+// Close stops accepting work and waits for the queued seeds to finish.
+func (bs *BackgroundSeeder) Close() {
+	bs.mu.Lock()
+	if !bs.closed {
+		bs.closed = true
+		close(bs.queue)
+	}
+	bs.mu.Unlock()
+	bs.wg.Wait()
+}
+
+func (bs *BackgroundSeeder) work() {
+	defer bs.wg.Done()
+	for task := range bs.queue {
+		bs.run(task)
+	}
+}
+
+func (bs *BackgroundSeeder) run(task seedTask) {
+	bs.stats.inFlight.Add(1)
+	ctx, cancel := context.WithTimeout(context.Background(), bs.timeout)
+	defer func() {
+		cancel()
+		bs.stats.inFlight.Add(-1)
+		bs.mu.Lock()
+		delete(bs.pending, task.key)
+		bs.mu.Unlock()
+	}()
+
+	if errSeed := task.seed(ctx); errSeed != nil {
+		bs.stats.failed.Add(1)
+		logger.Logger.Error("background-seed-error", "key", task.key, "error", errSeed.Error())
+		return
+	}
+	bs.stats.succeeded.Add(1)
+}
+
+func NewBackgroundSeeder(workers int, queueSize int) *BackgroundSeeder {
+	if workers < 1 {
+		workers = definition.BackgroundSeedWorkers
+	}
+	if queueSize < 1 {
+		queueSize = definition.BackgroundSeedQueueSize
+	}
+
+	bs := &BackgroundSeeder{
+		queue:   make(chan seedTask, queueSize),
+		timeout: definition.BackgroundSeedTimeout,
+		pending: make(map[string]struct{}),
+	}
+	bs.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go bs.work()
+	}
+	return bs
+}
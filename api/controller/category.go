@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/pkg/category"
+)
+
+type CreateCategoryRequest struct {
+	Category string `json:"category"`
+}
+
+type RenameCategoryRequest struct {
+	CategoryRandId string `json:"category_rand_id"`
+	Category       string `json:"category"`
+}
+
+type CategoryController struct {
+	categoryService *category.CategoryService
+	seedHandler     TicketSeeder
+}
+
+func (cc *CategoryController) CreateCategory(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+
+	var reqBody CreateCategoryRequest
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "C100", "CreateCategory.BodyParser")
+	}
+	if reqBody.Category == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("category is empty"), "C100", "CreateCategory.Validate")
+	}
+
+	errCreate := cc.categoryService.Create(mainCtx, reqBody.Category)
+	if errCreate != nil {
+		if errors.Is(errCreate, definition.Conflict) {
+			return logger.Error(c, fiber.StatusConflict, errCreate, "C409", "CreateCategory.Conflict")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errCreate, "C500", "CreateCategory.Create")
+	}
+
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+func (cc *CategoryController) RenameCategory(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+
+	var reqBody RenameCategoryRequest
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "C100", "RenameCategory.BodyParser")
+	}
+	if reqBody.CategoryRandId == "" || reqBody.Category == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("category_rand_id and category are required"), "C100", "RenameCategory.Validate")
+	}
+
+	errRename := cc.categoryService.Rename(mainCtx, reqBody.CategoryRandId, reqBody.Category)
+	if errRename != nil {
+		if errors.Is(errRename, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errRename, "C404", "RenameCategory.NotFound")
+		}
+		if errors.Is(errRename, definition.Conflict) {
+			return logger.Error(c, fiber.StatusConflict, errRename, "C409", "RenameCategory.Conflict")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errRename, "C500", "RenameCategory.Rename")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (cc *CategoryController) DeleteCategory(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	categoryRandId := c.Params("categoryRandId")
+	if categoryRandId == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("categoryRandId is empty"), "C100", "DeleteCategory.Params")
+	}
+
+	errDelete := cc.categoryService.Delete(mainCtx, categoryRandId)
+	if errDelete != nil {
+		if errors.Is(errDelete, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errDelete, "C404", "DeleteCategory.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errDelete, "C500", "DeleteCategory.Delete")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (cc *CategoryController) GetCategory(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	categoryRandId := c.Params("categoryRandId")
+	if categoryRandId == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("categoryRandId is empty"), "C100", "GetCategory.Params")
+	}
+
+	category, isBlank, errFetch := cc.categoryService.GetCategory(mainCtx, categoryRandId)
+	if errFetch != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errFetch, "C500", "GetCategory.Fetch")
+	}
+	if isBlank {
+		return logger.Error(c, fiber.StatusNotFound, fmt.Errorf("category not found"), "C404", "GetCategory.NotFound")
+	}
+	if category == nil {
+		errSeed := cc.seedHandler.SeedCategory(mainCtx, categoryRandId)
+		if errSeed != nil {
+			if errors.Is(errSeed, definition.NotFound) {
+				return logger.Error(c, fiber.StatusNotFound, errSeed, "C404", "GetCategory.NotFound")
+			}
+			return logger.Error(c, fiber.StatusInternalServerError, errSeed, "C500", "GetCategory.Seed")
+		}
+
+		category, isBlank, errFetch = cc.categoryService.GetCategory(mainCtx, categoryRandId)
+		if errFetch != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "C500", "GetCategoryAfterSeed.Fetch")
+		}
+		if category == nil || isBlank {
+			return logger.Error(c, fiber.StatusNotFound, fmt.Errorf("category not found"), "C404", "GetCategory.NotFound")
+		}
+	}
+
+	return c.JSON(category)
+}
+
+func (cc *CategoryController) GetCategories(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+
+	categories, requireSeeding, errFetch := cc.categoryService.GetCategories(mainCtx)
+	if errFetch != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errFetch, "C500", "GetCategories.Fetch")
+	}
+	if requireSeeding {
+		errSeed := cc.seedHandler.SeedCategories(mainCtx)
+		if errSeed != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errSeed, "C500", "GetCategories.Seed")
+		}
+
+		categories, requireSeeding, errFetch = cc.categoryService.GetCategories(mainCtx)
+		if errFetch != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "C500", "GetCategoriesAfterSeed.Fetch")
+		}
+	}
+
+	return c.JSON(categories)
+}
+
+// NewCategoryController seeds cache misses through seedHandler, GETTER nodes
+// reach the SETTER's seeder over gRPC like they do for tickets.
+func NewCategoryController(categoryService *category.CategoryService, seedHandler TicketSeeder) *CategoryController {
+	return &CategoryController{categoryService: categoryService, seedHandler: seedHandler}
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"redifu-example/definition"
+	"time"
+)
+
+// releaseSeedLock deletes the lock only while it still holds our token, a
+// lease that already expired may belong to another node by now.
+var releaseSeedLock = redis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// CoalescingSeedHandler wraps a TicketSeeder so concurrent misses on the same
+// cache key run a single seed. Callers inside the process share one call
+// through singleflight, callers on other nodes are held back by a Redis lock
+// and return once the winner releases it, the caller then re-reads the cache.
+type CoalescingSeedHandler struct {
+	next        TicketSeeder
+	redisClient redis.UniversalClient
+	group       singleflight.Group
+	// waiting is called once a caller shares a call and once a node waits on
+	// the lock of another, tests use it to hold the seed until everyone is in
+	waiting func(key string)
+}
+
+func (ch *CoalescingSeedHandler) SeedTickets(ctx context.Context, subtraction int64, lastRandId string) error {
+	return ch.coalesce(ctx, fmt.Sprintf("tickets:%d:%s", subtraction, lastRandId), func(ctx context.Context) error {
+		return ch.next.SeedTickets(ctx, subtraction, lastRandId)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedTicketBySecurityRisk(ctx context.Context, subtraction int64, lastRandId string) error {
+	return ch.coalesce(ctx, fmt.Sprintf("security-risk:%d:%s", subtraction, lastRandId), func(ctx context.Context) error {
+		return ch.next.SeedTicketBySecurityRisk(ctx, subtraction, lastRandId)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedTicketsByCategory(ctx context.Context, subtraction int64, lastRandId string, categoryRandId string) error {
+	return ch.coalesce(ctx, fmt.Sprintf("category:%s:%d:%s", categoryRandId, subtraction, lastRandId), func(ctx context.Context) error {
+		return ch.next.SeedTicketsByCategory(ctx, subtraction, lastRandId, categoryRandId)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedTicketsByStatus(ctx context.Context, subtraction int64, lastRandId string, status string) error {
+	return ch.coalesce(ctx, fmt.Sprintf("status:%s:%d:%s", status, subtraction, lastRandId), func(ctx context.Context) error {
+		return ch.next.SeedTicketsByStatus(ctx, subtraction, lastRandId, status)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedTicketsByFilter(ctx context.Context, subtraction int64, lastRandId string, filterKey string) error {
+	return ch.coalesce(ctx, fmt.Sprintf("filter:%s:%d:%s", filterKey, subtraction, lastRandId), func(ctx context.Context) error {
+		return ch.next.SeedTicketsByFilter(ctx, subtraction, lastRandId, filterKey)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedByAccount(ctx context.Context, accountUUID string) error {
+	return ch.coalesce(ctx, "account:"+accountUUID, func(ctx context.Context) error {
+		return ch.next.SeedByAccount(ctx, accountUUID)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedByAssignee(ctx context.Context, assigneeUUID string) error {
+	return ch.coalesce(ctx, "assignee:"+assigneeUUID, func(ctx context.Context) error {
+		return ch.next.SeedByAssignee(ctx, assigneeUUID)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedTicket(ctx context.Context, randId string) error {
+	return ch.coalesce(ctx, "ticket:"+randId, func(ctx context.Context) error {
+		return ch.next.SeedTicket(ctx, randId)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedTicketsByPage(ctx context.Context, page int64) error {
+	return ch.coalesce(ctx, fmt.Sprintf("page:%d", page), func(ctx context.Context) error {
+		return ch.next.SeedTicketsByPage(ctx, page)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedTicketsByDate(ctx context.Context, lowerbound time.Time, upperbound time.Time) error {
+	return ch.coalesce(ctx, fmt.Sprintf("date:%d:%d", lowerbound.UnixNano(), upperbound.UnixNano()), func(ctx context.Context) error {
+		return ch.next.SeedTicketsByDate(ctx, lowerbound, upperbound)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedComments(ctx context.Context, subtraction int64, lastRandId string, ticketRandId string) error {
+	return ch.coalesce(ctx, fmt.Sprintf("comments:%s:%d:%s", ticketRandId, subtraction, lastRandId), func(ctx context.Context) error {
+		return ch.next.SeedComments(ctx, subtraction, lastRandId, ticketRandId)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedTicketSearch(ctx context.Context, query string, page int64) error {
+	return ch.coalesce(ctx, fmt.Sprintf("search:%d:%s", page, query), func(ctx context.Context) error {
+		return ch.next.SeedTicketSearch(ctx, query, page)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedAPIKey(ctx context.Context, randId string) error {
+	return ch.coalesce(ctx, "api-key:"+randId, func(ctx context.Context) error {
+		return ch.next.SeedAPIKey(ctx, randId)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedCategory(ctx context.Context, randId string) error {
+	return ch.coalesce(ctx, "category-item:"+randId, func(ctx context.Context) error {
+		return ch.next.SeedCategory(ctx, randId)
+	})
+}
+
+func (ch *CoalescingSeedHandler) SeedCategories(ctx context.Context) error {
+	return ch.coalesce(ctx, "categories", func(ctx context.Context) error {
+		return ch.next.SeedCategories(ctx)
+	})
+}
+
+func (ch *CoalescingSeedHandler) coalesce(ctx context.Context, key string, seed func(context.Context) error) error {
+	// the shared call must not die with whichever request happened to start it,
+	// it is bounded by the lease instead
+	result := ch.group.DoChan(key, func() (interface{}, error) {
+		sharedCtx, cancel := context.WithTimeout(context.Background(), definition.SeedLockLease)
+		defer cancel()
+		return nil, ch.seedWithLock(sharedCtx, key, seed)
+	})
+	if ch.waiting != nil {
+		ch.waiting(key)
+	}
+
+	select {
+	case res := <-result:
+		return res.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ch *CoalescingSeedHandler) seedWithLock(ctx context.Context, key string, seed func(context.Context) error) error {
+	lockKey := "seed-lock:" + key
+	token, errToken := seedLockToken()
+	if errToken != nil {
+		return errToken
+	}
+
+	acquired, errLock := ch.redisClient.SetNX(ctx, lockKey, token, definition.SeedLockLease).Result()
+	if errLock != nil {
+		return errLock
+	}
+	if acquired {
+		defer releaseSeedLock.Run(ctx, ch.redisClient, []string{lockKey}, token)
+		return seed(ctx)
+	}
+
+	// another node holds the lease, wait for it to go away and let the caller re-read
+	if ch.waiting != nil {
+		ch.waiting(key)
+	}
+	ticker := time.NewTicker(definition.SeedLockPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			exists, errExists := ch.redisClient.Exists(ctx, lockKey).Result()
+			if errExists != nil {
+				return errExists
+			}
+			if exists == 0 {
+				return nil
+			}
+		case <-ctx.Done():
+			// ctx is bounded by the lease, past it the winner's lock is gone either way
+			return nil
+		}
+	}
+}
+
+func seedLockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func NewCoalescingSeedHandler(next TicketSeeder, redisClient redis.UniversalClient) *CoalescingSeedHandler {
+	return &CoalescingSeedHandler{
+		next:        next,
+		redisClient: redisClient,
+	}
+}
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingSeeder stands in for the Postgres backed seeder, every call is one
+// query. A call blocks until every caller of the test shares a call and every
+// other node waits on the lock, so nobody can arrive after the seed it should
+// have shared.
+type countingSeeder struct {
+	queries atomic.Int32
+	waiting sync.WaitGroup
+}
+
+func (s *countingSeeder) seed() error {
+	s.queries.Add(1)
+	s.waiting.Wait()
+	return nil
+}
+
+func (s *countingSeeder) SeedTickets(ctx context.Context, subtraction int64, lastRandId string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedTicketBySecurityRisk(ctx context.Context, subtraction int64, lastRandId string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedTicketsByCategory(ctx context.Context, subtraction int64, lastRandId string, categoryRandId string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedTicketsByStatus(ctx context.Context, subtraction int64, lastRandId string, status string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedTicketsByFilter(ctx context.Context, subtraction int64, lastRandId string, filterKey string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedByAccount(ctx context.Context, accountUUID string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedByAssignee(ctx context.Context, assigneeUUID string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedTicket(ctx context.Context, randId string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedTicketsByPage(ctx context.Context, page int64) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedTicketsByDate(ctx context.Context, lowerbound time.Time, upperbound time.Time) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedComments(ctx context.Context, subtraction int64, lastRandId string, ticketRandId string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedTicketSearch(ctx context.Context, query string, page int64) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedAPIKey(ctx context.Context, randId string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedCategory(ctx context.Context, randId string) error {
+	return s.seed()
+}
+
+func (s *countingSeeder) SeedCategories(ctx context.Context) error {
+	return s.seed()
+}
+
+func newTestRedis(t *testing.T) redis.UniversalClient {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+var coalesceTestLowerbound = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// seederCalls misses the same cache key through every TicketSeeder method.
+var seederCalls = []struct {
+	name string
+	call func(seeder TicketSeeder) error
+}{
+	{"SeedTickets", func(seeder TicketSeeder) error { return seeder.SeedTickets(context.Background(), 0, "") }},
+	{"SeedTicketBySecurityRisk", func(seeder TicketSeeder) error {
+		return seeder.SeedTicketBySecurityRisk(context.Background(), 5, "tkt0005")
+	}},
+	{"SeedTicketsByCategory", func(seeder TicketSeeder) error {
+		return seeder.SeedTicketsByCategory(context.Background(), 0, "", "category-a")
+	}},
+	{"SeedTicketsByStatus", func(seeder TicketSeeder) error {
+		return seeder.SeedTicketsByStatus(context.Background(), 0, "", "open")
+	}},
+	{"SeedTicketsByFilter", func(seeder TicketSeeder) error {
+		return seeder.SeedTicketsByFilter(context.Background(), 0, "", "minRisk=7&unresolved=true")
+	}},
+	{"SeedByAccount", func(seeder TicketSeeder) error { return seeder.SeedByAccount(context.Background(), "reporter") }},
+	{"SeedByAssignee", func(seeder TicketSeeder) error { return seeder.SeedByAssignee(context.Background(), "assignee") }},
+	{"SeedTicket", func(seeder TicketSeeder) error { return seeder.SeedTicket(context.Background(), "tkt0001") }},
+	{"SeedTicketsByPage", func(seeder TicketSeeder) error { return seeder.SeedTicketsByPage(context.Background(), 2) }},
+	{"SeedTicketsByDate", func(seeder TicketSeeder) error {
+		return seeder.SeedTicketsByDate(context.Background(), coalesceTestLowerbound, coalesceTestLowerbound.Add(24*time.Hour))
+	}},
+	{"SeedComments", func(seeder TicketSeeder) error {
+		return seeder.SeedComments(context.Background(), 0, "", "tkt0001")
+	}},
+	{"SeedTicketSearch", func(seeder TicketSeeder) error {
+		return seeder.SeedTicketSearch(context.Background(), "password reset", 1)
+	}},
+	{"SeedAPIKey", func(seeder TicketSeeder) error { return seeder.SeedAPIKey(context.Background(), "key0001") }},
+	{"SeedCategory", func(seeder TicketSeeder) error { return seeder.SeedCategory(context.Background(), "category-a") }},
+	{"SeedCategories", func(seeder TicketSeeder) error { return seeder.SeedCategories(context.Background()) }},
+}
+
+// missConcurrently fires callers misses of the same key spread over handlers
+// and returns the errors they got back. The seed returns once every caller
+// shares a call and every handler but the one seeding waits on its lock.
+func missConcurrently(seeder *countingSeeder, handlers []*CoalescingSeedHandler, callers int, call func(seeder TicketSeeder) error) []error {
+	seeder.waiting.Add(callers + len(handlers) - 1)
+	for _, handler := range handlers {
+		handler.waiting = func(key string) { seeder.waiting.Done() }
+	}
+
+	var done sync.WaitGroup
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			errs[i] = call(handlers[i%len(handlers)])
+		}(i)
+	}
+
+	done.Wait()
+	return errs
+}
+
+func TestCoalescingSeedHandlerSingleNode(t *testing.T) {
+	for _, seederCall := range seederCalls {
+		t.Run(seederCall.name, func(t *testing.T) {
+			seeder := &countingSeeder{}
+			handler := NewCoalescingSeedHandler(seeder, newTestRedis(t))
+
+			errs := missConcurrently(seeder, []*CoalescingSeedHandler{handler}, 50, seederCall.call)
+
+			for i, err := range errs {
+				if err != nil {
+					t.Errorf("caller %d: %v", i, err)
+				}
+			}
+			if queries := seeder.queries.Load(); queries != 1 {
+				t.Errorf("seeded %d times, want 1", queries)
+			}
+		})
+	}
+}
+
+func TestCoalescingSeedHandlerAcrossNodes(t *testing.T) {
+	for _, seederCall := range seederCalls {
+		t.Run(seederCall.name, func(t *testing.T) {
+			seeder := &countingSeeder{}
+			redisClient := newTestRedis(t)
+			// two handlers sharing Redis and the database are two nodes
+			handlers := []*CoalescingSeedHandler{
+				NewCoalescingSeedHandler(seeder, redisClient),
+				NewCoalescingSeedHandler(seeder, redisClient),
+			}
+
+			errs := missConcurrently(seeder, handlers, 50, seederCall.call)
+
+			for i, err := range errs {
+				if err != nil {
+					t.Errorf("caller %d: %v", i, err)
+				}
+			}
+			if queries := seeder.queries.Load(); queries != 1 {
+				t.Errorf("seeded %d times, want 1", queries)
+			}
+			if locks, _ := redisClient.Keys(context.Background(), "seed-lock:*").Result(); len(locks) != 0 {
+				t.Errorf("seed locks %v outlived the seed", locks)
+			}
+		})
+	}
+}
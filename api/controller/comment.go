@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/21strive/redifu"
+	"github.com/gofiber/fiber/v2"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/pkg/comment"
+	"strings"
+)
+
+type CreateCommentRequest struct {
+	TicketUUID  string `json:"ticket_uuid"`
+	AccountUUID string `json:"account_uuid"`
+	ParentUUID  string `json:"parent_uuid"`
+	Body        string `json:"body"`
+}
+
+type UpdateCommentRequest struct {
+	CommentUUID string `json:"comment_uuid"`
+	Body        string `json:"body"`
+}
+
+type CommentCUDController struct {
+	commentService *comment.CommentService
+}
+
+func (cud *CommentCUDController) CreateComment(c *fiber.Ctx) error {
+	var reqBody CreateCommentRequest
+	mainCtx := c.Context()
+
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "M100", "CreateComment.BodyParser")
+	}
+	if reqBody.TicketUUID == "" || reqBody.AccountUUID == "" || reqBody.Body == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("ticket_uuid, account_uuid and body are required"), "M100", "CreateComment.Validate")
+	}
+
+	errCreate := cud.commentService.Create(mainCtx, reqBody.TicketUUID, reqBody.AccountUUID, reqBody.ParentUUID, reqBody.Body)
+	if errCreate != nil {
+		if errors.Is(errCreate, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errCreate, "M404", "CreateComment.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errCreate, "M500", "CreateComment.Create")
+	}
+
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+func (cud *CommentCUDController) PatchComment(c *fiber.Ctx) error {
+	var reqBody UpdateCommentRequest
+	mainCtx := c.Context()
+
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "M100", "PatchComment.BodyParser")
+	}
+	if reqBody.CommentUUID == "" || reqBody.Body == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("comment_uuid and body are required"), "M100", "PatchComment.Validate")
+	}
+
+	errUpdate := cud.commentService.UpdateBody(mainCtx, reqBody.CommentUUID, reqBody.Body)
+	if errUpdate != nil {
+		if errors.Is(errUpdate, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errUpdate, "M404", "PatchComment.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errUpdate, "M500", "PatchComment.Update")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (cud *CommentCUDController) DeleteComment(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	commentUUID := c.Params("commentUUID")
+	if commentUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("commentUUID is empty"), "M100", "DeleteComment.Params")
+	}
+
+	errDelete := cud.commentService.Delete(mainCtx, commentUUID)
+	if errDelete != nil {
+		if errors.Is(errDelete, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errDelete, "M404", "DeleteComment.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errDelete, "M500", "DeleteComment.Delete")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func NewCommentCUDController(commentService *comment.CommentService) *CommentCUDController {
+	return &CommentCUDController{commentService: commentService}
+}
+
+type CommentFetchController struct {
+	commentService *comment.CommentService
+	seedHandler    TicketSeeder
+}
+
+func (fh *CommentFetchController) GetComments(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	ticketRandId := c.Params("ticketRandId")
+	if ticketRandId == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("ticketRandId is empty"), "M100", "GetComments.Params")
+	}
+
+	var lastRandIdArray []string
+	lastRandId := c.Query("lastRandId")
+	if lastRandId != "" {
+		lastRandIdArray = strings.Split(lastRandId, ",")
+	}
+
+	comments, validLastRandId, position, isSeedingRequired, errFetch := fh.commentService.GetComments(mainCtx, ticketRandId, lastRandIdArray)
+	if errFetch != nil {
+		if errors.Is(errFetch, redifu.ResetPagination) {
+			lastRandIdArray = []string{}
+		} else {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "M500", "GetComments.Fetch")
+		}
+	}
+	if isSeedingRequired {
+		errSeedComments := fh.seedHandler.SeedComments(mainCtx, int64(len(comments)), validLastRandId, ticketRandId)
+		if errSeedComments != nil {
+			if errors.Is(errSeedComments, definition.NotFound) {
+				return logger.Error(c, fiber.StatusNotFound, errSeedComments, "M404", "GetComments.NotFound")
+			}
+			return logger.Error(c, fiber.StatusInternalServerError, errSeedComments, "M500", "GetComments.Seed")
+		}
+
+		comments, validLastRandId, position, isSeedingRequired, errFetch = fh.commentService.GetComments(mainCtx, ticketRandId, lastRandIdArray)
+		if errFetch != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "M500", "GetCommentsAfterSeed.Fetch")
+		}
+	}
+
+	c.Set("Content-Type", "application/json")
+	return c.JSON(map[string]interface{}{
+		"position": position,
+		"comments": comments,
+	})
+}
+
+func NewCommentFetchController(commentService *comment.CommentService, seeder TicketSeeder) *CommentFetchController {
+	return &CommentFetchController{
+		commentService: commentService,
+		seedHandler:    seeder,
+	}
+}
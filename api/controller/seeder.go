@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"redifu-example/api/proto/seeder"
+	"redifu-example/definition"
+)
+
+// seedTokenHeader carries the shared token GETTER nodes present to the
+// seeding service.
+const seedTokenHeader = "authorization"
+
+type TicketSeedServer struct {
+	seeder.UnimplementedTicketSeederServer
+	seedHandler TicketSeeder
+}
+
+func (ss *TicketSeedServer) SeedTickets(ctx context.Context, req *seeder.SeedTimelineRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedTickets(ctx, req.GetSubtraction(), req.GetLastRandId()))
+}
+
+func (ss *TicketSeedServer) SeedTicketBySecurityRisk(ctx context.Context, req *seeder.SeedTimelineRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedTicketBySecurityRisk(ctx, req.GetSubtraction(), req.GetLastRandId()))
+}
+
+func (ss *TicketSeedServer) SeedTicketsByCategory(ctx context.Context, req *seeder.SeedTicketsByCategoryRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedTicketsByCategory(ctx, req.GetSubtraction(), req.GetLastRandId(), req.GetCategoryRandId()))
+}
+
+func (ss *TicketSeedServer) SeedTicketsByStatus(ctx context.Context, req *seeder.SeedTicketsByStatusRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedTicketsByStatus(ctx, req.GetSubtraction(), req.GetLastRandId(), req.GetStatus()))
+}
+
+func (ss *TicketSeedServer) SeedTicketsByFilter(ctx context.Context, req *seeder.SeedTicketsByFilterRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedTicketsByFilter(ctx, req.GetSubtraction(), req.GetLastRandId(), req.GetFilterKey()))
+}
+
+func (ss *TicketSeedServer) SeedByAccount(ctx context.Context, req *seeder.SeedByAccountRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedByAccount(ctx, req.GetAccountUuid()))
+}
+
+func (ss *TicketSeedServer) SeedByAssignee(ctx context.Context, req *seeder.SeedByAssigneeRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedByAssignee(ctx, req.GetAssigneeUuid()))
+}
+
+func (ss *TicketSeedServer) SeedTicket(ctx context.Context, req *seeder.SeedTicketRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedTicket(ctx, req.GetRandId()))
+}
+
+func (ss *TicketSeedServer) SeedTicketsByPage(ctx context.Context, req *seeder.SeedTicketsByPageRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedTicketsByPage(ctx, req.GetPage()))
+}
+
+func (ss *TicketSeedServer) SeedTicketsByDate(ctx context.Context, req *seeder.SeedTicketsByDateRequest) (*seeder.SeedResponse, error) {
+	if req.GetLowerbound() == nil || req.GetUpperbound() == nil {
+		return nil, status.Error(codes.InvalidArgument, "lowerbound and upperbound are required")
+	}
+
+	return seedResponse(ss.seedHandler.SeedTicketsByDate(ctx, req.GetLowerbound().AsTime(), req.GetUpperbound().AsTime()))
+}
+
+func (ss *TicketSeedServer) SeedComments(ctx context.Context, req *seeder.SeedCommentsRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedComments(ctx, req.GetSubtraction(), req.GetLastRandId(), req.GetTicketRandId()))
+}
+
+func (ss *TicketSeedServer) SeedTicketSearch(ctx context.Context, req *seeder.SeedTicketSearchRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedTicketSearch(ctx, req.GetQuery(), req.GetPage()))
+}
+
+func (ss *TicketSeedServer) SeedAPIKey(ctx context.Context, req *seeder.SeedAPIKeyRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedAPIKey(ctx, req.GetRandId()))
+}
+
+func (ss *TicketSeedServer) SeedCategory(ctx context.Context, req *seeder.SeedCategoryRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedCategory(ctx, req.GetRandId()))
+}
+
+func (ss *TicketSeedServer) SeedCategories(ctx context.Context, req *seeder.SeedCategoriesRequest) (*seeder.SeedResponse, error) {
+	return seedResponse(ss.seedHandler.SeedCategories(ctx))
+}
+
+// seedResponse maps seeder errors onto gRPC status codes so the client can
+// restore definition.NotFound on the other side of the wire.
+func seedResponse(err error) (*seeder.SeedResponse, error) {
+	if err != nil {
+		if errors.Is(err, definition.NotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &seeder.SeedResponse{}, nil
+}
+
+func NewTicketSeedServer(seedHandler TicketSeeder) *TicketSeedServer {
+	return &TicketSeedServer{seedHandler: seedHandler}
+}
+
+// SeedTokenInterceptor refuses every call that does not carry token, GETTER
+// nodes send it through NewGRPCSeedHandler.
+func SeedTokenInterceptor(token string) grpc.UnaryServerInterceptor {
+	want := []byte("Bearer " + token)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(seedTokenHeader)
+		if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), want) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid seeder token")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// seedToken attaches the shared token to every call. isSecure is whether the
+// connection is TLS, gRPC refuses to send credentials requiring it over
+// plaintext.
+type seedToken struct {
+	token    string
+	isSecure bool
+}
+
+func (st seedToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{seedTokenHeader: "Bearer " + st.token}, nil
+}
+
+func (st seedToken) RequireTransportSecurity() bool {
+	return st.isSecure
+}
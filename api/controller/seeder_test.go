@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"redifu-example/api/proto/seeder"
+	"sync/atomic"
+	"testing"
+)
+
+type recordingSeeder struct {
+	TicketSeeder
+	seeds atomic.Int32
+}
+
+func (s *recordingSeeder) SeedTickets(ctx context.Context, subtraction int64, lastRandId string) error {
+	s.seeds.Add(1)
+	return nil
+}
+
+func startSeedServer(t *testing.T, token string, seedHandler TicketSeeder) string {
+	t.Helper()
+
+	listener, errListen := net.Listen("tcp", "127.0.0.1:0")
+	if errListen != nil {
+		t.Fatal(errListen)
+	}
+	server := grpc.NewServer(grpc.UnaryInterceptor(SeedTokenInterceptor(token)))
+	seeder.RegisterTicketSeederServer(server, NewTicketSeedServer(seedHandler))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestSeedServerRequiresToken(t *testing.T) {
+	seedHandler := &recordingSeeder{}
+	target := startSeedServer(t, "shared-token", seedHandler)
+
+	for _, token := range []string{"", "wrong-token"} {
+		client, errDial := NewGRPCSeedHandler(target, token, "")
+		if errDial != nil {
+			t.Fatal(errDial)
+		}
+		errSeed := client.SeedTickets(context.Background(), 0, "")
+		client.Close()
+		if status.Code(errSeed) != codes.Unauthenticated {
+			t.Errorf("token %q: %v, want Unauthenticated", token, errSeed)
+		}
+	}
+	if seeds := seedHandler.seeds.Load(); seeds != 0 {
+		t.Fatalf("seeded %d times without the token", seeds)
+	}
+
+	client, errDial := NewGRPCSeedHandler(target, "shared-token", "")
+	if errDial != nil {
+		t.Fatal(errDial)
+	}
+	defer client.Close()
+	if errSeed := client.SeedTickets(context.Background(), 0, ""); errSeed != nil {
+		t.Fatal(errSeed)
+	}
+	if seeds := seedHandler.seeds.Load(); seeds != 1 {
+		t.Errorf("seeded %d times, want 1", seeds)
+	}
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/pkg/events"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TicketStreamFilter narrows the live feed, it uses the query parameter names
+// of GET /ticket.
+type TicketStreamFilter struct {
+	CategoryRandId string
+	MinRisk        *int64
+}
+
+func (f *TicketStreamFilter) Matches(entry events.StreamEntry) bool {
+	if entry.Envelope == nil || !strings.HasPrefix(entry.Envelope.Type, "ticket.") {
+		return false
+	}
+
+	ticket, ok := entry.Ticket()
+	if !ok {
+		return false
+	}
+	if f.CategoryRandId != "" && ticket.CategoryRandId != f.CategoryRandId {
+		return false
+	}
+	if f.MinRisk != nil && ticket.SecurityRisk < *f.MinRisk {
+		return false
+	}
+	return true
+}
+
+type TicketStreamController struct {
+	streamReader *events.StreamReader
+}
+
+// StreamTickets is a Server-Sent Events feed of ticket events. Each event id
+// is its Redis stream id, a reconnecting EventSource sends it back as
+// Last-Event-ID and the feed resumes right after it.
+func (sc *TicketStreamController) StreamTickets(c *fiber.Ctx) error {
+	filter := &TicketStreamFilter{CategoryRandId: c.Query("categoryRandId")}
+	if minRisk := c.Query("minRisk"); minRisk != "" {
+		parsed, errParse := strconv.ParseInt(minRisk, 10, 64)
+		if errParse != nil {
+			return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("incorrect minRisk value-type: %w", errParse), "T100", "StreamTickets.Params")
+		}
+		filter.MinRisk = &parsed
+	}
+
+	lastID := c.Get("Last-Event-ID", c.Query("lastEventId"))
+	if lastID != "" && !events.IsValidStreamID(lastID) {
+		return logger.Error(c, fiber.StatusBadRequest, events.InvalidStreamID, "T100", "StreamTickets.LastEventID")
+	}
+	if lastID == "" {
+		latest, errLatest := sc.streamReader.Latest(c.Context())
+		if errLatest != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errLatest, "T500", "StreamTickets.Latest")
+		}
+		lastID = latest
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// the request context is recycled once the handler returns, the writer
+	// runs after that and stops on the first failed flush
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		sc.pump(context.Background(), w, filter, lastID)
+	})
+	return nil
+}
+
+func (sc *TicketStreamController) pump(ctx context.Context, w *bufio.Writer, filter *TicketStreamFilter, lastID string) {
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if w.Flush() != nil {
+		return
+	}
+
+	lastSent := time.Now()
+	for {
+		// blocking for one heartbeat interval means an idle read doubles as the heartbeat timer
+		entries, errRead := sc.streamReader.Read(ctx, lastID, definition.StreamReadCount, definition.StreamHeartbeatInterval)
+		if errRead != nil {
+			logger.Logger.Error("ticket-stream-read-error", "error", errRead.Error())
+			fmt.Fprintf(w, "event: error\ndata: stream unavailable\n\n")
+			w.Flush()
+			return
+		}
+
+		for _, entry := range entries {
+			lastID = entry.ID
+			if !filter.Matches(entry) {
+				continue
+			}
+			if errWrite := writeStreamEntry(w, entry); errWrite != nil {
+				logger.Logger.Error("ticket-stream-encode-error", "id", entry.ID, "error", errWrite.Error())
+				continue
+			}
+			lastSent = time.Now()
+		}
+
+		// a busy stream whose events are all filtered out still needs heartbeats
+		if time.Since(lastSent) >= definition.StreamHeartbeatInterval {
+			fmt.Fprint(w, ": heartbeat\n\n")
+			lastSent = time.Now()
+		}
+
+		if w.Flush() != nil {
+			return
+		}
+	}
+}
+
+func writeStreamEntry(w *bufio.Writer, entry events.StreamEntry) error {
+	if entry.Envelope == nil {
+		return errors.New("entry has no envelope")
+	}
+
+	encoded, errMarshal := json.Marshal(entry.Envelope)
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	_, errWrite := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", entry.ID, entry.Envelope.Type, encoded)
+	return errWrite
+}
+
+func NewTicketStreamController(streamReader *events.StreamReader) *TicketStreamController {
+	return &TicketStreamController{streamReader: streamReader}
+}
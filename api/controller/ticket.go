@@ -6,7 +6,20 @@ import (
 	"fmt"
 	"github.com/21strive/redifu"
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/url"
+	"redifu-example/api/proto/seeder"
+	"redifu-example/definition"
 	"redifu-example/internal/logger"
+	"redifu-example/internal/model"
+	"redifu-example/pkg/apikey"
+	"redifu-example/pkg/category"
+	"redifu-example/pkg/comment"
 	"redifu-example/pkg/ticket"
 	"strconv"
 	"strings"
@@ -14,9 +27,10 @@ import (
 )
 
 type CreateTicketRequest struct {
-	Description  string `json:"description"`
-	ReporterUUID string `json:"reporter_uuid"`
-	SecurityRisk int64  `json:"security_risk"`
+	Description    string `json:"description"`
+	ReporterUUID   string `json:"reporter_uuid"` // only read for API key callers, users always report as themselves
+	SecurityRisk   int64  `json:"security_risk"`
+	CategoryRandId string `json:"category_rand_id"`
 }
 
 type UpdateTicketDescriptionRequest struct {
@@ -24,6 +38,26 @@ type UpdateTicketDescriptionRequest struct {
 	Description string `json:"description"`
 }
 
+type UpdateTicketSecurityRiskRequest struct {
+	TicketUUID   string `json:"ticket_uuid"`
+	SecurityRisk int64  `json:"security_risk"`
+}
+
+type SetTicketCategoryRequest struct {
+	TicketUUID     string `json:"ticket_uuid"`
+	CategoryRandId string `json:"category_rand_id"`
+}
+
+type AssignTicketRequest struct {
+	TicketUUID   string `json:"ticket_uuid"`
+	AssigneeUUID string `json:"assignee_uuid"`
+}
+
+type TransitionTicketRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
+}
+
 type UpdateAccountRequest struct {
 	AccountUUID string `json:"account_uuid"`
 	Name        string `json:"name"`
@@ -42,8 +76,18 @@ func (cud *TicketCUDController) CreateTicket(c *fiber.Ctx) error {
 		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "CreateTicket.BodyParser")
 	}
 
-	errCreate := cud.ticketService.Create(mainCtx, reqBody.Description, reqBody.ReporterUUID, reqBody.SecurityRisk)
+	reporterUUID := reqBody.ReporterUUID
+	if caller := CallerAccount(c); caller != nil {
+		reporterUUID = caller.GetUUID()
+	} else if reporterUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("reporter_uuid is required for API key callers"), "T100", "CreateTicket.Reporter")
+	}
+
+	errCreate := cud.ticketService.Create(mainCtx, reqBody.Description, reporterUUID, reqBody.SecurityRisk, reqBody.CategoryRandId)
 	if errCreate != nil {
+		if errors.Is(errCreate, definition.NotFound) {
+			return logger.Error(c, fiber.StatusBadRequest, errCreate, "T100", "CreateTicket.Category")
+		}
 		return logger.Error(c, fiber.StatusInternalServerError, errCreate, "T500", "CreateTicket.Create")
 	}
 
@@ -58,6 +102,10 @@ func (cud *TicketCUDController) PatchTicket(c *fiber.Ctx) error {
 		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "UpdateTicketDescription.BodyParser")
 	}
 
+	if errAuthorize := cud.authorize(c, reqBody.TicketUUID); errAuthorize != nil {
+		return cud.authorizeError(c, errAuthorize, "UpdateTicketDescription")
+	}
+
 	errUpdate := cud.ticketService.UpdateDescription(mainCtx, reqBody.TicketUUID, reqBody.Description)
 	if errUpdate != nil {
 		return logger.Error(c, fiber.StatusInternalServerError, errUpdate, "T500", "UpdateTicketDescription.Update")
@@ -73,14 +121,155 @@ func (cud *TicketCUDController) ResolveTicket(c *fiber.Ctx) error {
 		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "UpdateTicketDescription.BodyParser")
 	}
 
+	if errAuthorize := cud.authorize(c, reqBody.TicketUUID); errAuthorize != nil {
+		return cud.authorizeError(c, errAuthorize, "ResolveTicket")
+	}
+
 	errResolve := cud.ticketService.ResolveTicket(mainCtx, reqBody.TicketUUID)
 	if errResolve != nil {
+		if errors.Is(errResolve, definition.InvalidTransition) {
+			return logger.Error(c, fiber.StatusConflict, errResolve, "T409", "UpdateTicketDescription.Resolve")
+		}
 		return logger.Error(c, fiber.StatusInternalServerError, errResolve, "T500", "UpdateTicketDescription.Resolve")
 	}
 
 	return c.SendStatus(fiber.StatusOK)
 }
 
+func (cud *TicketCUDController) UpdateTicketSecurityRisk(c *fiber.Ctx) error {
+	var reqBody UpdateTicketSecurityRiskRequest
+	mainCtx := c.Context()
+
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "UpdateTicketSecurityRisk.BodyParser")
+	}
+
+	if errAuthorize := cud.authorize(c, reqBody.TicketUUID); errAuthorize != nil {
+		return cud.authorizeError(c, errAuthorize, "UpdateTicketSecurityRisk")
+	}
+
+	errUpdate := cud.ticketService.UpdateSecurityRisk(mainCtx, reqBody.TicketUUID, reqBody.SecurityRisk)
+	if errUpdate != nil {
+		if errors.Is(errUpdate, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errUpdate, "T404", "UpdateTicketSecurityRisk.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errUpdate, "T500", "UpdateTicketSecurityRisk.Update")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (cud *TicketCUDController) SetTicketCategory(c *fiber.Ctx) error {
+	var reqBody SetTicketCategoryRequest
+	mainCtx := c.Context()
+
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "SetTicketCategory.BodyParser")
+	}
+	if reqBody.TicketUUID == "" || reqBody.CategoryRandId == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("ticket_uuid and category_rand_id are required"), "T100", "SetTicketCategory.Validate")
+	}
+
+	if errAuthorize := cud.authorize(c, reqBody.TicketUUID); errAuthorize != nil {
+		return cud.authorizeError(c, errAuthorize, "SetTicketCategory")
+	}
+
+	errSet := cud.ticketService.SetCategory(mainCtx, reqBody.TicketUUID, reqBody.CategoryRandId)
+	if errSet != nil {
+		if errors.Is(errSet, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errSet, "T404", "SetTicketCategory.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errSet, "T500", "SetTicketCategory.Update")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (cud *TicketCUDController) AssignTicket(c *fiber.Ctx) error {
+	var reqBody AssignTicketRequest
+	mainCtx := c.Context()
+
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "AssignTicket.BodyParser")
+	}
+	if reqBody.TicketUUID == "" || reqBody.AssigneeUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("ticket_uuid and assignee_uuid are required"), "T100", "AssignTicket.Validate")
+	}
+
+	if errAuthorize := cud.authorize(c, reqBody.TicketUUID); errAuthorize != nil {
+		return cud.authorizeError(c, errAuthorize, "AssignTicket")
+	}
+
+	errAssign := cud.ticketService.Assign(mainCtx, reqBody.TicketUUID, reqBody.AssigneeUUID)
+	if errAssign != nil {
+		if errors.Is(errAssign, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errAssign, "T404", "AssignTicket.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errAssign, "T500", "AssignTicket.Assign")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (cud *TicketCUDController) UnassignTicket(c *fiber.Ctx) error {
+	var reqBody AssignTicketRequest
+	mainCtx := c.Context()
+
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "UnassignTicket.BodyParser")
+	}
+	if reqBody.TicketUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("ticket_uuid is required"), "T100", "UnassignTicket.Validate")
+	}
+
+	if errAuthorize := cud.authorize(c, reqBody.TicketUUID); errAuthorize != nil {
+		return cud.authorizeError(c, errAuthorize, "UnassignTicket")
+	}
+
+	errUnassign := cud.ticketService.Unassign(mainCtx, reqBody.TicketUUID)
+	if errUnassign != nil {
+		if errors.Is(errUnassign, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errUnassign, "T404", "UnassignTicket.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errUnassign, "T500", "UnassignTicket.Unassign")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (cud *TicketCUDController) TransitionTicket(c *fiber.Ctx) error {
+	var reqBody TransitionTicketRequest
+	mainCtx := c.Context()
+	ticketUUID := c.Params("ticketUUID")
+	if ticketUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("ticketUUID is empty"), "T100", "TransitionTicket.Params")
+	}
+
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "TransitionTicket.BodyParser")
+	}
+
+	if errAuthorize := cud.authorize(c, ticketUUID); errAuthorize != nil {
+		return cud.authorizeError(c, errAuthorize, "TransitionTicket")
+	}
+
+	errTransition := cud.ticketService.Transition(mainCtx, ticketUUID, reqBody.Status, reqBody.Note)
+	if errTransition != nil {
+		if errors.Is(errTransition, definition.InvalidStatus) {
+			return logger.Error(c, fiber.StatusBadRequest, errTransition, "T100", "TransitionTicket.Status")
+		}
+		if errors.Is(errTransition, definition.InvalidTransition) {
+			return logger.Error(c, fiber.StatusConflict, errTransition, "T409", "TransitionTicket.Transition")
+		}
+		if errors.Is(errTransition, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errTransition, "T404", "TransitionTicket.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errTransition, "T500", "TransitionTicket.Transition")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
 func (cud *TicketCUDController) DeleteTicket(c *fiber.Ctx) error {
 	mainCtx := c.Context()
 	ticketUUID := c.Params("ticketUUID")
@@ -88,6 +277,10 @@ func (cud *TicketCUDController) DeleteTicket(c *fiber.Ctx) error {
 		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("ticketUUID is empty"), "T100", "DeleteTicket.Params")
 	}
 
+	if errAuthorize := cud.authorize(c, ticketUUID); errAuthorize != nil {
+		return cud.authorizeError(c, errAuthorize, "DeleteTicket")
+	}
+
 	errDelete := cud.ticketService.Delete(mainCtx, ticketUUID)
 	if errDelete != nil {
 		return logger.Error(c, fiber.StatusInternalServerError, errDelete, "T500", "DeleteTicket.Delete")
@@ -95,13 +288,50 @@ func (cud *TicketCUDController) DeleteTicket(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusOK)
 }
 
+// authorize lets the ticket's reporter, admins and ticket:write API keys through.
+func (cud *TicketCUDController) authorize(c *fiber.Ctx, ticketUUID string) error {
+	var callerUUID string
+	if caller := CallerAccount(c); caller != nil {
+		callerUUID = caller.GetUUID()
+	}
+	isPrivileged := CallerIsAdmin(c) || CallerAPIKey(c) != nil
+	return cud.ticketService.Authorize(c.Context(), ticketUUID, callerUUID, isPrivileged)
+}
+
+func (cud *TicketCUDController) authorizeError(c *fiber.Ctx, errAuthorize error, source string) error {
+	if errors.Is(errAuthorize, definition.Forbidden) {
+		return logger.Error(c, fiber.StatusForbidden, errAuthorize, "T403", source+".Authorize")
+	}
+	if errors.Is(errAuthorize, definition.NotFound) {
+		return logger.Error(c, fiber.StatusNotFound, errAuthorize, "T404", source+".NotFound")
+	}
+	return logger.Error(c, fiber.StatusInternalServerError, errAuthorize, "T500", source+".Authorize")
+}
+
 func NewTicketCUDController(ticketService *ticket.TicketService) *TicketCUDController {
 	return &TicketCUDController{ticketService: ticketService}
 }
 
+// TicketReader is what TicketFetchController reads tickets through,
+// implemented by ticket.TicketService.
+type TicketReader interface {
+	GetTicket(ctx context.Context, randid string) (*model.Ticket, *model.Account, bool, error)
+	GetTickets(ctx context.Context, lastRandId []string) ([]*model.Ticket, string, string, bool, error)
+	GetTicketsByReporter(ctx context.Context, reporterUUID string) ([]*model.Ticket, bool, error)
+	GetTicketsByAssignee(ctx context.Context, assigneeUUID string) ([]*model.Ticket, bool, error)
+	GetTicketsByCategory(ctx context.Context, categoryRandId string, lastRandId []string) ([]*model.Ticket, string, string, bool, error)
+	GetTicketsByStatus(ctx context.Context, status string, lastRandId []string) ([]*model.Ticket, string, string, bool, error)
+	GetTicketsByFilter(ctx context.Context, filter *ticket.Filter, lastRandId []string) ([]*model.Ticket, string, string, bool, error)
+	GetTicketsBySecurityRisk(ctx context.Context, lastRandId []string) ([]*model.Ticket, string, string, bool, error)
+	GetTicketsByPage(ctx context.Context, page int64) ([]*model.Ticket, bool, error)
+	GetTicketsByDate(ctx context.Context, lowerbound time.Time, upperbound time.Time) ([]*model.Ticket, bool, error)
+	SearchTickets(ctx context.Context, query string, page int64) ([]*model.Ticket, bool, error)
+}
+
 type TicketFetchController struct {
-	ticketService *ticket.TicketService
-	seedHandler   TicketSeeder
+	ticketService    TicketReader
+	seedHandler      TicketSeeder
+	backgroundSeeder *BackgroundSeeder
 }
 
 func (fh *TicketFetchController) GetTicket(c *fiber.Ctx) error {
@@ -144,10 +374,59 @@ func (fh *TicketFetchController) GetTickets(c *fiber.Ctx) error {
 	mainCtx := c.Context()
 	sortBy := c.Query("sort")
 	page := c.Query("page")
+	status := c.Query("status")
 	lowerbound := c.Query("lowerbound")
 	upperbound := c.Query("upperbound")
 
-	if sortBy == "security" {
+	queries := url.Values{}
+	for key, value := range c.Queries() {
+		queries.Set(key, value)
+	}
+	filter, errFilter := ticket.ParseFilter(queries)
+	if errFilter != nil {
+		return logger.Error(c, fiber.StatusBadRequest, errFilter, "T100", "GetTicketsByFilter.Params")
+	}
+
+	// explicit sort modes keep their dedicated timelines
+	if sortBy == "" && filter.IsCombined() {
+		var lastRandIdArray []string
+		lastRandId := c.Query("lastRandId")
+		if lastRandId != "" {
+			lastRandIdArray = strings.Split(lastRandId, ",")
+		}
+
+		tickets, validLastRandId, position, isSeedingRequired, errFetch := fh.ticketService.GetTicketsByFilter(mainCtx, filter, lastRandIdArray)
+		if errFetch != nil {
+			if errors.Is(errFetch, redifu.ResetPagination) {
+				lastRandIdArray = []string{}
+			} else {
+				return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByFilter.Fetch")
+			}
+		}
+		subtraction := int64(len(tickets))
+		if isSeedingRequired && !fh.serveStale(c, len(tickets) > 0, fmt.Sprintf("filter:%s:%d:%s", filter.Key(), subtraction, validLastRandId), func(ctx context.Context) error {
+			return fh.seedHandler.SeedTicketsByFilter(ctx, subtraction, validLastRandId, filter.Key())
+		}) {
+			errSeedTicketTimeline := fh.seedHandler.SeedTicketsByFilter(mainCtx, subtraction, validLastRandId, filter.Key())
+			if errSeedTicketTimeline != nil {
+				if errors.Is(errSeedTicketTimeline, definition.NotFound) {
+					return logger.Error(c, fiber.StatusNotFound, errSeedTicketTimeline, "T404", "GetTicketsByFilter.Category")
+				}
+				return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketTimeline, "T500", "GetTicketsByFilter.Seed")
+			}
+
+			tickets, validLastRandId, position, isSeedingRequired, errFetch = fh.ticketService.GetTicketsByFilter(mainCtx, filter, lastRandIdArray)
+			if errFetch != nil {
+				return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByFilterAfterSeed.Fetch")
+			}
+		}
+
+		c.Set("Content-Type", "application/json")
+		return c.JSON(map[string]interface{}{
+			"position": position,
+			"tickets":  tickets,
+		})
+	} else if sortBy == "security" {
 		var lastRandIdArray []string
 		lastRandId := c.Query("lastRandId")
 		if lastRandId != "" {
@@ -162,8 +441,11 @@ func (fh *TicketFetchController) GetTickets(c *fiber.Ctx) error {
 				return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsBySecurityRisk.Fetch")
 			}
 		}
-		if isSeedingRequired {
-			errSeedTicketTimeline := fh.seedHandler.SeedTicketBySecurityRisk(mainCtx, int64(len(tickets)), validLastRandId)
+		subtraction := int64(len(tickets))
+		if isSeedingRequired && !fh.serveStale(c, len(tickets) > 0, fmt.Sprintf("security-risk:%d:%s", subtraction, validLastRandId), func(ctx context.Context) error {
+			return fh.seedHandler.SeedTicketBySecurityRisk(ctx, subtraction, validLastRandId)
+		}) {
+			errSeedTicketTimeline := fh.seedHandler.SeedTicketBySecurityRisk(mainCtx, subtraction, validLastRandId)
 			if errSeedTicketTimeline != nil {
 				return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketTimeline, "T500", "GetTicketsBySecurityRisk.Seed")
 			}
@@ -199,8 +481,11 @@ func (fh *TicketFetchController) GetTickets(c *fiber.Ctx) error {
 				return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByCategory.Fetch")
 			}
 		}
-		if isSeedingRequired {
-			errSeedTicketTimeline := fh.seedHandler.SeedTicketsByCategory(mainCtx, int64(len(tickets)), validLastRandId, categoryRandId)
+		subtraction := int64(len(tickets))
+		if isSeedingRequired && !fh.serveStale(c, len(tickets) > 0, fmt.Sprintf("category:%s:%d:%s", categoryRandId, subtraction, validLastRandId), func(ctx context.Context) error {
+			return fh.seedHandler.SeedTicketsByCategory(ctx, subtraction, validLastRandId, categoryRandId)
+		}) {
+			errSeedTicketTimeline := fh.seedHandler.SeedTicketsByCategory(mainCtx, subtraction, validLastRandId, categoryRandId)
 			if errSeedTicketTimeline != nil {
 				return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketTimeline, "T500", "GetTicketsByCategory.Seed")
 			}
@@ -211,6 +496,45 @@ func (fh *TicketFetchController) GetTickets(c *fiber.Ctx) error {
 			}
 		}
 
+		c.Set("Content-Type", "application/json")
+		return c.JSON(map[string]interface{}{
+			"position": position,
+			"tickets":  tickets,
+		})
+	} else if status != "" {
+		if !ticket.IsValidStatus(status) {
+			return logger.Error(c, fiber.StatusBadRequest, definition.InvalidStatus, "T100", "GetTicketsByStatus.Params")
+		}
+
+		var lastRandIdArray []string
+		lastRandId := c.Query("lastRandId")
+		if lastRandId != "" {
+			lastRandIdArray = strings.Split(lastRandId, ",")
+		}
+
+		tickets, validLastRandId, position, isSeedingRequired, errFetch := fh.ticketService.GetTicketsByStatus(mainCtx, status, lastRandIdArray)
+		if errFetch != nil {
+			if errors.Is(errFetch, redifu.ResetPagination) {
+				lastRandIdArray = []string{}
+			} else {
+				return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByStatus.Fetch")
+			}
+		}
+		subtraction := int64(len(tickets))
+		if isSeedingRequired && !fh.serveStale(c, len(tickets) > 0, fmt.Sprintf("status:%s:%d:%s", status, subtraction, validLastRandId), func(ctx context.Context) error {
+			return fh.seedHandler.SeedTicketsByStatus(ctx, subtraction, validLastRandId, status)
+		}) {
+			errSeedTicketTimeline := fh.seedHandler.SeedTicketsByStatus(mainCtx, subtraction, validLastRandId, status)
+			if errSeedTicketTimeline != nil {
+				return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketTimeline, "T500", "GetTicketsByStatus.Seed")
+			}
+
+			tickets, validLastRandId, position, isSeedingRequired, errFetch = fh.ticketService.GetTicketsByStatus(mainCtx, status, lastRandIdArray)
+			if errFetch != nil {
+				return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByStatusAfterSeed.Fetch")
+			}
+		}
+
 		c.Set("Content-Type", "application/json")
 		return c.JSON(map[string]interface{}{
 			"position": position,
@@ -227,7 +551,9 @@ func (fh *TicketFetchController) GetTickets(c *fiber.Ctx) error {
 		if errFetch != nil {
 			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByDate.Fetch")
 		}
-		if seedRequired {
+		if seedRequired && !fh.serveStale(c, len(tickets) > 0, fmt.Sprintf("date:%d:%d", lowerboundAsTime.UnixNano(), upperboundAsTime.UnixNano()), func(ctx context.Context) error {
+			return fh.seedHandler.SeedTicketsByDate(ctx, lowerboundAsTime, upperboundAsTime)
+		}) {
 			errSeedTicketByDate := fh.seedHandler.SeedTicketsByDate(mainCtx, lowerboundAsTime, upperboundAsTime)
 			if errSeedTicketByDate != nil {
 				return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketByDate, "T500", "GetTicketsByDate.Seed")
@@ -252,7 +578,9 @@ func (fh *TicketFetchController) GetTickets(c *fiber.Ctx) error {
 			if errFetch != nil {
 				return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByPage.Fetch")
 			}
-			if seedRequired {
+			if seedRequired && !fh.serveStale(c, len(tickets) > 0, fmt.Sprintf("page:%d", pageAsInt), func(ctx context.Context) error {
+				return fh.seedHandler.SeedTicketsByPage(ctx, pageAsInt)
+			}) {
 				errSeedTicketByPage := fh.seedHandler.SeedTicketsByPage(mainCtx, pageAsInt)
 				if errSeedTicketByPage != nil {
 					return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketByPage, "T500", "GetTicketsByPage.Seed")
@@ -282,8 +610,11 @@ func (fh *TicketFetchController) GetTickets(c *fiber.Ctx) error {
 				}
 			}
 
-			if isSeedingRequired {
-				errSeedTicketTimeline := fh.seedHandler.SeedTickets(mainCtx, int64(len(ticket)), validLastRandId)
+			subtraction := int64(len(ticket))
+			if isSeedingRequired && !fh.serveStale(c, len(ticket) > 0, fmt.Sprintf("tickets:%d:%s", subtraction, validLastRandId), func(ctx context.Context) error {
+				return fh.seedHandler.SeedTickets(ctx, subtraction, validLastRandId)
+			}) {
+				errSeedTicketTimeline := fh.seedHandler.SeedTickets(mainCtx, subtraction, validLastRandId)
 				if errSeedTicketTimeline != nil {
 					return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketTimeline, "T500", "GetTickets.Seed")
 				}
@@ -305,12 +636,14 @@ func (fh *TicketFetchController) GetTickets(c *fiber.Ctx) error {
 
 func (fh *TicketFetchController) GetTicketsByReporter(c *fiber.Ctx) error {
 	mainCtx := c.Context()
-	accountUUID := c.Params("accountUUID")
+	accountUUID := c.Params("reporterUUID")
 	ticket, requireSeeding, errFetch := fh.ticketService.GetTicketsByReporter(mainCtx, accountUUID)
 	if errFetch != nil {
 		return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketSorted.Fetch")
 	}
-	if requireSeeding {
+	if requireSeeding && !fh.serveStale(c, len(ticket) > 0, "account:"+accountUUID, func(ctx context.Context) error {
+		return fh.seedHandler.SeedByAccount(ctx, accountUUID)
+	}) {
 		errSeedTicketSorted := fh.seedHandler.SeedByAccount(mainCtx, accountUUID)
 		if errSeedTicketSorted != nil {
 			return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketSorted, "T500", "GetTicketSorted.Seed")
@@ -325,10 +658,101 @@ func (fh *TicketFetchController) GetTicketsByReporter(c *fiber.Ctx) error {
 	return c.JSON(ticket)
 }
 
-func NewTicketFetchController(ticketService *ticket.TicketService, seeder TicketSeeder) *TicketFetchController {
+func (fh *TicketFetchController) GetTicketsByAssignee(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	assigneeUUID := c.Params("assigneeUUID")
+	if assigneeUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("assigneeUUID is empty"), "T100", "GetTicketsByAssignee.Params")
+	}
+
+	tickets, requireSeeding, errFetch := fh.ticketService.GetTicketsByAssignee(mainCtx, assigneeUUID)
+	if errFetch != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByAssignee.Fetch")
+	}
+	if requireSeeding {
+		errSeedTicketSorted := fh.seedHandler.SeedByAssignee(mainCtx, assigneeUUID)
+		if errSeedTicketSorted != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errSeedTicketSorted, "T500", "GetTicketsByAssignee.Seed")
+		}
+
+		tickets, requireSeeding, errFetch = fh.ticketService.GetTicketsByAssignee(mainCtx, assigneeUUID)
+		if errFetch != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "GetTicketsByAssigneeAfterSeed.Fetch")
+		}
+	}
+
+	return c.JSON(tickets)
+}
+
+func (fh *TicketFetchController) SearchTickets(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+	query := c.Query("q")
+	if strings.TrimSpace(query) == "" {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("q is required"), "T100", "SearchTickets.Params")
+	}
+
+	pageAsInt := int64(1)
+	if page := c.Query("page"); page != "" {
+		parsed, errParse := strconv.ParseInt(page, 10, 64)
+		if errParse != nil || parsed < 1 {
+			return logger.Error(c, fiber.StatusBadRequest, errors.New("incorrect page number value-type"), "T100", "SearchTickets.Parse")
+		}
+		pageAsInt = parsed
+	}
+
+	tickets, seedRequired, errFetch := fh.ticketService.SearchTickets(mainCtx, query, pageAsInt)
+	if errFetch != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "SearchTickets.Fetch")
+	}
+	if seedRequired {
+		errSeedSearch := fh.seedHandler.SeedTicketSearch(mainCtx, query, pageAsInt)
+		if errSeedSearch != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errSeedSearch, "T500", "SearchTickets.Seed")
+		}
+
+		tickets, _, errFetch = fh.ticketService.SearchTickets(mainCtx, query, pageAsInt)
+		if errFetch != nil {
+			return logger.Error(c, fiber.StatusInternalServerError, errFetch, "T500", "SearchTicketsAfterSeed.Fetch")
+		}
+	}
+
+	c.Set("Content-Type", "application/json")
+	return c.JSON(map[string]interface{}{
+		"page":    pageAsInt,
+		"tickets": tickets,
+	})
+}
+
+// serveStale hands the seed to the background pool when stale-while-revalidate
+// is enabled and something is already cached to answer with, the response is
+// then marked X-Cache: stale and the caller skips the inline seed.
+func (fh *TicketFetchController) serveStale(c *fiber.Ctx, hasCached bool, key string, seed func(context.Context) error) bool {
+	if fh.backgroundSeeder == nil || !hasCached {
+		return false
+	}
+	if !fh.backgroundSeeder.Enqueue(key, seed) {
+		return false
+	}
+
+	c.Set("X-Cache", "stale")
+	return true
+}
+
+func (fh *TicketFetchController) GetSeedQueueStats(c *fiber.Ctx) error {
+	if fh.backgroundSeeder == nil {
+		return logger.Error(c, fiber.StatusNotFound, errors.New("background seeding is disabled"), "T404", "GetSeedQueueStats.Disabled")
+	}
+
+	return c.JSON(fh.backgroundSeeder.Stats())
+}
+
+// NewTicketFetchController takes an optional backgroundSeeder, nil keeps every
+// cache miss seeding inline.
+func NewTicketFetchController(ticketService TicketReader, seeder TicketSeeder, backgroundSeeder *BackgroundSeeder) *TicketFetchController {
 	return &TicketFetchController{
-		ticketService: ticketService,
-		seedHandler:   seeder,
+		ticketService:    ticketService,
+		seedHandler:      seeder,
+		backgroundSeeder: backgroundSeeder,
 	}
 }
 
@@ -336,14 +760,25 @@ type TicketSeeder interface {
 	SeedTickets(context.Context, int64, string) error
 	SeedTicketBySecurityRisk(context.Context, int64, string) error
 	SeedTicketsByCategory(context.Context, int64, string, string) error
+	SeedTicketsByStatus(context.Context, int64, string, string) error
+	SeedTicketsByFilter(context.Context, int64, string, string) error
 	SeedByAccount(context.Context, string) error
+	SeedByAssignee(context.Context, string) error
 	SeedTicket(context.Context, string) error
 	SeedTicketsByPage(ctx context.Context, page int64) error
 	SeedTicketsByDate(ctx context.Context, lowerbound time.Time, upperbound time.Time) error
+	SeedComments(ctx context.Context, subtraction int64, lastRandId string, ticketRandId string) error
+	SeedTicketSearch(ctx context.Context, query string, page int64) error
+	SeedAPIKey(ctx context.Context, randId string) error
+	SeedCategory(ctx context.Context, randId string) error
+	SeedCategories(ctx context.Context) error
 }
 
 type TicketSeedHandler struct {
-	ticketService *ticket.TicketService
+	ticketService   *ticket.TicketService
+	commentService  *comment.CommentService
+	categoryService *category.CategoryService
+	apiKeyService   *apikey.APIKeyService
 }
 
 func (sh *TicketSeedHandler) SeedTickets(ctx context.Context, subtraction int64, lastRandId string) error {
@@ -358,10 +793,22 @@ func (sh *TicketSeedHandler) SeedTicketsByCategory(ctx context.Context, subtract
 	return sh.ticketService.SeedTicketsByCategory(ctx, subtraction, lastRandId, categoryRandId)
 }
 
+func (sh *TicketSeedHandler) SeedTicketsByStatus(ctx context.Context, subtraction int64, lastRandId string, status string) error {
+	return sh.ticketService.SeedTicketsByStatus(ctx, subtraction, lastRandId, status)
+}
+
+func (sh *TicketSeedHandler) SeedTicketsByFilter(ctx context.Context, subtraction int64, lastRandId string, filterKey string) error {
+	return sh.ticketService.SeedTicketsByFilter(ctx, subtraction, lastRandId, filterKey)
+}
+
 func (sh *TicketSeedHandler) SeedByAccount(ctx context.Context, accountUUID string) error {
 	return sh.ticketService.SeedTicketsByAccount(ctx, accountUUID)
 }
 
+func (sh *TicketSeedHandler) SeedByAssignee(ctx context.Context, assigneeUUID string) error {
+	return sh.ticketService.SeedTicketsByAssignee(ctx, assigneeUUID)
+}
+
 func (sh *TicketSeedHandler) SeedTicket(ctx context.Context, randId string) error {
 	return sh.ticketService.SeedTicket(ctx, randId)
 }
@@ -374,10 +821,152 @@ func (sh *TicketSeedHandler) SeedTicketsByDate(ctx context.Context, lowerbound t
 	return sh.ticketService.SeedTicketsByDate(ctx, lowerbound, upperbound)
 }
 
-func NewSelfSeedHandler(ticketService *ticket.TicketService) *TicketSeedHandler {
+func (sh *TicketSeedHandler) SeedComments(ctx context.Context, subtraction int64, lastRandId string, ticketRandId string) error {
+	return sh.commentService.SeedComments(ctx, subtraction, lastRandId, ticketRandId)
+}
+
+func (sh *TicketSeedHandler) SeedTicketSearch(ctx context.Context, query string, page int64) error {
+	return sh.ticketService.SeedTicketSearch(ctx, query, page)
+}
+
+func (sh *TicketSeedHandler) SeedAPIKey(ctx context.Context, randId string) error {
+	return sh.apiKeyService.SeedAPIKey(ctx, randId)
+}
+
+func (sh *TicketSeedHandler) SeedCategory(ctx context.Context, randId string) error {
+	return sh.categoryService.SeedCategory(ctx, randId)
+}
+
+func (sh *TicketSeedHandler) SeedCategories(ctx context.Context) error {
+	return sh.categoryService.SeedCategories(ctx)
+}
+
+func NewSelfSeedHandler(ticketService *ticket.TicketService, commentService *comment.CommentService, categoryService *category.CategoryService, apiKeyService *apikey.APIKeyService) *TicketSeedHandler {
 	return &TicketSeedHandler{
-		ticketService: ticketService,
+		ticketService:   ticketService,
+		commentService:  commentService,
+		categoryService: categoryService,
+		apiKeyService:   apiKeyService,
 	}
 }
 
-// Implement type GRPCSeedHandler here
+type GRPCSeedHandler struct {
+	conn   *grpc.ClientConn
+	client seeder.TicketSeederClient
+}
+
+func (gh *GRPCSeedHandler) SeedTickets(ctx context.Context, subtraction int64, lastRandId string) error {
+	_, err := gh.client.SeedTickets(ctx, &seeder.SeedTimelineRequest{Subtraction: subtraction, LastRandId: lastRandId})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedTicketBySecurityRisk(ctx context.Context, subtraction int64, lastRandId string) error {
+	_, err := gh.client.SeedTicketBySecurityRisk(ctx, &seeder.SeedTimelineRequest{Subtraction: subtraction, LastRandId: lastRandId})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedTicketsByCategory(ctx context.Context, subtraction int64, lastRandId string, categoryRandId string) error {
+	_, err := gh.client.SeedTicketsByCategory(ctx, &seeder.SeedTicketsByCategoryRequest{Subtraction: subtraction, LastRandId: lastRandId, CategoryRandId: categoryRandId})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedTicketsByStatus(ctx context.Context, subtraction int64, lastRandId string, status string) error {
+	_, err := gh.client.SeedTicketsByStatus(ctx, &seeder.SeedTicketsByStatusRequest{Subtraction: subtraction, LastRandId: lastRandId, Status: status})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedTicketsByFilter(ctx context.Context, subtraction int64, lastRandId string, filterKey string) error {
+	_, err := gh.client.SeedTicketsByFilter(ctx, &seeder.SeedTicketsByFilterRequest{Subtraction: subtraction, LastRandId: lastRandId, FilterKey: filterKey})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedByAccount(ctx context.Context, accountUUID string) error {
+	_, err := gh.client.SeedByAccount(ctx, &seeder.SeedByAccountRequest{AccountUuid: accountUUID})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedByAssignee(ctx context.Context, assigneeUUID string) error {
+	_, err := gh.client.SeedByAssignee(ctx, &seeder.SeedByAssigneeRequest{AssigneeUuid: assigneeUUID})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedTicket(ctx context.Context, randId string) error {
+	_, err := gh.client.SeedTicket(ctx, &seeder.SeedTicketRequest{RandId: randId})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedTicketsByPage(ctx context.Context, page int64) error {
+	_, err := gh.client.SeedTicketsByPage(ctx, &seeder.SeedTicketsByPageRequest{Page: page})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedTicketsByDate(ctx context.Context, lowerbound time.Time, upperbound time.Time) error {
+	_, err := gh.client.SeedTicketsByDate(ctx, &seeder.SeedTicketsByDateRequest{
+		Lowerbound: timestamppb.New(lowerbound),
+		Upperbound: timestamppb.New(upperbound),
+	})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedComments(ctx context.Context, subtraction int64, lastRandId string, ticketRandId string) error {
+	_, err := gh.client.SeedComments(ctx, &seeder.SeedCommentsRequest{Subtraction: subtraction, LastRandId: lastRandId, TicketRandId: ticketRandId})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedTicketSearch(ctx context.Context, query string, page int64) error {
+	_, err := gh.client.SeedTicketSearch(ctx, &seeder.SeedTicketSearchRequest{Query: query, Page: page})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedAPIKey(ctx context.Context, randId string) error {
+	_, err := gh.client.SeedAPIKey(ctx, &seeder.SeedAPIKeyRequest{RandId: randId})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedCategory(ctx context.Context, randId string) error {
+	_, err := gh.client.SeedCategory(ctx, &seeder.SeedCategoryRequest{RandId: randId})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) SeedCategories(ctx context.Context) error {
+	_, err := gh.client.SeedCategories(ctx, &seeder.SeedCategoriesRequest{})
+	return seedError(err)
+}
+
+func (gh *GRPCSeedHandler) Close() error {
+	return gh.conn.Close()
+}
+
+func seedError(err error) error {
+	if err != nil && status.Code(err) == codes.NotFound {
+		return definition.NotFound
+	}
+	return err
+}
+
+// NewGRPCSeedHandler dials the seeding service at target. token is sent on
+// every call when set, caFile switches the connection to TLS verified against
+// that CA.
+func NewGRPCSeedHandler(target string, token string, caFile string) (*GRPCSeedHandler, error) {
+	options := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if caFile != "" {
+		transport, errTLS := credentials.NewClientTLSFromFile(caFile, "")
+		if errTLS != nil {
+			return nil, errTLS
+		}
+		options[0] = grpc.WithTransportCredentials(transport)
+	}
+	if token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(seedToken{token: token, isSecure: caFile != ""}))
+	}
+
+	conn, err := grpc.NewClient(target, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPCSeedHandler{
+		conn:   conn,
+		client: seeder.NewTicketSeederClient(conn),
+	}, nil
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/pkg/ticket"
+)
+
+type BatchTicketsRequest struct {
+	Operations []ticket.BatchOperation `json:"operations"`
+}
+
+// BatchTicketResult carries the status and error code the single-ticket route
+// would have answered the operation with.
+type BatchTicketResult struct {
+	Index      int    `json:"index"`
+	Op         string `json:"op"`
+	TicketUUID string `json:"ticket_uuid"`
+	Status     int    `json:"status"`
+	Code       string `json:"code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type BatchTicketsResponse struct {
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BatchTicketResult `json:"results"`
+}
+
+// BatchTickets applies up to definition.BatchMaxOperations operations in one
+// transaction. Failed items do not fail the request, each result says how its
+// operation went.
+func (cud *TicketCUDController) BatchTickets(c *fiber.Ctx) error {
+	var reqBody BatchTicketsRequest
+	mainCtx := c.Context()
+
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "T100", "BatchTickets.BodyParser")
+	}
+	if len(reqBody.Operations) == 0 {
+		return logger.Error(c, fiber.StatusBadRequest, errors.New("operations is empty"), "T100", "BatchTickets.Validate")
+	}
+	if len(reqBody.Operations) > definition.BatchMaxOperations {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("at most %d operations per batch", definition.BatchMaxOperations), "T100", "BatchTickets.Validate")
+	}
+
+	var callerUUID string
+	if caller := CallerAccount(c); caller != nil {
+		callerUUID = caller.GetUUID()
+	}
+	isPrivileged := CallerIsAdmin(c) || CallerAPIKey(c) != nil
+
+	report, errBatch := cud.ticketService.Batch(mainCtx, callerUUID, isPrivileged, reqBody.Operations)
+	if errBatch != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errBatch, "T500", "BatchTickets.Batch")
+	}
+
+	response := BatchTicketsResponse{
+		Succeeded: report.Succeeded,
+		Failed:    len(report.Results) - report.Succeeded,
+		Results:   make([]BatchTicketResult, len(report.Results)),
+	}
+	for i, result := range report.Results {
+		response.Results[i] = BatchTicketResult{Index: i, Op: result.Op, TicketUUID: result.TicketUUID, Status: fiber.StatusOK}
+		if result.Err != nil {
+			response.Results[i].Status, response.Results[i].Code = batchErrorStatus(result.Err)
+			response.Results[i].Error = result.Err.Error()
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func batchErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, definition.InvalidOperation):
+		return fiber.StatusBadRequest, "T100"
+	case errors.Is(err, definition.Forbidden):
+		return fiber.StatusForbidden, "T403"
+	case errors.Is(err, definition.NotFound):
+		return fiber.StatusNotFound, "T404"
+	case errors.Is(err, definition.InvalidTransition):
+		return fiber.StatusConflict, "T409"
+	}
+	return fiber.StatusInternalServerError, "T500"
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/21strive/redifu"
+	"github.com/gofiber/fiber/v2"
+	"net/http/httptest"
+	"redifu-example/internal/model"
+	"testing"
+	"time"
+)
+
+// partialTimeline is a TicketReader whose default timeline holds fewer
+// tickets than a page and still needs a seed.
+type partialTimeline struct {
+	TicketReader
+	tickets []*model.Ticket
+}
+
+func (p *partialTimeline) GetTickets(ctx context.Context, lastRandId []string) ([]*model.Ticket, string, string, bool, error) {
+	return p.tickets, "", "", true, nil
+}
+
+// signallingSeeder reports every SeedTickets call on seeded.
+type signallingSeeder struct {
+	TicketSeeder
+	seeded chan string
+}
+
+func (s *signallingSeeder) SeedTickets(ctx context.Context, subtraction int64, lastRandId string) error {
+	s.seeded <- fmt.Sprintf("%d:%s", subtraction, lastRandId)
+	return nil
+}
+
+func getTimeline(t *testing.T, fetchController *TicketFetchController) (string, []*model.Ticket) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get("/ticket", fetchController.GetTickets)
+	response, errRequest := app.Test(httptest.NewRequest("GET", "/ticket", nil))
+	if errRequest != nil {
+		t.Fatal(errRequest)
+	}
+	defer response.Body.Close()
+
+	var body struct {
+		Tickets []*model.Ticket `json:"tickets"`
+	}
+	if errDecode := json.NewDecoder(response.Body).Decode(&body); errDecode != nil {
+		t.Fatal(errDecode)
+	}
+	return response.Header.Get("X-Cache"), body.Tickets
+}
+
+func partialTimelineTickets() []*model.Ticket {
+	var tickets []*model.Ticket
+	for i := 1; i <= 2; i++ {
+		tickets = append(tickets, &model.Ticket{
+			Record: &redifu.Record{RandId: fmt.Sprintf("tkt000%d", i), CreatedAt: time.Date(2026, 3, i, 0, 0, 0, 0, time.UTC)},
+			Status: model.StatusOpen,
+		})
+	}
+	return tickets
+}
+
+func TestGetTicketsServesStaleTimeline(t *testing.T) {
+	backgroundSeeder := NewBackgroundSeeder(1, 4)
+	defer backgroundSeeder.Close()
+	seeder := &signallingSeeder{seeded: make(chan string, 1)}
+	fetchController := NewTicketFetchController(&partialTimeline{tickets: partialTimelineTickets()}, seeder, backgroundSeeder)
+
+	cache, tickets := getTimeline(t, fetchController)
+
+	if cache != "stale" {
+		t.Errorf("X-Cache %q, want stale", cache)
+	}
+	if len(tickets) != 2 {
+		t.Errorf("served %d tickets, want the 2 cached", len(tickets))
+	}
+	select {
+	case key := <-seeder.seeded:
+		if key != "2:" {
+			t.Errorf("background seed after %q, want it after the 2 cached tickets", key)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("the timeline was never seeded in the background")
+	}
+}
+
+func TestGetTicketsSeedsInlineWithoutBackgroundSeeder(t *testing.T) {
+	seeder := &signallingSeeder{seeded: make(chan string, 1)}
+	fetchController := NewTicketFetchController(&partialTimeline{tickets: partialTimelineTickets()}, seeder, nil)
+
+	cache, _ := getTimeline(t, fetchController)
+
+	if cache != "" {
+		t.Errorf("X-Cache %q, want none", cache)
+	}
+	select {
+	case <-seeder.seeded:
+	default:
+		t.Error("the timeline was not seeded inline")
+	}
+}
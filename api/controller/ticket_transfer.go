@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"io"
+	"net/url"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/internal/model"
+	"redifu-example/pkg/ticket"
+)
+
+// exportFlushEvery is how many rows an export buffers before pushing them to
+// the client.
+const exportFlushEvery = 500
+
+// TicketTransferController moves tickets in and out in bulk. Both directions
+// cover every reporter, so only admins and API keys may use them.
+type TicketTransferController struct {
+	ticketService *ticket.TicketService
+}
+
+// ImportTickets reads a CSV or NDJSON body, picked by Content-Type or the
+// format query parameter, as it streams in and answers with the per-row report.
+func (tc *TicketTransferController) ImportTickets(c *fiber.Ctx) error {
+	if !CallerIsAdmin(c) && CallerAPIKey(c) == nil {
+		return logger.Error(c, fiber.StatusForbidden, errors.New("admin role or API key is required"), "T403", "ImportTickets.Role")
+	}
+
+	format := c.Query("format", ticket.FormatFromContentType(c.Get(fiber.HeaderContentType)))
+
+	// without StreamRequestBody the body is already buffered in full
+	var body io.Reader = c.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(c.Body())
+	}
+
+	rows, errReader := ticket.NewRowReader(format, body)
+	if errReader != nil {
+		return logger.Error(c, fiber.StatusBadRequest, errReader, "T100", "ImportTickets.Format")
+	}
+
+	report, errImport := tc.ticketService.ImportTickets(c.Context(), rows)
+	if errImport != nil {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("import stopped after %d rows: %w", report.Imported, errImport), "T100", "ImportTickets.Read")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// ExportTickets streams every ticket matching the GET /ticket filter
+// parameters as CSV or NDJSON, picked by the format query parameter or Accept.
+func (tc *TicketTransferController) ExportTickets(c *fiber.Ctx) error {
+	if !CallerIsAdmin(c) && CallerAPIKey(c) == nil {
+		return logger.Error(c, fiber.StatusForbidden, errors.New("admin role or API key is required"), "T403", "ExportTickets.Role")
+	}
+
+	format := c.Query("format", ticket.FormatFromContentType(c.Get(fiber.HeaderAccept)))
+	if format == "" {
+		format = ticket.FormatNDJSON
+	}
+
+	values, errParse := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if errParse != nil {
+		return logger.Error(c, fiber.StatusBadRequest, errParse, "T100", "ExportTickets.Params")
+	}
+	filter, errFilter := ticket.ParseFilter(values)
+	if errFilter != nil {
+		return logger.Error(c, fiber.StatusBadRequest, errFilter, "T100", "ExportTickets.Filter")
+	}
+
+	export, errPrepare := tc.ticketService.PrepareExport(c.Context(), filter)
+	if errPrepare != nil {
+		if errors.Is(errPrepare, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errPrepare, "T404", "ExportTickets.Category")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errPrepare, "T500", "ExportTickets.Prepare")
+	}
+
+	switch format {
+	case ticket.FormatCSV:
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	case ticket.FormatNDJSON:
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	default:
+		return logger.Error(c, fiber.StatusBadRequest, ticket.UnsupportedFormat, "T100", "ExportTickets.Format")
+	}
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="tickets.%s"`, format))
+
+	// the writer runs after the handler returns, the status is sent by then so
+	// a failure halfway can only be logged and cut the body short
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if errExport := writeExport(context.Background(), w, export, format); errExport != nil {
+			logger.Logger.Error("ticket-export-error", "error", errExport.Error())
+		}
+	})
+	return nil
+}
+
+func writeExport(ctx context.Context, w *bufio.Writer, export *ticket.Export, format string) error {
+	writer, errWriter := ticket.NewRowWriter(format, w)
+	if errWriter != nil {
+		return errWriter
+	}
+
+	written := 0
+	errEach := export.Each(ctx, func(exported *model.Ticket) error {
+		if errWrite := writer.Write(exported); errWrite != nil {
+			return errWrite
+		}
+		written++
+		if written%exportFlushEvery != 0 {
+			return nil
+		}
+		// a failed flush means the client went away, stop reading rows
+		if errFlush := writer.Flush(); errFlush != nil {
+			return errFlush
+		}
+		return w.Flush()
+	})
+	if errEach != nil {
+		return errEach
+	}
+
+	if errFlush := writer.Flush(); errFlush != nil {
+		return errFlush
+	}
+	return w.Flush()
+}
+
+func NewTicketTransferController(ticketService *ticket.TicketService) *TicketTransferController {
+	return &TicketTransferController{ticketService: ticketService}
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/pkg/webhook"
+)
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+type WebhookController struct {
+	webhookService *webhook.WebhookService
+}
+
+func (wc *WebhookController) CreateWebhook(c *fiber.Ctx) error {
+	mainCtx := c.Context()
+
+	var reqBody CreateWebhookRequest
+	if err := c.BodyParser(&reqBody); err != nil {
+		return logger.Error(c, fiber.StatusBadRequest, err, "W100", "CreateWebhook.BodyParser")
+	}
+
+	subscription, errSubscribe := wc.webhookService.Subscribe(mainCtx, reqBody.URL, reqBody.EventTypes)
+	if errSubscribe != nil {
+		if errors.Is(errSubscribe, webhook.InvalidSubscription) {
+			return logger.Error(c, fiber.StatusBadRequest, errSubscribe, "W100", "CreateWebhook.Validate")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errSubscribe, "W500", "CreateWebhook.Subscribe")
+	}
+
+	// the secret is not part of the model's JSON, this is the only response carrying it
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"subscription": subscription,
+		"secret":       subscription.Secret,
+	})
+}
+
+func (wc *WebhookController) GetWebhooks(c *fiber.Ctx) error {
+	subscriptions, errList := wc.webhookService.GetSubscriptions(c.Context())
+	if errList != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errList, "W500", "GetWebhooks.List")
+	}
+
+	return c.JSON(subscriptions)
+}
+
+func (wc *WebhookController) DeleteWebhook(c *fiber.Ctx) error {
+	subscriptionUUID := c.Params("uuid")
+	if subscriptionUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("uuid is empty"), "W100", "DeleteWebhook.Params")
+	}
+
+	errDelete := wc.webhookService.Unsubscribe(c.Context(), subscriptionUUID)
+	if errDelete != nil {
+		if errors.Is(errDelete, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errDelete, "W404", "DeleteWebhook.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errDelete, "W500", "DeleteWebhook.Delete")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (wc *WebhookController) GetDeliveries(c *fiber.Ctx) error {
+	subscriptionUUID := c.Params("uuid")
+	if subscriptionUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("uuid is empty"), "W100", "GetDeliveries.Params")
+	}
+
+	deliveries, errList := wc.webhookService.GetDeliveries(c.Context(), subscriptionUUID, c.QueryInt("limit", 50))
+	if errList != nil {
+		if errors.Is(errList, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errList, "W404", "GetDeliveries.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errList, "W500", "GetDeliveries.List")
+	}
+
+	return c.JSON(deliveries)
+}
+
+func (wc *WebhookController) GetDeadLetters(c *fiber.Ctx) error {
+	deliveries, errList := wc.webhookService.GetDeadLetters(c.Context(), c.QueryInt("limit", 50))
+	if errList != nil {
+		return logger.Error(c, fiber.StatusInternalServerError, errList, "W500", "GetDeadLetters.List")
+	}
+
+	return c.JSON(deliveries)
+}
+
+func (wc *WebhookController) RetryDelivery(c *fiber.Ctx) error {
+	deliveryUUID := c.Params("deliveryUUID")
+	if deliveryUUID == "" {
+		return logger.Error(c, fiber.StatusBadRequest, fmt.Errorf("deliveryUUID is empty"), "W100", "RetryDelivery.Params")
+	}
+
+	errRetry := wc.webhookService.Redeliver(c.Context(), deliveryUUID)
+	if errRetry != nil {
+		if errors.Is(errRetry, definition.NotFound) {
+			return logger.Error(c, fiber.StatusNotFound, errRetry, "W404", "RetryDelivery.NotFound")
+		}
+		return logger.Error(c, fiber.StatusInternalServerError, errRetry, "W500", "RetryDelivery.Redeliver")
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+func NewWebhookController(webhookService *webhook.WebhookService) *WebhookController {
+	return &WebhookController{webhookService: webhookService}
+}
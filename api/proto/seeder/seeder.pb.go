@@ -0,0 +1,923 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: seeder/seeder.proto
+
+package seeder
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SeedTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtraction   int64                  `protobuf:"varint,1,opt,name=subtraction,proto3" json:"subtraction,omitempty"`
+	LastRandId    string                 `protobuf:"bytes,2,opt,name=last_rand_id,json=lastRandId,proto3" json:"last_rand_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTimelineRequest) Reset() {
+	*x = SeedTimelineRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTimelineRequest) ProtoMessage() {}
+
+func (x *SeedTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTimelineRequest.ProtoReflect.Descriptor instead.
+func (*SeedTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SeedTimelineRequest) GetSubtraction() int64 {
+	if x != nil {
+		return x.Subtraction
+	}
+	return 0
+}
+
+func (x *SeedTimelineRequest) GetLastRandId() string {
+	if x != nil {
+		return x.LastRandId
+	}
+	return ""
+}
+
+type SeedTicketsByCategoryRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Subtraction    int64                  `protobuf:"varint,1,opt,name=subtraction,proto3" json:"subtraction,omitempty"`
+	LastRandId     string                 `protobuf:"bytes,2,opt,name=last_rand_id,json=lastRandId,proto3" json:"last_rand_id,omitempty"`
+	CategoryRandId string                 `protobuf:"bytes,3,opt,name=category_rand_id,json=categoryRandId,proto3" json:"category_rand_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SeedTicketsByCategoryRequest) Reset() {
+	*x = SeedTicketsByCategoryRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTicketsByCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTicketsByCategoryRequest) ProtoMessage() {}
+
+func (x *SeedTicketsByCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTicketsByCategoryRequest.ProtoReflect.Descriptor instead.
+func (*SeedTicketsByCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SeedTicketsByCategoryRequest) GetSubtraction() int64 {
+	if x != nil {
+		return x.Subtraction
+	}
+	return 0
+}
+
+func (x *SeedTicketsByCategoryRequest) GetLastRandId() string {
+	if x != nil {
+		return x.LastRandId
+	}
+	return ""
+}
+
+func (x *SeedTicketsByCategoryRequest) GetCategoryRandId() string {
+	if x != nil {
+		return x.CategoryRandId
+	}
+	return ""
+}
+
+type SeedTicketsByStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtraction   int64                  `protobuf:"varint,1,opt,name=subtraction,proto3" json:"subtraction,omitempty"`
+	LastRandId    string                 `protobuf:"bytes,2,opt,name=last_rand_id,json=lastRandId,proto3" json:"last_rand_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTicketsByStatusRequest) Reset() {
+	*x = SeedTicketsByStatusRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTicketsByStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTicketsByStatusRequest) ProtoMessage() {}
+
+func (x *SeedTicketsByStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTicketsByStatusRequest.ProtoReflect.Descriptor instead.
+func (*SeedTicketsByStatusRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SeedTicketsByStatusRequest) GetSubtraction() int64 {
+	if x != nil {
+		return x.Subtraction
+	}
+	return 0
+}
+
+func (x *SeedTicketsByStatusRequest) GetLastRandId() string {
+	if x != nil {
+		return x.LastRandId
+	}
+	return ""
+}
+
+func (x *SeedTicketsByStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SeedTicketsByFilterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtraction   int64                  `protobuf:"varint,1,opt,name=subtraction,proto3" json:"subtraction,omitempty"`
+	LastRandId    string                 `protobuf:"bytes,2,opt,name=last_rand_id,json=lastRandId,proto3" json:"last_rand_id,omitempty"`
+	FilterKey     string                 `protobuf:"bytes,3,opt,name=filter_key,json=filterKey,proto3" json:"filter_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTicketsByFilterRequest) Reset() {
+	*x = SeedTicketsByFilterRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTicketsByFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTicketsByFilterRequest) ProtoMessage() {}
+
+func (x *SeedTicketsByFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTicketsByFilterRequest.ProtoReflect.Descriptor instead.
+func (*SeedTicketsByFilterRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SeedTicketsByFilterRequest) GetSubtraction() int64 {
+	if x != nil {
+		return x.Subtraction
+	}
+	return 0
+}
+
+func (x *SeedTicketsByFilterRequest) GetLastRandId() string {
+	if x != nil {
+		return x.LastRandId
+	}
+	return ""
+}
+
+func (x *SeedTicketsByFilterRequest) GetFilterKey() string {
+	if x != nil {
+		return x.FilterKey
+	}
+	return ""
+}
+
+type SeedByAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountUuid   string                 `protobuf:"bytes,1,opt,name=account_uuid,json=accountUuid,proto3" json:"account_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedByAccountRequest) Reset() {
+	*x = SeedByAccountRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedByAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedByAccountRequest) ProtoMessage() {}
+
+func (x *SeedByAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedByAccountRequest.ProtoReflect.Descriptor instead.
+func (*SeedByAccountRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SeedByAccountRequest) GetAccountUuid() string {
+	if x != nil {
+		return x.AccountUuid
+	}
+	return ""
+}
+
+type SeedByAssigneeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssigneeUuid  string                 `protobuf:"bytes,1,opt,name=assignee_uuid,json=assigneeUuid,proto3" json:"assignee_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedByAssigneeRequest) Reset() {
+	*x = SeedByAssigneeRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedByAssigneeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedByAssigneeRequest) ProtoMessage() {}
+
+func (x *SeedByAssigneeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedByAssigneeRequest.ProtoReflect.Descriptor instead.
+func (*SeedByAssigneeRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SeedByAssigneeRequest) GetAssigneeUuid() string {
+	if x != nil {
+		return x.AssigneeUuid
+	}
+	return ""
+}
+
+type SeedTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RandId        string                 `protobuf:"bytes,1,opt,name=rand_id,json=randId,proto3" json:"rand_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTicketRequest) Reset() {
+	*x = SeedTicketRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTicketRequest) ProtoMessage() {}
+
+func (x *SeedTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTicketRequest.ProtoReflect.Descriptor instead.
+func (*SeedTicketRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SeedTicketRequest) GetRandId() string {
+	if x != nil {
+		return x.RandId
+	}
+	return ""
+}
+
+type SeedTicketsByPageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int64                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTicketsByPageRequest) Reset() {
+	*x = SeedTicketsByPageRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTicketsByPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTicketsByPageRequest) ProtoMessage() {}
+
+func (x *SeedTicketsByPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTicketsByPageRequest.ProtoReflect.Descriptor instead.
+func (*SeedTicketsByPageRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SeedTicketsByPageRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type SeedTicketsByDateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lowerbound    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=lowerbound,proto3" json:"lowerbound,omitempty"`
+	Upperbound    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=upperbound,proto3" json:"upperbound,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTicketsByDateRequest) Reset() {
+	*x = SeedTicketsByDateRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTicketsByDateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTicketsByDateRequest) ProtoMessage() {}
+
+func (x *SeedTicketsByDateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTicketsByDateRequest.ProtoReflect.Descriptor instead.
+func (*SeedTicketsByDateRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SeedTicketsByDateRequest) GetLowerbound() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Lowerbound
+	}
+	return nil
+}
+
+func (x *SeedTicketsByDateRequest) GetUpperbound() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Upperbound
+	}
+	return nil
+}
+
+type SeedCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtraction   int64                  `protobuf:"varint,1,opt,name=subtraction,proto3" json:"subtraction,omitempty"`
+	LastRandId    string                 `protobuf:"bytes,2,opt,name=last_rand_id,json=lastRandId,proto3" json:"last_rand_id,omitempty"`
+	TicketRandId  string                 `protobuf:"bytes,3,opt,name=ticket_rand_id,json=ticketRandId,proto3" json:"ticket_rand_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedCommentsRequest) Reset() {
+	*x = SeedCommentsRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedCommentsRequest) ProtoMessage() {}
+
+func (x *SeedCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedCommentsRequest.ProtoReflect.Descriptor instead.
+func (*SeedCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SeedCommentsRequest) GetSubtraction() int64 {
+	if x != nil {
+		return x.Subtraction
+	}
+	return 0
+}
+
+func (x *SeedCommentsRequest) GetLastRandId() string {
+	if x != nil {
+		return x.LastRandId
+	}
+	return ""
+}
+
+func (x *SeedCommentsRequest) GetTicketRandId() string {
+	if x != nil {
+		return x.TicketRandId
+	}
+	return ""
+}
+
+type SeedTicketSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTicketSearchRequest) Reset() {
+	*x = SeedTicketSearchRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTicketSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTicketSearchRequest) ProtoMessage() {}
+
+func (x *SeedTicketSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTicketSearchRequest.ProtoReflect.Descriptor instead.
+func (*SeedTicketSearchRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SeedTicketSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SeedTicketSearchRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type SeedAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RandId        string                 `protobuf:"bytes,1,opt,name=rand_id,json=randId,proto3" json:"rand_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedAPIKeyRequest) Reset() {
+	*x = SeedAPIKeyRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedAPIKeyRequest) ProtoMessage() {}
+
+func (x *SeedAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*SeedAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SeedAPIKeyRequest) GetRandId() string {
+	if x != nil {
+		return x.RandId
+	}
+	return ""
+}
+
+type SeedCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RandId        string                 `protobuf:"bytes,1,opt,name=rand_id,json=randId,proto3" json:"rand_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedCategoryRequest) Reset() {
+	*x = SeedCategoryRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedCategoryRequest) ProtoMessage() {}
+
+func (x *SeedCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedCategoryRequest.ProtoReflect.Descriptor instead.
+func (*SeedCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SeedCategoryRequest) GetRandId() string {
+	if x != nil {
+		return x.RandId
+	}
+	return ""
+}
+
+type SeedCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedCategoriesRequest) Reset() {
+	*x = SeedCategoriesRequest{}
+	mi := &file_seeder_seeder_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedCategoriesRequest) ProtoMessage() {}
+
+func (x *SeedCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*SeedCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{13}
+}
+
+type SeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedResponse) Reset() {
+	*x = SeedResponse{}
+	mi := &file_seeder_seeder_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedResponse) ProtoMessage() {}
+
+func (x *SeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_seeder_seeder_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedResponse.ProtoReflect.Descriptor instead.
+func (*SeedResponse) Descriptor() ([]byte, []int) {
+	return file_seeder_seeder_proto_rawDescGZIP(), []int{14}
+}
+
+var File_seeder_seeder_proto protoreflect.FileDescriptor
+
+const file_seeder_seeder_proto_rawDesc = "" +
+	"\n" +
+	"\x13seeder/seeder.proto\x12\x06seeder\x1a\x1fgoogle/protobuf/timestamp.proto\"Y\n" +
+	"\x13SeedTimelineRequest\x12 \n" +
+	"\vsubtraction\x18\x01 \x01(\x03R\vsubtraction\x12 \n" +
+	"\flast_rand_id\x18\x02 \x01(\tR\n" +
+	"lastRandId\"\x8c\x01\n" +
+	"\x1cSeedTicketsByCategoryRequest\x12 \n" +
+	"\vsubtraction\x18\x01 \x01(\x03R\vsubtraction\x12 \n" +
+	"\flast_rand_id\x18\x02 \x01(\tR\n" +
+	"lastRandId\x12(\n" +
+	"\x10category_rand_id\x18\x03 \x01(\tR\x0ecategoryRandId\"x\n" +
+	"\x1aSeedTicketsByStatusRequest\x12 \n" +
+	"\vsubtraction\x18\x01 \x01(\x03R\vsubtraction\x12 \n" +
+	"\flast_rand_id\x18\x02 \x01(\tR\n" +
+	"lastRandId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\x7f\n" +
+	"\x1aSeedTicketsByFilterRequest\x12 \n" +
+	"\vsubtraction\x18\x01 \x01(\x03R\vsubtraction\x12 \n" +
+	"\flast_rand_id\x18\x02 \x01(\tR\n" +
+	"lastRandId\x12\x1d\n" +
+	"\n" +
+	"filter_key\x18\x03 \x01(\tR\tfilterKey\"9\n" +
+	"\x14SeedByAccountRequest\x12!\n" +
+	"\faccount_uuid\x18\x01 \x01(\tR\vaccountUuid\"<\n" +
+	"\x15SeedByAssigneeRequest\x12#\n" +
+	"\rassignee_uuid\x18\x01 \x01(\tR\fassigneeUuid\",\n" +
+	"\x11SeedTicketRequest\x12\x17\n" +
+	"\arand_id\x18\x01 \x01(\tR\x06randId\".\n" +
+	"\x18SeedTicketsByPageRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x03R\x04page\"\x92\x01\n" +
+	"\x18SeedTicketsByDateRequest\x12:\n" +
+	"\n" +
+	"lowerbound\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lowerbound\x12:\n" +
+	"\n" +
+	"upperbound\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"upperbound\"\x7f\n" +
+	"\x13SeedCommentsRequest\x12 \n" +
+	"\vsubtraction\x18\x01 \x01(\x03R\vsubtraction\x12 \n" +
+	"\flast_rand_id\x18\x02 \x01(\tR\n" +
+	"lastRandId\x12$\n" +
+	"\x0eticket_rand_id\x18\x03 \x01(\tR\fticketRandId\"C\n" +
+	"\x17SeedTicketSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\",\n" +
+	"\x11SeedAPIKeyRequest\x12\x17\n" +
+	"\arand_id\x18\x01 \x01(\tR\x06randId\".\n" +
+	"\x13SeedCategoryRequest\x12\x17\n" +
+	"\arand_id\x18\x01 \x01(\tR\x06randId\"\x17\n" +
+	"\x15SeedCategoriesRequest\"\x0e\n" +
+	"\fSeedResponse2\xd2\b\n" +
+	"\fTicketSeeder\x12@\n" +
+	"\vSeedTickets\x12\x1b.seeder.SeedTimelineRequest\x1a\x14.seeder.SeedResponse\x12M\n" +
+	"\x18SeedTicketBySecurityRisk\x12\x1b.seeder.SeedTimelineRequest\x1a\x14.seeder.SeedResponse\x12S\n" +
+	"\x15SeedTicketsByCategory\x12$.seeder.SeedTicketsByCategoryRequest\x1a\x14.seeder.SeedResponse\x12O\n" +
+	"\x13SeedTicketsByStatus\x12\".seeder.SeedTicketsByStatusRequest\x1a\x14.seeder.SeedResponse\x12O\n" +
+	"\x13SeedTicketsByFilter\x12\".seeder.SeedTicketsByFilterRequest\x1a\x14.seeder.SeedResponse\x12C\n" +
+	"\rSeedByAccount\x12\x1c.seeder.SeedByAccountRequest\x1a\x14.seeder.SeedResponse\x12E\n" +
+	"\x0eSeedByAssignee\x12\x1d.seeder.SeedByAssigneeRequest\x1a\x14.seeder.SeedResponse\x12=\n" +
+	"\n" +
+	"SeedTicket\x12\x19.seeder.SeedTicketRequest\x1a\x14.seeder.SeedResponse\x12K\n" +
+	"\x11SeedTicketsByPage\x12 .seeder.SeedTicketsByPageRequest\x1a\x14.seeder.SeedResponse\x12K\n" +
+	"\x11SeedTicketsByDate\x12 .seeder.SeedTicketsByDateRequest\x1a\x14.seeder.SeedResponse\x12A\n" +
+	"\fSeedComments\x12\x1b.seeder.SeedCommentsRequest\x1a\x14.seeder.SeedResponse\x12I\n" +
+	"\x10SeedTicketSearch\x12\x1f.seeder.SeedTicketSearchRequest\x1a\x14.seeder.SeedResponse\x12=\n" +
+	"\n" +
+	"SeedAPIKey\x12\x19.seeder.SeedAPIKeyRequest\x1a\x14.seeder.SeedResponse\x12A\n" +
+	"\fSeedCategory\x12\x1b.seeder.SeedCategoryRequest\x1a\x14.seeder.SeedResponse\x12E\n" +
+	"\x0eSeedCategories\x12\x1d.seeder.SeedCategoriesRequest\x1a\x14.seeder.SeedResponseB(Z&redifu-example/api/proto/seeder;seederb\x06proto3"
+
+var (
+	file_seeder_seeder_proto_rawDescOnce sync.Once
+	file_seeder_seeder_proto_rawDescData []byte
+)
+
+func file_seeder_seeder_proto_rawDescGZIP() []byte {
+	file_seeder_seeder_proto_rawDescOnce.Do(func() {
+		file_seeder_seeder_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_seeder_seeder_proto_rawDesc), len(file_seeder_seeder_proto_rawDesc)))
+	})
+	return file_seeder_seeder_proto_rawDescData
+}
+
+var file_seeder_seeder_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_seeder_seeder_proto_goTypes = []any{
+	(*SeedTimelineRequest)(nil),          // 0: seeder.SeedTimelineRequest
+	(*SeedTicketsByCategoryRequest)(nil), // 1: seeder.SeedTicketsByCategoryRequest
+	(*SeedTicketsByStatusRequest)(nil),   // 2: seeder.SeedTicketsByStatusRequest
+	(*SeedTicketsByFilterRequest)(nil),   // 3: seeder.SeedTicketsByFilterRequest
+	(*SeedByAccountRequest)(nil),         // 4: seeder.SeedByAccountRequest
+	(*SeedByAssigneeRequest)(nil),        // 5: seeder.SeedByAssigneeRequest
+	(*SeedTicketRequest)(nil),            // 6: seeder.SeedTicketRequest
+	(*SeedTicketsByPageRequest)(nil),     // 7: seeder.SeedTicketsByPageRequest
+	(*SeedTicketsByDateRequest)(nil),     // 8: seeder.SeedTicketsByDateRequest
+	(*SeedCommentsRequest)(nil),          // 9: seeder.SeedCommentsRequest
+	(*SeedTicketSearchRequest)(nil),      // 10: seeder.SeedTicketSearchRequest
+	(*SeedAPIKeyRequest)(nil),            // 11: seeder.SeedAPIKeyRequest
+	(*SeedCategoryRequest)(nil),          // 12: seeder.SeedCategoryRequest
+	(*SeedCategoriesRequest)(nil),        // 13: seeder.SeedCategoriesRequest
+	(*SeedResponse)(nil),                 // 14: seeder.SeedResponse
+	(*timestamppb.Timestamp)(nil),        // 15: google.protobuf.Timestamp
+}
+var file_seeder_seeder_proto_depIdxs = []int32{
+	15, // 0: seeder.SeedTicketsByDateRequest.lowerbound:type_name -> google.protobuf.Timestamp
+	15, // 1: seeder.SeedTicketsByDateRequest.upperbound:type_name -> google.protobuf.Timestamp
+	0,  // 2: seeder.TicketSeeder.SeedTickets:input_type -> seeder.SeedTimelineRequest
+	0,  // 3: seeder.TicketSeeder.SeedTicketBySecurityRisk:input_type -> seeder.SeedTimelineRequest
+	1,  // 4: seeder.TicketSeeder.SeedTicketsByCategory:input_type -> seeder.SeedTicketsByCategoryRequest
+	2,  // 5: seeder.TicketSeeder.SeedTicketsByStatus:input_type -> seeder.SeedTicketsByStatusRequest
+	3,  // 6: seeder.TicketSeeder.SeedTicketsByFilter:input_type -> seeder.SeedTicketsByFilterRequest
+	4,  // 7: seeder.TicketSeeder.SeedByAccount:input_type -> seeder.SeedByAccountRequest
+	5,  // 8: seeder.TicketSeeder.SeedByAssignee:input_type -> seeder.SeedByAssigneeRequest
+	6,  // 9: seeder.TicketSeeder.SeedTicket:input_type -> seeder.SeedTicketRequest
+	7,  // 10: seeder.TicketSeeder.SeedTicketsByPage:input_type -> seeder.SeedTicketsByPageRequest
+	8,  // 11: seeder.TicketSeeder.SeedTicketsByDate:input_type -> seeder.SeedTicketsByDateRequest
+	9,  // 12: seeder.TicketSeeder.SeedComments:input_type -> seeder.SeedCommentsRequest
+	10, // 13: seeder.TicketSeeder.SeedTicketSearch:input_type -> seeder.SeedTicketSearchRequest
+	11, // 14: seeder.TicketSeeder.SeedAPIKey:input_type -> seeder.SeedAPIKeyRequest
+	12, // 15: seeder.TicketSeeder.SeedCategory:input_type -> seeder.SeedCategoryRequest
+	13, // 16: seeder.TicketSeeder.SeedCategories:input_type -> seeder.SeedCategoriesRequest
+	14, // 17: seeder.TicketSeeder.SeedTickets:output_type -> seeder.SeedResponse
+	14, // 18: seeder.TicketSeeder.SeedTicketBySecurityRisk:output_type -> seeder.SeedResponse
+	14, // 19: seeder.TicketSeeder.SeedTicketsByCategory:output_type -> seeder.SeedResponse
+	14, // 20: seeder.TicketSeeder.SeedTicketsByStatus:output_type -> seeder.SeedResponse
+	14, // 21: seeder.TicketSeeder.SeedTicketsByFilter:output_type -> seeder.SeedResponse
+	14, // 22: seeder.TicketSeeder.SeedByAccount:output_type -> seeder.SeedResponse
+	14, // 23: seeder.TicketSeeder.SeedByAssignee:output_type -> seeder.SeedResponse
+	14, // 24: seeder.TicketSeeder.SeedTicket:output_type -> seeder.SeedResponse
+	14, // 25: seeder.TicketSeeder.SeedTicketsByPage:output_type -> seeder.SeedResponse
+	14, // 26: seeder.TicketSeeder.SeedTicketsByDate:output_type -> seeder.SeedResponse
+	14, // 27: seeder.TicketSeeder.SeedComments:output_type -> seeder.SeedResponse
+	14, // 28: seeder.TicketSeeder.SeedTicketSearch:output_type -> seeder.SeedResponse
+	14, // 29: seeder.TicketSeeder.SeedAPIKey:output_type -> seeder.SeedResponse
+	14, // 30: seeder.TicketSeeder.SeedCategory:output_type -> seeder.SeedResponse
+	14, // 31: seeder.TicketSeeder.SeedCategories:output_type -> seeder.SeedResponse
+	17, // [17:32] is the sub-list for method output_type
+	2,  // [2:17] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_seeder_seeder_proto_init() }
+func file_seeder_seeder_proto_init() {
+	if File_seeder_seeder_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_seeder_seeder_proto_rawDesc), len(file_seeder_seeder_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_seeder_seeder_proto_goTypes,
+		DependencyIndexes: file_seeder_seeder_proto_depIdxs,
+		MessageInfos:      file_seeder_seeder_proto_msgTypes,
+	}.Build()
+	File_seeder_seeder_proto = out.File
+	file_seeder_seeder_proto_goTypes = nil
+	file_seeder_seeder_proto_depIdxs = nil
+}
@@ -0,0 +1,653 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: seeder/seeder.proto
+
+package seeder
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TicketSeeder_SeedTickets_FullMethodName              = "/seeder.TicketSeeder/SeedTickets"
+	TicketSeeder_SeedTicketBySecurityRisk_FullMethodName = "/seeder.TicketSeeder/SeedTicketBySecurityRisk"
+	TicketSeeder_SeedTicketsByCategory_FullMethodName    = "/seeder.TicketSeeder/SeedTicketsByCategory"
+	TicketSeeder_SeedTicketsByStatus_FullMethodName      = "/seeder.TicketSeeder/SeedTicketsByStatus"
+	TicketSeeder_SeedTicketsByFilter_FullMethodName      = "/seeder.TicketSeeder/SeedTicketsByFilter"
+	TicketSeeder_SeedByAccount_FullMethodName            = "/seeder.TicketSeeder/SeedByAccount"
+	TicketSeeder_SeedByAssignee_FullMethodName           = "/seeder.TicketSeeder/SeedByAssignee"
+	TicketSeeder_SeedTicket_FullMethodName               = "/seeder.TicketSeeder/SeedTicket"
+	TicketSeeder_SeedTicketsByPage_FullMethodName        = "/seeder.TicketSeeder/SeedTicketsByPage"
+	TicketSeeder_SeedTicketsByDate_FullMethodName        = "/seeder.TicketSeeder/SeedTicketsByDate"
+	TicketSeeder_SeedComments_FullMethodName             = "/seeder.TicketSeeder/SeedComments"
+	TicketSeeder_SeedTicketSearch_FullMethodName         = "/seeder.TicketSeeder/SeedTicketSearch"
+	TicketSeeder_SeedAPIKey_FullMethodName               = "/seeder.TicketSeeder/SeedAPIKey"
+	TicketSeeder_SeedCategory_FullMethodName             = "/seeder.TicketSeeder/SeedCategory"
+	TicketSeeder_SeedCategories_FullMethodName           = "/seeder.TicketSeeder/SeedCategories"
+)
+
+// TicketSeederClient is the client API for TicketSeeder service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TicketSeederClient interface {
+	SeedTickets(ctx context.Context, in *SeedTimelineRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedTicketBySecurityRisk(ctx context.Context, in *SeedTimelineRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedTicketsByCategory(ctx context.Context, in *SeedTicketsByCategoryRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedTicketsByStatus(ctx context.Context, in *SeedTicketsByStatusRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedTicketsByFilter(ctx context.Context, in *SeedTicketsByFilterRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedByAccount(ctx context.Context, in *SeedByAccountRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedByAssignee(ctx context.Context, in *SeedByAssigneeRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedTicket(ctx context.Context, in *SeedTicketRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedTicketsByPage(ctx context.Context, in *SeedTicketsByPageRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedTicketsByDate(ctx context.Context, in *SeedTicketsByDateRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedComments(ctx context.Context, in *SeedCommentsRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedTicketSearch(ctx context.Context, in *SeedTicketSearchRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedAPIKey(ctx context.Context, in *SeedAPIKeyRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedCategory(ctx context.Context, in *SeedCategoryRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+	SeedCategories(ctx context.Context, in *SeedCategoriesRequest, opts ...grpc.CallOption) (*SeedResponse, error)
+}
+
+type ticketSeederClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTicketSeederClient(cc grpc.ClientConnInterface) TicketSeederClient {
+	return &ticketSeederClient{cc}
+}
+
+func (c *ticketSeederClient) SeedTickets(ctx context.Context, in *SeedTimelineRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedTicketBySecurityRisk(ctx context.Context, in *SeedTimelineRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTicketBySecurityRisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedTicketsByCategory(ctx context.Context, in *SeedTicketsByCategoryRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTicketsByCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedTicketsByStatus(ctx context.Context, in *SeedTicketsByStatusRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTicketsByStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedTicketsByFilter(ctx context.Context, in *SeedTicketsByFilterRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTicketsByFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedByAccount(ctx context.Context, in *SeedByAccountRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedByAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedByAssignee(ctx context.Context, in *SeedByAssigneeRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedByAssignee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedTicket(ctx context.Context, in *SeedTicketRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedTicketsByPage(ctx context.Context, in *SeedTicketsByPageRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTicketsByPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedTicketsByDate(ctx context.Context, in *SeedTicketsByDateRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTicketsByDate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedComments(ctx context.Context, in *SeedCommentsRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedTicketSearch(ctx context.Context, in *SeedTicketSearchRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedTicketSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedAPIKey(ctx context.Context, in *SeedAPIKeyRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedCategory(ctx context.Context, in *SeedCategoryRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketSeederClient) SeedCategories(ctx context.Context, in *SeedCategoriesRequest, opts ...grpc.CallOption) (*SeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeedResponse)
+	err := c.cc.Invoke(ctx, TicketSeeder_SeedCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TicketSeederServer is the server API for TicketSeeder service.
+// All implementations must embed UnimplementedTicketSeederServer
+// for forward compatibility.
+type TicketSeederServer interface {
+	SeedTickets(context.Context, *SeedTimelineRequest) (*SeedResponse, error)
+	SeedTicketBySecurityRisk(context.Context, *SeedTimelineRequest) (*SeedResponse, error)
+	SeedTicketsByCategory(context.Context, *SeedTicketsByCategoryRequest) (*SeedResponse, error)
+	SeedTicketsByStatus(context.Context, *SeedTicketsByStatusRequest) (*SeedResponse, error)
+	SeedTicketsByFilter(context.Context, *SeedTicketsByFilterRequest) (*SeedResponse, error)
+	SeedByAccount(context.Context, *SeedByAccountRequest) (*SeedResponse, error)
+	SeedByAssignee(context.Context, *SeedByAssigneeRequest) (*SeedResponse, error)
+	SeedTicket(context.Context, *SeedTicketRequest) (*SeedResponse, error)
+	SeedTicketsByPage(context.Context, *SeedTicketsByPageRequest) (*SeedResponse, error)
+	SeedTicketsByDate(context.Context, *SeedTicketsByDateRequest) (*SeedResponse, error)
+	SeedComments(context.Context, *SeedCommentsRequest) (*SeedResponse, error)
+	SeedTicketSearch(context.Context, *SeedTicketSearchRequest) (*SeedResponse, error)
+	SeedAPIKey(context.Context, *SeedAPIKeyRequest) (*SeedResponse, error)
+	SeedCategory(context.Context, *SeedCategoryRequest) (*SeedResponse, error)
+	SeedCategories(context.Context, *SeedCategoriesRequest) (*SeedResponse, error)
+	mustEmbedUnimplementedTicketSeederServer()
+}
+
+// UnimplementedTicketSeederServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTicketSeederServer struct{}
+
+func (UnimplementedTicketSeederServer) SeedTickets(context.Context, *SeedTimelineRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTickets not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedTicketBySecurityRisk(context.Context, *SeedTimelineRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTicketBySecurityRisk not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedTicketsByCategory(context.Context, *SeedTicketsByCategoryRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTicketsByCategory not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedTicketsByStatus(context.Context, *SeedTicketsByStatusRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTicketsByStatus not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedTicketsByFilter(context.Context, *SeedTicketsByFilterRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTicketsByFilter not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedByAccount(context.Context, *SeedByAccountRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedByAccount not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedByAssignee(context.Context, *SeedByAssigneeRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedByAssignee not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedTicket(context.Context, *SeedTicketRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTicket not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedTicketsByPage(context.Context, *SeedTicketsByPageRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTicketsByPage not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedTicketsByDate(context.Context, *SeedTicketsByDateRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTicketsByDate not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedComments(context.Context, *SeedCommentsRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedComments not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedTicketSearch(context.Context, *SeedTicketSearchRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTicketSearch not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedAPIKey(context.Context, *SeedAPIKeyRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedAPIKey not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedCategory(context.Context, *SeedCategoryRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedCategory not implemented")
+}
+func (UnimplementedTicketSeederServer) SeedCategories(context.Context, *SeedCategoriesRequest) (*SeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedCategories not implemented")
+}
+func (UnimplementedTicketSeederServer) mustEmbedUnimplementedTicketSeederServer() {}
+func (UnimplementedTicketSeederServer) testEmbeddedByValue()                      {}
+
+// UnsafeTicketSeederServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TicketSeederServer will
+// result in compilation errors.
+type UnsafeTicketSeederServer interface {
+	mustEmbedUnimplementedTicketSeederServer()
+}
+
+func RegisterTicketSeederServer(s grpc.ServiceRegistrar, srv TicketSeederServer) {
+	// If the following call pancis, it indicates UnimplementedTicketSeederServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TicketSeeder_ServiceDesc, srv)
+}
+
+func _TicketSeeder_SeedTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTickets(ctx, req.(*SeedTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedTicketBySecurityRisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTicketBySecurityRisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTicketBySecurityRisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTicketBySecurityRisk(ctx, req.(*SeedTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedTicketsByCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTicketsByCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTicketsByCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTicketsByCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTicketsByCategory(ctx, req.(*SeedTicketsByCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedTicketsByStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTicketsByStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTicketsByStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTicketsByStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTicketsByStatus(ctx, req.(*SeedTicketsByStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedTicketsByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTicketsByFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTicketsByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTicketsByFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTicketsByFilter(ctx, req.(*SeedTicketsByFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedByAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedByAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedByAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedByAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedByAccount(ctx, req.(*SeedByAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedByAssignee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedByAssigneeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedByAssignee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedByAssignee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedByAssignee(ctx, req.(*SeedByAssigneeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTicket(ctx, req.(*SeedTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedTicketsByPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTicketsByPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTicketsByPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTicketsByPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTicketsByPage(ctx, req.(*SeedTicketsByPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedTicketsByDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTicketsByDateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTicketsByDate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTicketsByDate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTicketsByDate(ctx, req.(*SeedTicketsByDateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedComments(ctx, req.(*SeedCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedTicketSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedTicketSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedTicketSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedTicketSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedTicketSearch(ctx, req.(*SeedTicketSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedAPIKey(ctx, req.(*SeedAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedCategory(ctx, req.(*SeedCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketSeeder_SeedCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketSeederServer).SeedCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketSeeder_SeedCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketSeederServer).SeedCategories(ctx, req.(*SeedCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TicketSeeder_ServiceDesc is the grpc.ServiceDesc for TicketSeeder service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TicketSeeder_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "seeder.TicketSeeder",
+	HandlerType: (*TicketSeederServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SeedTickets",
+			Handler:    _TicketSeeder_SeedTickets_Handler,
+		},
+		{
+			MethodName: "SeedTicketBySecurityRisk",
+			Handler:    _TicketSeeder_SeedTicketBySecurityRisk_Handler,
+		},
+		{
+			MethodName: "SeedTicketsByCategory",
+			Handler:    _TicketSeeder_SeedTicketsByCategory_Handler,
+		},
+		{
+			MethodName: "SeedTicketsByStatus",
+			Handler:    _TicketSeeder_SeedTicketsByStatus_Handler,
+		},
+		{
+			MethodName: "SeedTicketsByFilter",
+			Handler:    _TicketSeeder_SeedTicketsByFilter_Handler,
+		},
+		{
+			MethodName: "SeedByAccount",
+			Handler:    _TicketSeeder_SeedByAccount_Handler,
+		},
+		{
+			MethodName: "SeedByAssignee",
+			Handler:    _TicketSeeder_SeedByAssignee_Handler,
+		},
+		{
+			MethodName: "SeedTicket",
+			Handler:    _TicketSeeder_SeedTicket_Handler,
+		},
+		{
+			MethodName: "SeedTicketsByPage",
+			Handler:    _TicketSeeder_SeedTicketsByPage_Handler,
+		},
+		{
+			MethodName: "SeedTicketsByDate",
+			Handler:    _TicketSeeder_SeedTicketsByDate_Handler,
+		},
+		{
+			MethodName: "SeedComments",
+			Handler:    _TicketSeeder_SeedComments_Handler,
+		},
+		{
+			MethodName: "SeedTicketSearch",
+			Handler:    _TicketSeeder_SeedTicketSearch_Handler,
+		},
+		{
+			MethodName: "SeedAPIKey",
+			Handler:    _TicketSeeder_SeedAPIKey_Handler,
+		},
+		{
+			MethodName: "SeedCategory",
+			Handler:    _TicketSeeder_SeedCategory_Handler,
+		},
+		{
+			MethodName: "SeedCategories",
+			Handler:    _TicketSeeder_SeedCategories_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "seeder/seeder.proto",
+}
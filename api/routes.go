@@ -2,33 +2,116 @@ package api
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
 	"redifu-example/api/controller"
+	"redifu-example/api/proto/seeder"
+	"redifu-example/internal/model"
 	"redifu-example/pkg/account"
+	"redifu-example/pkg/apikey"
+	"redifu-example/pkg/category"
+	"redifu-example/pkg/comment"
+	"redifu-example/pkg/events"
 	"redifu-example/pkg/ticket"
+	"redifu-example/pkg/webhook"
 )
 
-func SetterEndpoints(app *fiber.App, ticketService *ticket.TicketService, accountService *account.AccountService) {
+func SetterEndpoints(app *fiber.App, ticketService *ticket.TicketService, accountService *account.AccountService, categoryService *category.CategoryService, commentService *comment.CommentService, webhookService *webhook.WebhookService, apiKeyService *apikey.APIKeyService, authMiddleware *controller.AuthMiddleware) {
 	cudController := controller.NewTicketCUDController(ticketService)
+	transferController := controller.NewTicketTransferController(ticketService)
+	requireTicketWrite := authMiddleware.Require(model.ScopeTicketWrite)
+	requireAccountRead := authMiddleware.Require(model.ScopeAccountRead)
+	requireAccountWrite := authMiddleware.Require(model.ScopeAccountWrite)
 
-	// Ticket management group
+	// Ticket management group, authenticated per route since GetterEndpoints
+	// shares the /ticket prefix in the combined mode
 	ticketGroup := app.Group("/ticket")
-	ticketGroup.Post("/", cudController.CreateTicket)
-	ticketGroup.Patch("/", cudController.PatchTicket)
-	ticketGroup.Post("/resolve", cudController.ResolveTicket)
-	ticketGroup.Delete("/:ticketUUID", cudController.DeleteTicket)
+	ticketGroup.Post("/", requireTicketWrite, cudController.CreateTicket)
+	ticketGroup.Patch("/", requireTicketWrite, cudController.PatchTicket)
+	ticketGroup.Post("/resolve", requireTicketWrite, cudController.ResolveTicket)
+	ticketGroup.Post("/security-risk", requireTicketWrite, cudController.UpdateTicketSecurityRisk)
+	ticketGroup.Post("/category", requireTicketWrite, cudController.SetTicketCategory)
+	ticketGroup.Post("/assign", requireTicketWrite, cudController.AssignTicket)
+	ticketGroup.Post("/unassign", requireTicketWrite, cudController.UnassignTicket)
+	ticketGroup.Post("/batch", requireTicketWrite, cudController.BatchTickets)
+	ticketGroup.Post("/:ticketUUID/transition", requireTicketWrite, cudController.TransitionTicket)
+	ticketGroup.Delete("/:ticketUUID", requireTicketWrite, cudController.DeleteTicket)
+	ticketGroup.Post("/import", requireTicketWrite, transferController.ImportTickets)
+	// export reads Postgres, not the cache, so it lives here and not with the
+	// other reads, registered ahead of the getter's /:ticketRandId
+	ticketGroup.Get("/export", authMiddleware.Require(model.ScopeTicketRead), transferController.ExportTickets)
 
 	// Account management group
 	accountGroup := app.Group("/account")
 	accountController := controller.NewAccountCUDController(accountService)
-	accountGroup.Post("/", accountController.CreateAccount)
+	accountGroup.Get("/uuid/:uuid", requireAccountRead, accountController.GetAccountByUUID)
+	accountGroup.Get("/:randId", requireAccountRead, accountController.GetAccount)
+	accountGroup.Post("/", requireAccountWrite, accountController.CreateAccount)
+	accountGroup.Patch("/", requireAccountWrite, accountController.PatchAccount)
+	accountGroup.Delete("/:uuid", requireAccountWrite, accountController.DeleteAccount)
+
+	// Category management group, reads live with the other reads in GetterEndpoints
+	categoryGroup := app.Group("/category")
+	categoryController := controller.NewCategoryController(categoryService, nil)
+	categoryGroup.Post("/", requireTicketWrite, categoryController.CreateCategory)
+	categoryGroup.Patch("/", requireTicketWrite, categoryController.RenameCategory)
+	categoryGroup.Delete("/:categoryRandId", requireTicketWrite, categoryController.DeleteCategory)
+
+	// Comment management group
+	commentGroup := app.Group("/comment")
+	commentController := controller.NewCommentCUDController(commentService)
+	commentGroup.Post("/", requireTicketWrite, commentController.CreateComment)
+	commentGroup.Patch("/", requireTicketWrite, commentController.PatchComment)
+	commentGroup.Delete("/:commentUUID", requireTicketWrite, commentController.DeleteComment)
+
+	// Webhook management group, admins only
+	webhookGroup := app.Group("/webhook", authMiddleware.Authenticate, authMiddleware.RequireAdmin)
+	webhookController := controller.NewWebhookController(webhookService)
+	webhookGroup.Post("/", webhookController.CreateWebhook)
+	webhookGroup.Get("/", webhookController.GetWebhooks)
+	webhookGroup.Get("/dead-letter", webhookController.GetDeadLetters)
+	webhookGroup.Post("/delivery/:deliveryUUID/retry", webhookController.RetryDelivery)
+	webhookGroup.Get("/:uuid/deliveries", webhookController.GetDeliveries)
+	webhookGroup.Delete("/:uuid", webhookController.DeleteWebhook)
+
+	// API key management group, admins only
+	apiKeyGroup := app.Group("/api-key", authMiddleware.Authenticate, authMiddleware.RequireAdmin)
+	apiKeyController := controller.NewAPIKeyController(apiKeyService)
+	apiKeyGroup.Post("/", apiKeyController.CreateAPIKey)
+	apiKeyGroup.Get("/", apiKeyController.GetAPIKeys)
+	apiKeyGroup.Delete("/:uuid", apiKeyController.RevokeAPIKey)
 }
 
-func GetterEndpoints(app *fiber.App, ticketService *ticket.TicketService, ticketSeeder controller.TicketSeeder) {
-	fetchController := controller.NewTicketFetchController(ticketService, ticketSeeder)
+func GetterEndpoints(app *fiber.App, ticketService *ticket.TicketService, commentService *comment.CommentService, categoryService *category.CategoryService, ticketSeeder controller.TicketSeeder, backgroundSeeder *controller.BackgroundSeeder, streamReader *events.StreamReader, authMiddleware *controller.AuthMiddleware) {
+	fetchController := controller.NewTicketFetchController(ticketService, ticketSeeder, backgroundSeeder)
+	streamController := controller.NewTicketStreamController(streamReader)
+	requireTicketRead := authMiddleware.Require(model.ScopeTicketRead)
 
-	// Ticket retrieval group
+	// Ticket retrieval group, scoped per route like the management group
 	ticketGroup := app.Group("/ticket")
-	ticketGroup.Get("/", fetchController.GetTickets)
-	ticketGroup.Get("/account/:reporterUUID", fetchController.GetTicketsByReporter)
-	ticketGroup.Get("/:ticketRandId", fetchController.GetTicket)
+	ticketGroup.Get("/", requireTicketRead, fetchController.GetTickets)
+	ticketGroup.Get("/search", requireTicketRead, fetchController.SearchTickets)
+	ticketGroup.Get("/stream", requireTicketRead, streamController.StreamTickets)
+	ticketGroup.Get("/account/:reporterUUID", requireTicketRead, fetchController.GetTicketsByReporter)
+	ticketGroup.Get("/assignee/:assigneeUUID", requireTicketRead, fetchController.GetTicketsByAssignee)
+	ticketGroup.Get("/:ticketRandId", requireTicketRead, fetchController.GetTicket)
+
+	// Category retrieval group
+	categoryGroup := app.Group("/category")
+	categoryController := controller.NewCategoryController(categoryService, ticketSeeder)
+	categoryGroup.Get("/", requireTicketRead, categoryController.GetCategories)
+	categoryGroup.Get("/:categoryRandId", requireTicketRead, categoryController.GetCategory)
+
+	// Comment retrieval group
+	commentGroup := app.Group("/comment")
+	commentFetchController := controller.NewCommentFetchController(commentService, ticketSeeder)
+	commentGroup.Get("/:ticketRandId", requireTicketRead, commentFetchController.GetComments)
+
+	// Metrics group, admins only
+	metricsGroup := app.Group("/metrics", authMiddleware.Authenticate, authMiddleware.RequireAdmin)
+	metricsGroup.Get("/seed-queue", fetchController.GetSeedQueueStats)
+}
+
+func SeederEndpoints(server *grpc.Server, ticketService *ticket.TicketService, commentService *comment.CommentService, categoryService *category.CategoryService, apiKeyService *apikey.APIKeyService) {
+	seedServer := controller.NewTicketSeedServer(controller.NewSelfSeedHandler(ticketService, commentService, categoryService, apiKeyService))
+	seeder.RegisterTicketSeederServer(server, seedServer)
 }
@@ -1,21 +1,39 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
 	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"log"
+	"net"
 	"os"
 	"redifu-example/api"
 	"redifu-example/api/controller"
+	"redifu-example/definition"
 	"redifu-example/internal/fetcher"
 	"redifu-example/internal/pools"
 	"redifu-example/internal/repository"
 	"redifu-example/pkg/account"
+	"redifu-example/pkg/apikey"
+	"redifu-example/pkg/auth"
+	"redifu-example/pkg/category"
+	"redifu-example/pkg/comment"
+	"redifu-example/pkg/events"
 	"redifu-example/pkg/ticket"
 	"redifu-example/pkg/utils"
+	"redifu-example/pkg/warmup"
+	"redifu-example/pkg/webhook"
+	"strconv"
+	"time"
 )
 
 func InitSetterOnly() {
-	app := fiber.New()
+	// import bodies are read as they arrive instead of buffered whole
+	app := fiber.New(fiber.Config{StreamRequestBody: true})
 	db := utils.CreatePostgresConnection(os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_USER"),
 		os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), os.Getenv("DB_SSLMODE"))
 	redisClient := utils.ConnectRedis(os.Getenv("REDIS_HOST"), os.Getenv("REDIS_USER"),
@@ -24,53 +42,102 @@ func InitSetterOnly() {
 	fetcherPool := pools.NewFetcherPool(redisClient)
 	seederPool := pools.NewSeederPool()
 
-	seederPool.InitTicketSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.Timeline)
-	seederPool.InitTicketBySecurityRiskSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.TimelineSortBySecurityRisk)
-	seederPool.InitTicketByCategorySeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.TimelineByCategory)
-	seederPool.InitTicketByAccountSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.SortedByAccount)
-	seederPool.InitTicketPageSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.Page)
-	seederPool.InitTicketTimeSeriesSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.TimeSeries)
+	seederPool.InitTicketSeeders(redisClient, db, fetcherPool)
+	seederPool.InitCategorySeeder(redisClient, db, fetcherPool.BaseCategory, fetcherPool.SortedCategory)
+	seederPool.InitCommentSeeder(redisClient, db, fetcherPool.BaseComment, fetcherPool.CommentTimeline)
 
 	ticketRepo := repository.NewTicketRepository(db, fetcherPool, seederPool)
+	ticketRepo.InitFilterDecoder(ticket.FilterDecoder)
 	accountRepo := repository.NewAccountRepository(db, redisClient, fetcherPool)
-	categoryRepo := repository.NewCategoryRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db, fetcherPool, seederPool)
+	commentRepo := repository.NewCommentRepository(db, fetcherPool, seederPool)
+	webhookRepo := repository.NewWebhookRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db, fetcherPool)
 
 	ticketService := ticket.NewTicketService()
 	accountService := account.NewAccountService()
+	categoryService := category.NewCategoryService()
+	commentService := comment.NewCommentService()
 
 	ticketService.InitRepository(ticketRepo, categoryRepo, accountService)
-	accountService.InitRepository(accountRepo)
+	ticketService.InitFetcher(fetcher.NewTicketFetcher(fetcherPool))
+	accountService.InitRepository(accountRepo, ticketRepo)
+	accountService.InitFetcher(fetcher.NewAccountFetcher(redisClient, fetcherPool))
+	categoryService.InitRepository(categoryRepo, ticketRepo)
+	categoryService.InitFetcher(fetcher.NewCategoryFetcher(fetcherPool))
+	commentService.InitRepository(commentRepo, ticketRepo, accountService)
+
+	webhookService := webhook.NewWebhookService()
+	webhookService.InitRepository(webhookRepo)
+	ticketRepo.InitWriteHook(webhookService.QueueTicketWrite)
+	apiKeyService := apikey.NewAPIKeyService()
+	apiKeyService.InitRepository(apiKeyRepo)
+	apiKeyService.InitFetcher(fetcher.NewAPIKeyFetcher(fetcherPool))
+
+	publisher := NewEventPublisher(redisClient)
+	ticketService.InitPublisher(publisher)
+	accountService.InitPublisher(publisher)
+	categoryService.InitPublisher(publisher)
+	commentService.InitFetcher(fetcher.NewCommentFetcher(fetcherPool))
+
+	StartSeederServer(ticketService, commentService, categoryService, apiKeyService)
+	StartOutboxRelay(ticketRepo)
+	StartWebhookWorker(webhookRepo)
+	StartWarmup(ticketService, categoryService)
 
-	api.SetterEndpoints(app, ticketService, accountService)
+	seedHandler := controller.NewCoalescingSeedHandler(controller.NewSelfSeedHandler(ticketService, commentService, categoryService, apiKeyService), redisClient)
+	authMiddleware := NewAuthMiddleware(accountService, apiKeyService, seedHandler)
+	api.SetterEndpoints(app, ticketService, accountService, categoryService, commentService, webhookService, apiKeyService, authMiddleware)
 	app.Listen(":" + os.Getenv("RUNNING_PORT"))
 }
 
 func InitGetterOnly() {
 	app := fiber.New()
-	db := utils.CreatePostgresConnection(os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), os.Getenv("DB_SSLMODE"))
 	redisClient := utils.ConnectRedis(os.Getenv("REDIS_HOST"), os.Getenv("REDIS_USER"),
 		os.Getenv("REDIS_PASS"), false)
 
 	fetcherPool := pools.NewFetcherPool(redisClient)
-	categoryRepo := repository.NewCategoryRepository(db)
 
 	accountFetcher := fetcher.NewAccountFetcher(redisClient, fetcherPool)
 	ticketFetcher := fetcher.NewTicketFetcher(fetcherPool)
+	commentFetcher := fetcher.NewCommentFetcher(fetcherPool)
 
 	ticketService := ticket.NewTicketService()
 	accountService := account.NewAccountService()
+	categoryService := category.NewCategoryService()
+	commentService := comment.NewCommentService()
+	apiKeyService := apikey.NewAPIKeyService()
 
-	ticketService.InitRepository(nil, categoryRepo, nil)
+	ticketService.InitRepository(nil, nil, accountService)
 	ticketService.InitFetcher(ticketFetcher)
 	accountService.InitFetcher(accountFetcher)
+	categoryService.InitFetcher(fetcher.NewCategoryFetcher(fetcherPool))
+	commentService.InitFetcher(commentFetcher)
+	apiKeyService.InitFetcher(fetcher.NewAPIKeyFetcher(fetcherPool))
+
+	// GETTER nodes hold no Postgres credentials, every cache miss is seeded by the SETTER node
+	seedHandler, errDial := controller.NewGRPCSeedHandler(os.Getenv("SEEDER_GRPC_ADDR"), os.Getenv("SEEDER_GRPC_TOKEN"),
+		os.Getenv("SEEDER_GRPC_TLS_CA"))
+	if errDial != nil {
+		log.Fatal(errDial)
+	}
+	defer seedHandler.Close()
 
-	api.GetterEndpoints(app, ticketService, nil)
+	backgroundSeeder := StartBackgroundSeeder()
+	if backgroundSeeder != nil {
+		defer backgroundSeeder.Close()
+	}
+
+	coalescingSeedHandler := controller.NewCoalescingSeedHandler(seedHandler, redisClient)
+	authMiddleware := NewAuthMiddleware(nil, apiKeyService, coalescingSeedHandler)
+	api.GetterEndpoints(app, ticketService, commentService, categoryService, coalescingSeedHandler, backgroundSeeder,
+		NewStreamReader(), authMiddleware)
 	app.Listen(":" + os.Getenv("RUNNING_PORT"))
 }
 
 func Init() {
-	app := fiber.New()
+	// import bodies are read as they arrive instead of buffered whole
+	app := fiber.New(fiber.Config{StreamRequestBody: true})
 	db := utils.CreatePostgresConnection(os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_USER"),
 		os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), os.Getenv("DB_SSLMODE"))
 	redisClient := utils.ConnectRedis(os.Getenv("REDIS_HOST"), os.Getenv("REDIS_USER"),
@@ -79,40 +146,223 @@ func Init() {
 	fetcherPool := pools.NewFetcherPool(redisClient)
 	seederPool := pools.NewSeederPool()
 
-	seederPool.InitTicketSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.Timeline)
-	seederPool.InitTicketBySecurityRiskSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.TimelineSortBySecurityRisk)
-	seederPool.InitTicketByCategorySeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.TimelineByCategory)
-	seederPool.InitTicketByAccountSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.SortedByAccount)
-	seederPool.InitTicketPageSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.Page)
-	seederPool.InitTicketTimeSeriesSeeder(redisClient, db, fetcherPool.BaseTicket, fetcherPool.TimeSeries)
+	seederPool.InitTicketSeeders(redisClient, db, fetcherPool)
+	seederPool.InitCategorySeeder(redisClient, db, fetcherPool.BaseCategory, fetcherPool.SortedCategory)
+	seederPool.InitCommentSeeder(redisClient, db, fetcherPool.BaseComment, fetcherPool.CommentTimeline)
 
 	ticketRepo := repository.NewTicketRepository(db, fetcherPool, seederPool)
+	ticketRepo.InitFilterDecoder(ticket.FilterDecoder)
 	accountRepo := repository.NewAccountRepository(db, redisClient, fetcherPool)
-	categoryRepo := repository.NewCategoryRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db, fetcherPool, seederPool)
+	commentRepo := repository.NewCommentRepository(db, fetcherPool, seederPool)
+	webhookRepo := repository.NewWebhookRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db, fetcherPool)
 	accountFetcher := fetcher.NewAccountFetcher(redisClient, fetcherPool)
 	ticketFetcher := fetcher.NewTicketFetcher(fetcherPool)
+	categoryFetcher := fetcher.NewCategoryFetcher(fetcherPool)
+	commentFetcher := fetcher.NewCommentFetcher(fetcherPool)
 
 	ticketService := ticket.NewTicketService()
 	accountService := account.NewAccountService()
+	categoryService := category.NewCategoryService()
+	commentService := comment.NewCommentService()
 
 	ticketService.InitRepository(ticketRepo, categoryRepo, accountService)
 	ticketService.InitFetcher(ticketFetcher)
-	accountService.InitRepository(accountRepo)
+	accountService.InitRepository(accountRepo, ticketRepo)
 	accountService.InitFetcher(accountFetcher)
+	categoryService.InitRepository(categoryRepo, ticketRepo)
+	categoryService.InitFetcher(categoryFetcher)
+	commentService.InitRepository(commentRepo, ticketRepo, accountService)
 
-	api.SetterEndpoints(app, ticketService, accountService)
+	webhookService := webhook.NewWebhookService()
+	webhookService.InitRepository(webhookRepo)
+	ticketRepo.InitWriteHook(webhookService.QueueTicketWrite)
+	apiKeyService := apikey.NewAPIKeyService()
+	apiKeyService.InitRepository(apiKeyRepo)
+	apiKeyService.InitFetcher(fetcher.NewAPIKeyFetcher(fetcherPool))
 
-	var seedHandler controller.TicketSeeder
-	if os.Getenv("OP_MODE") == "" {
-		seedHandler = controller.NewSelfSeedHandler(ticketService)
-	} else if os.Getenv("OP_MODE") == "GETTER" {
-		// seedHandler = GRPCHandler
+	publisher := NewEventPublisher(redisClient)
+	ticketService.InitPublisher(publisher)
+	accountService.InitPublisher(publisher)
+	categoryService.InitPublisher(publisher)
+	commentService.InitFetcher(commentFetcher)
+
+	StartSeederServer(ticketService, commentService, categoryService, apiKeyService)
+	StartOutboxRelay(ticketRepo)
+	StartWebhookWorker(webhookRepo)
+	StartWarmup(ticketService, categoryService)
+
+	seedHandler := controller.NewCoalescingSeedHandler(controller.NewSelfSeedHandler(ticketService, commentService, categoryService, apiKeyService), redisClient)
+	authMiddleware := NewAuthMiddleware(accountService, apiKeyService, seedHandler)
+	api.SetterEndpoints(app, ticketService, accountService, categoryService, commentService, webhookService, apiKeyService, authMiddleware)
+	backgroundSeeder := StartBackgroundSeeder()
+	if backgroundSeeder != nil {
+		defer backgroundSeeder.Close()
 	}
 
-	api.GetterEndpoints(app, ticketService, seedHandler)
+	api.GetterEndpoints(app, ticketService, commentService, categoryService, seedHandler, backgroundSeeder, NewStreamReader(), authMiddleware)
 	app.Listen(":" + os.Getenv("RUNNING_PORT"))
 }
 
+// StartSeederServer exposes the seeding gRPC service when SEEDER_GRPC_PORT is set,
+// GETTER nodes dial it through SEEDER_GRPC_ADDR. It listens on SEEDER_GRPC_HOST,
+// loopback by default, any other host needs SEEDER_GRPC_TOKEN. SEEDER_GRPC_TLS_CERT
+// and SEEDER_GRPC_TLS_KEY serve it over TLS.
+func StartSeederServer(ticketService *ticket.TicketService, commentService *comment.CommentService, categoryService *category.CategoryService, apiKeyService *apikey.APIKeyService) {
+	port := os.Getenv("SEEDER_GRPC_PORT")
+	if port == "" {
+		return
+	}
+
+	host := os.Getenv("SEEDER_GRPC_HOST")
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	token := os.Getenv("SEEDER_GRPC_TOKEN")
+	if token == "" && !isLoopback(host) {
+		log.Fatal("SEEDER_GRPC_TOKEN is required when SEEDER_GRPC_HOST is not a loopback address")
+	}
+
+	var options []grpc.ServerOption
+	if token != "" {
+		options = append(options, grpc.UnaryInterceptor(controller.SeedTokenInterceptor(token)))
+	}
+	if certFile := os.Getenv("SEEDER_GRPC_TLS_CERT"); certFile != "" {
+		transport, errTLS := credentials.NewServerTLSFromFile(certFile, os.Getenv("SEEDER_GRPC_TLS_KEY"))
+		if errTLS != nil {
+			log.Fatal("Failed to load SEEDER_GRPC_TLS_CERT: ", errTLS)
+		}
+		options = append(options, grpc.Creds(transport))
+	}
+
+	listener, errListen := net.Listen("tcp", net.JoinHostPort(host, port))
+	if errListen != nil {
+		log.Fatal(errListen)
+	}
+
+	server := grpc.NewServer(options...)
+	api.SeederEndpoints(server, ticketService, commentService, categoryService, apiKeyService)
+
+	go func() {
+		if errServe := server.Serve(listener); errServe != nil {
+			log.Fatal(errServe)
+		}
+	}()
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// NewEventPublisher always appends to the EVENT_STREAM stream, GET /ticket/stream
+// on every node reads it.
+func NewEventPublisher(redisClient redis.UniversalClient) events.Publisher {
+	return events.NewRedisStreamPublisher(redisClient, EventStreamName(), definition.EventStreamMaxLen)
+}
+
+// NewStreamReader tails EVENT_STREAM over its own Redis client, see
+// utils.ConnectStreamRedis.
+func NewStreamReader() *events.StreamReader {
+	streamClient := utils.ConnectStreamRedis(os.Getenv("REDIS_HOST"), os.Getenv("REDIS_USER"),
+		os.Getenv("REDIS_PASS"), definition.StreamPoolSize)
+	return events.NewStreamReader(streamClient, EventStreamName())
+}
+
+// NewAuthMiddleware verifies bearer tokens with the key in AUTH_JWT_KEY_FILE,
+// AUTH_JWT_ALG is HS256 (default) or RS256. AUTH_JWT_ISSUER and
+// AUTH_JWT_AUDIENCE are checked when set. API keys missing from the cache are
+// seeded through seedHandler.
+func NewAuthMiddleware(accountService *account.AccountService, apiKeyService *apikey.APIKeyService, seedHandler controller.TicketSeeder) *controller.AuthMiddleware {
+	algorithm := os.Getenv("AUTH_JWT_ALG")
+	if algorithm == "" {
+		algorithm = auth.HS256
+	}
+
+	verifier, errVerifier := auth.NewVerifierFromFile(algorithm, os.Getenv("AUTH_JWT_KEY_FILE"))
+	if errVerifier != nil {
+		log.Fatal("Failed to load AUTH_JWT_KEY_FILE: ", errVerifier)
+	}
+	verifier.WithIssuer(os.Getenv("AUTH_JWT_ISSUER")).WithAudience(os.Getenv("AUTH_JWT_AUDIENCE"))
+
+	return controller.NewAuthMiddleware(verifier, accountService, apiKeyService, seedHandler)
+}
+
+// EventStreamName is the Redis stream ticket events are published to and
+// GET /ticket/stream reads from, EVENT_STREAM overrides the default.
+func EventStreamName() string {
+	if stream := os.Getenv("EVENT_STREAM"); stream != "" {
+		return stream
+	}
+	return definition.EventStream
+}
+
+// StartOutboxRelay retries the ticket cache changes the writes could not apply,
+// every node with Postgres access runs one, drains are serialized in the database.
+func StartOutboxRelay(ticketRepo *repository.TicketRepository) {
+	go repository.NewOutboxRelay(ticketRepo).Run(context.Background())
+}
+
+// StartWebhookWorker sends queued webhook deliveries, workers on several nodes
+// claim disjoint batches.
+func StartWebhookWorker(webhookRepo *repository.WebhookRepository) {
+	go webhook.NewWorker(webhookRepo, webhook.NewSender(nil)).Run(context.Background())
+}
+
+// StartWarmup pre-seeds the hot structures in the background when WARMUP_ON_START
+// is true, WARMUP_PAGES, WARMUP_CATEGORIES and WARMUP_WINDOWS follow cmd/warmup.
+func StartWarmup(ticketService *ticket.TicketService, categoryService *category.CategoryService) {
+	enabled, _ := strconv.ParseBool(os.Getenv("WARMUP_ON_START"))
+	if !enabled {
+		return
+	}
+
+	pages := int64(3)
+	if raw := os.Getenv("WARMUP_PAGES"); raw != "" {
+		parsed, errParse := strconv.ParseInt(raw, 10, 64)
+		if errParse != nil {
+			log.Fatal(fmt.Errorf("incorrect WARMUP_PAGES value-type: %w", errParse))
+		}
+		pages = parsed
+	}
+
+	windows, errParse := warmup.ParseWindows(os.Getenv("WARMUP_WINDOWS"))
+	if errParse != nil {
+		log.Fatal(errParse)
+	}
+
+	config := &warmup.Config{
+		Timeline:         true,
+		SecurityTimeline: true,
+		Pages:            pages,
+		Categories:       warmup.ParseCategories(os.Getenv("WARMUP_CATEGORIES")),
+		Windows:          windows,
+	}
+
+	go func() {
+		startedAt := time.Now()
+		warmup.NewWarmer(ticketService, categoryService).Run(context.Background(), config)
+		log.Printf("Warm-up finished in %s", time.Since(startedAt))
+	}()
+}
+
+// StartBackgroundSeeder enables stale-while-revalidate when STALE_WHILE_REVALIDATE
+// is true, SEED_WORKERS and SEED_QUEUE_SIZE size the pool.
+func StartBackgroundSeeder() *controller.BackgroundSeeder {
+	enabled, _ := strconv.ParseBool(os.Getenv("STALE_WHILE_REVALIDATE"))
+	if !enabled {
+		return nil
+	}
+
+	workers, _ := strconv.Atoi(os.Getenv("SEED_WORKERS"))
+	queueSize, _ := strconv.Atoi(os.Getenv("SEED_QUEUE_SIZE"))
+	return controller.NewBackgroundSeeder(workers, queueSize)
+}
+
 func StartAPI() {
 	if os.Getenv("OP_MODE") == "SETTER" {
 		InitSetterOnly()
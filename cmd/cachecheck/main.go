@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	_ "github.com/lib/pq"
+	"os"
+	"redifu-example/internal/pools"
+	"redifu-example/pkg/cachecheck"
+	"redifu-example/pkg/utils"
+	"time"
+)
+
+type CheckConfig struct {
+	Sample int
+	Format string
+	Repair bool
+	Purge  bool
+	Help   bool
+}
+
+func ParseCheckArgs() *CheckConfig {
+	config := &CheckConfig{}
+
+	flag.IntVar(&config.Sample, "sample", 0, "Members read per sorted set and keys read per family, 0 scans everything")
+	flag.StringVar(&config.Format, "format", "text", "Report format (text, json)")
+	flag.BoolVar(&config.Repair, "repair", false, "Fix each drifting entry in place")
+	flag.BoolVar(&config.Purge, "purge", false, "Delete every drifting key so it is seeded again")
+	flag.BoolVar(&config.Help, "help", false, "Show help message")
+	flag.BoolVar(&config.Help, "h", false, "Show help message")
+
+	flag.Parse()
+
+	return config
+}
+
+func ShowHelp() {
+	fmt.Println("Redifu Example Cache Consistency Checker")
+	fmt.Println("=======================")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run main.go [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -sample int      Members read per sorted set and keys read per family (default: 0, scan everything)")
+	fmt.Println("  -format string   Report format: text, json (default: text)")
+	fmt.Println("  -repair          Fix each drifting entry in place")
+	fmt.Println("  -purge           Delete every drifting key so it is seeded again")
+	fmt.Println("  -help, -h        Show this help message")
+	fmt.Println()
+	fmt.Println("Connections are read from the same environment as the API:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME, DB_SSLMODE, REDIS_HOST, REDIS_USER, REDIS_PASS")
+	fmt.Println()
+	fmt.Println("Key families checked:")
+	fmt.Println("  - ticket-timeline, ticket-timeline-by-security")
+	fmt.Println("  - ticket-timeline:category:*, ticket-timeline:status:*, ticket-timeline:filter:*")
+	fmt.Println("  - ticket-sorted-by-account:*, ticket-sorted-by-assignee:*, ticket-time-series")
+	fmt.Println("  - ticket-page*       cached listing pages, purged whole on repair")
+	fmt.Println("  - ticket-comments:*  comment timelines per ticket")
+	fmt.Println("  - category-sorted    cached category list")
+	fmt.Println("  - ticket:*           cached ticket items")
+	fmt.Println("  - account:pointer:*  account uuid to randId pointers")
+	fmt.Println()
+	fmt.Println("Exit status is 1 when drift remains after the run.")
+}
+
+func ValidateConfig(config *CheckConfig) error {
+	if config.Format != "text" && config.Format != "json" {
+		return fmt.Errorf("unknown format %q (use text or json)", config.Format)
+	}
+	if config.Sample < 0 {
+		return fmt.Errorf("sample must not be negative")
+	}
+	if config.Repair && config.Purge {
+		return fmt.Errorf("-repair and -purge are mutually exclusive")
+	}
+	return nil
+}
+
+func PrintReport(report *cachecheck.Report, format string) {
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+		return
+	}
+
+	for _, family := range report.Families {
+		fmt.Printf("%-30s keys=%d checked=%d drift=%d", family.Family, family.Keys, family.Checked, len(family.Drifts))
+		if family.Purged > 0 {
+			fmt.Printf(" purged=%d", family.Purged)
+		}
+		fmt.Println()
+		if family.Error != "" {
+			fmt.Printf("  error: %s\n", family.Error)
+		}
+		for _, drift := range family.Drifts {
+			status := ""
+			if drift.Repaired {
+				status = " (repaired)"
+			}
+			fmt.Printf("  %-12s %s %s %s%s\n", drift.Kind, drift.Key, drift.Member, drift.Detail, status)
+		}
+	}
+	fmt.Printf("%d drifting entries found in %s\n", report.DriftCount(), report.Duration.Round(time.Millisecond))
+}
+
+func StartCheck() {
+	config := ParseCheckArgs()
+
+	if config.Help {
+		ShowHelp()
+		return
+	}
+
+	if err := ValidateConfig(config); err != nil {
+		fmt.Printf("Error: %v\n\n", err)
+		ShowHelp()
+		os.Exit(1)
+	}
+
+	db := utils.CreatePostgresConnection(os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_USER"),
+		os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), os.Getenv("DB_SSLMODE"))
+	defer db.Close()
+	redisClient := utils.ConnectRedis(os.Getenv("REDIS_HOST"), os.Getenv("REDIS_USER"),
+		os.Getenv("REDIS_PASS"), false)
+
+	checker := cachecheck.NewChecker(db, redisClient, pools.NewFetcherPool(redisClient), cachecheck.Options{
+		Sample: config.Sample,
+		Repair: config.Repair,
+		Purge:  config.Purge,
+	})
+	report := checker.Run(context.Background())
+	PrintReport(report, config.Format)
+
+	for _, family := range report.Families {
+		if family.Error != "" {
+			os.Exit(1)
+		}
+		for _, drift := range family.Drifts {
+			if !drift.Repaired {
+				os.Exit(1)
+			}
+		}
+	}
+}
+
+func main() {
+	StartCheck()
+}
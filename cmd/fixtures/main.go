@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	_ "github.com/lib/pq"
+	"log"
+	"os"
+	"redifu-example/pkg/fixtures"
+	"redifu-example/pkg/utils"
+	"redifu-example/pkg/warmup"
+	"time"
+)
+
+type FixturesConfig struct {
+	Accounts   int
+	Categories int
+	Tickets    int
+	Window     time.Duration
+	Until      string
+	Seed       uint64
+	Skew       float64
+	Prewarm    bool
+	Pages      int64
+	JSON       bool
+	Help       bool
+}
+
+func ParseFixturesArgs() *FixturesConfig {
+	config := &FixturesConfig{}
+
+	flag.IntVar(&config.Accounts, "accounts", 1000, "Number of accounts to insert")
+	flag.IntVar(&config.Categories, "categories", 15, "Number of categories to insert")
+	flag.IntVar(&config.Tickets, "tickets", 100000, "Number of tickets to insert")
+	flag.DurationVar(&config.Window, "window", 90*24*time.Hour, "Tickets are created over this window ending at -until")
+	flag.StringVar(&config.Until, "until", "", "RFC 3339 end of the window (default: now)")
+	flag.Uint64Var(&config.Seed, "seed", 1, "Random seed, the same seed and -until insert the same rows")
+	flag.Float64Var(&config.Skew, "skew", 1.2, "Zipf exponent of tickets per reporter, greater than 1")
+	flag.BoolVar(&config.Prewarm, "prewarm", false, "Seed the Redis timelines, pages and category timelines afterwards")
+	flag.Int64Var(&config.Pages, "pages", 3, "Number of ticket pages to pre-warm")
+	flag.BoolVar(&config.JSON, "json", false, "Print the report as JSON")
+	flag.BoolVar(&config.Help, "help", false, "Show help message")
+	flag.BoolVar(&config.Help, "h", false, "Show help message")
+
+	flag.Parse()
+
+	return config
+}
+
+func ShowHelp() {
+	fmt.Println("Redifu Example Fixtures Tool")
+	fmt.Println("=======================")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run main.go [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -accounts int      Number of accounts to insert (default: 1000)")
+	fmt.Println("  -categories int    Number of categories to insert (default: 15)")
+	fmt.Println("  -tickets int       Number of tickets to insert (default: 100000)")
+	fmt.Println("  -window duration   Tickets are created over this window ending at -until (default: 2160h)")
+	fmt.Println("  -until string      RFC 3339 end of the window (default: now)")
+	fmt.Println("  -seed uint         Random seed, the same seed and -until insert the same rows (default: 1)")
+	fmt.Println("  -skew float        Zipf exponent of tickets per reporter, greater than 1 (default: 1.2)")
+	fmt.Println("  -prewarm           Seed the Redis timelines, pages and category timelines afterwards")
+	fmt.Println("  -pages int         Number of ticket pages to pre-warm (default: 3)")
+	fmt.Println("  -json              Print the report as JSON")
+	fmt.Println("  -help, -h          Show this help message")
+	fmt.Println()
+	fmt.Println("Rows are loaded with COPY in a single transaction into a migrated database.")
+	fmt.Println("Connections are read from the same environment as the API:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME, DB_SSLMODE, REDIS_HOST, REDIS_USER, REDIS_PASS")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run main.go -tickets 1000000 -accounts 20000")
+	fmt.Println("  go run main.go -seed 42 -until 2026-01-01T00:00:00Z -window 720h -prewarm")
+}
+
+func ToConfig(config *FixturesConfig) (*fixtures.Config, error) {
+	until := time.Now()
+	if config.Until != "" {
+		parsed, errParse := time.Parse(time.RFC3339, config.Until)
+		if errParse != nil {
+			return nil, fmt.Errorf("until must be an RFC 3339 time: %w", errParse)
+		}
+		until = parsed
+	}
+
+	fixturesConfig := &fixtures.Config{
+		Accounts:   config.Accounts,
+		Categories: config.Categories,
+		Tickets:    config.Tickets,
+		Window:     config.Window,
+		Until:      until,
+		Seed:       config.Seed,
+		Skew:       config.Skew,
+	}
+	if errValidate := fixturesConfig.Validate(); errValidate != nil {
+		return nil, errValidate
+	}
+	if config.Pages < 0 {
+		return nil, fmt.Errorf("pages must not be negative")
+	}
+
+	return fixturesConfig, nil
+}
+
+func PrintReport(report *fixtures.Report, warmed []warmup.Report, asJSON bool) {
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(map[string]interface{}{
+			"fixtures": report,
+			"prewarm":  warmed,
+		})
+		return
+	}
+
+	fmt.Printf("Inserted %d accounts, %d categories and %d tickets in %s\n",
+		report.Accounts, report.Categories, report.Tickets, report.Duration.Round(time.Millisecond))
+	for _, warmedReport := range warmed {
+		outcome := "ok"
+		if warmedReport.Error != "" {
+			outcome = "failed: " + warmedReport.Error
+		}
+		fmt.Printf("%-40s %12s  %s\n", warmedReport.Structure, warmedReport.Duration.Round(time.Millisecond), outcome)
+	}
+}
+
+func StartFixtures() {
+	config := ParseFixturesArgs()
+
+	if config.Help {
+		ShowHelp()
+		return
+	}
+
+	fixturesConfig, errConfig := ToConfig(config)
+	if errConfig != nil {
+		fmt.Printf("Error: %v\n\n", errConfig)
+		ShowHelp()
+		os.Exit(1)
+	}
+
+	db := utils.CreatePostgresConnection(os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_USER"),
+		os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), os.Getenv("DB_SSLMODE"))
+	defer db.Close()
+
+	ctx := context.Background()
+	report, errGenerate := fixtures.NewGenerator(fixturesConfig).Generate(ctx, db)
+	if errGenerate != nil {
+		log.Fatal("Failed to insert fixtures:", errGenerate)
+	}
+
+	var warmed []warmup.Report
+	if config.Prewarm {
+		redisClient := utils.ConnectRedis(os.Getenv("REDIS_HOST"), os.Getenv("REDIS_USER"),
+			os.Getenv("REDIS_PASS"), false)
+		warmer := warmup.NewWarmerFromConnections(db, redisClient)
+		warmed = warmer.Run(ctx, &warmup.Config{
+			Timeline:         true,
+			SecurityTimeline: true,
+			Pages:            config.Pages,
+		})
+	}
+
+	PrintReport(report, warmed, config.JSON)
+
+	for _, warmedReport := range warmed {
+		if warmedReport.Error != "" {
+			log.Println("Pre-warm finished with errors")
+			os.Exit(1)
+		}
+	}
+}
+
+func main() {
+	StartFixtures()
+}
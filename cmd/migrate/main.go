@@ -1,15 +1,29 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"embed"
+	"errors"
 	"flag"
 	"fmt"
 	_ "github.com/lib/pq"
+	"io/fs"
 	"log"
 	"os"
+	"redifu-example/pkg/migration"
 	"redifu-example/pkg/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var UsageError = errors.New("usage")
+
 type MigrationConfig struct {
 	Host     string
 	Port     string
@@ -17,6 +31,8 @@ type MigrationConfig struct {
 	Password string
 	Database string
 	SSLMode  string
+	Dir      string
+	MinRows  int64
 	Help     bool
 }
 
@@ -29,6 +45,8 @@ func ParseMigrationArgs() *MigrationConfig {
 	flag.StringVar(&config.Password, "password", "", "Database password (required)")
 	flag.StringVar(&config.Database, "database", "", "Database name (required)")
 	flag.StringVar(&config.SSLMode, "sslmode", "disable", "SSL mode (disable, require, verify-ca, verify-full)")
+	flag.StringVar(&config.Dir, "dir", "cmd/migrate/migrations", "Directory create writes new migrations to")
+	flag.Int64Var(&config.MinRows, "min-rows", 10000, "Rows a table needs before explain fails on a sequential scan of it")
 	flag.BoolVar(&config.Help, "help", false, "Show help message")
 	flag.BoolVar(&config.Help, "h", false, "Show help message")
 
@@ -42,7 +60,16 @@ func ShowHelp() {
 	fmt.Println("=======================")
 	fmt.Println()
 	fmt.Println("Usage:")
-	fmt.Println("  go run main.go migrate [options]")
+	fmt.Println("  go run main.go [options] [command]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  up                 Apply every pending migration (default)")
+	fmt.Println("  down [N]           Roll back the N most recent migrations (default: 1)")
+	fmt.Println("  status             List migrations and whether they are applied")
+	fmt.Println("  goto V             Migrate up or down to version V, 0 rolls everything back")
+	fmt.Println("  create NAME        Write empty up and down scripts for the next version into -dir")
+	fmt.Println("  explain            Run every ticket seeder against the fixture, EXPLAIN the SQL it sends and fail")
+	fmt.Println("                     on a sequential scan of a large table")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  -host string       Database host (default: localhost)")
@@ -51,15 +78,25 @@ func ShowHelp() {
 	fmt.Println("  -password string   Database password (required)")
 	fmt.Println("  -database string   Database name (required)")
 	fmt.Println("  -sslmode string    SSL mode: disable, require, verify-ca, verify-full (default: disable)")
+	fmt.Println("  -dir string        Directory create writes to (default: cmd/migrate/migrations)")
+	fmt.Println("  -min-rows int      Rows a table needs before explain fails on a sequential scan of it (default: 10000)")
 	fmt.Println("  -help, -h          Show this help message")
 	fmt.Println()
-	fmt.Println("Examples:")
-	fmt.Println("  go run main.go migrate -user myuser -password mypass -database mydb")
-	fmt.Println("  go run main.go migrate -host db.example.com -port 5432 -user admin -password secret -database tickets -sslmode require")
+	fmt.Println("Migrations are the NNNN_name.up.sql and NNNN_name.down.sql files in cmd/migrate/migrations,")
+	fmt.Println("embedded at build time. Each runs in its own transaction under an advisory lock and is")
+	fmt.Println("recorded in schema_migrations. A script starting with \"-- migrate:no-transaction\" runs")
+	fmt.Println("statement by statement outside a transaction, for CREATE INDEX CONCURRENTLY. Its finished")
+	fmt.Println("statements are recorded so a rerun resumes at the one that failed, and an INVALID index left")
+	fmt.Println("by a failed build is dropped before it is built again. up and goto refuse to run while")
+	fmt.Println("status reports a migration as modified.")
 	fmt.Println()
-	fmt.Println("Tables created:")
-	fmt.Println("  - account: User account information")
-	fmt.Println("  - ticket:  Support tickets with foreign key to account")
+	fmt.Println("Examples:")
+	fmt.Println("  go run main.go -user myuser -password mypass -database mydb")
+	fmt.Println("  go run main.go -user myuser -password mypass -database mydb status")
+	fmt.Println("  go run main.go -user myuser -password mypass -database mydb down 2")
+	fmt.Println("  go run main.go -host db.example.com -user admin -password secret -database tickets -sslmode require goto 1")
+	fmt.Println("  go run main.go -user myuser -password mypass -database fixtures explain")
+	fmt.Println("  go run main.go create add_ticket_priority")
 }
 
 func ValidateConfig(config *MigrationConfig) error {
@@ -75,63 +112,141 @@ func ValidateConfig(config *MigrationConfig) error {
 	return nil
 }
 
-func CreateTables(config *MigrationConfig) {
-	db := utils.CreatePostgresConnection(config.Host, config.Port, config.User, config.Password, config.Database, config.SSLMode)
-	defer func() {
-		if err := db.Close(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
-		}
-	}()
+func OpenMigrator(config *MigrationConfig) (*migration.Migrator, *sql.DB) {
+	migrations, errLoad := migration.Load(EmbeddedMigrations())
+	if errLoad != nil {
+		log.Fatal("Failed to load migrations:", errLoad)
+	}
 
 	log.Printf("Connecting to database: %s@%s:%s/%s", config.User, config.Host, config.Port, config.Database)
+	db := utils.CreatePostgresConnection(config.Host, config.Port, config.User, config.Password, config.Database, config.SSLMode)
 
-	createAccountTable(db)
-	createTicketTable(db)
+	return migration.NewMigrator(db, migrations), db
+}
 
-	log.Println("Migration completed successfully")
+func EmbeddedMigrations() fs.FS {
+	migrations, errSub := fs.Sub(migrationFiles, "migrations")
+	if errSub != nil {
+		log.Fatal("Failed to open embedded migrations:", errSub)
+	}
+	return migrations
 }
 
-func createAccountTable(db *sql.DB) {
-	createAccountTable := `
-		CREATE TABLE IF NOT EXISTS account (
-		    uuid varchar(36) PRIMARY KEY,
-		    randid varchar(16) UNIQUE NOT NULL,
-		    created_at timestamp NOT NULL DEFAULT NOW(),
-		    updated_at timestamp NOT NULL DEFAULT NOW(),
-		    name varchar(255) NOT NULL,
-		    email varchar(255) NOT NULL
-	  	);
-	`
-
-	_, errCreateAccountTable := db.Exec(createAccountTable)
-	if errCreateAccountTable != nil {
-		log.Fatal("Failed to create account table:", errCreateAccountTable)
+func PrintStatus(statuses []migration.Status) {
+	for _, status := range statuses {
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%04d  %-40s %-10s %s\n", status.Version, status.Name, status.State, appliedAt)
 	}
+}
 
-	log.Println("Account table created successfully")
+// PrintPlans reports whether any plan failed.
+func PrintPlans(reports []migration.PlanReport) bool {
+	failed := false
+	for _, report := range reports {
+		outcome := "ok"
+		switch {
+		case report.Error != "":
+			outcome = "error: " + report.Error
+		case report.Failed:
+			outcome = "seq scan on " + strings.Join(report.SeqScans, ", ")
+		}
+		failed = failed || report.Failed
+		fmt.Printf("%-36s %s\n", report.Name, outcome)
+		for _, node := range report.Nodes {
+			fmt.Printf("    %s\n", node)
+		}
+	}
+	return failed
 }
 
-func createTicketTable(db *sql.DB) {
-	createTicketTable := `
-		CREATE TABLE IF NOT EXISTS ticket (
-		    uuid varchar(36) PRIMARY KEY,
-		    randid varchar(16) UNIQUE NOT NULL,
-		    created_at timestamp NOT NULL DEFAULT NOW(),
-		    updated_at timestamp NOT NULL DEFAULT NOW(),
-		    account_uuid varchar(36) NOT NULL,
-		    description text NOT NULL,
-		    resolved boolean NOT NULL DEFAULT false,
-		    security_risk bigint NOT NULL DEFAULT 0,
-		    FOREIGN KEY (account_uuid) REFERENCES account(uuid) ON DELETE CASCADE
-	  	);
-	`
-
-	_, errCreateTicketTable := db.Exec(createTicketTable)
-	if errCreateTicketTable != nil {
-		log.Fatal("Failed to create ticket table:", errCreateTicketTable)
+// RunCommand executes one subcommand, every command but create needs the database.
+func RunCommand(config *MigrationConfig, command string, args []string) error {
+	if command == "create" {
+		if len(args) != 1 {
+			return fmt.Errorf("%w: create takes exactly one migration name", UsageError)
+		}
+		upPath, downPath, errCreate := migration.Create(config.Dir, args[0])
+		if errCreate != nil {
+			return errCreate
+		}
+		log.Printf("Created %s and %s", upPath, downPath)
+		return nil
+	}
+
+	if !slices.Contains([]string{"up", "down", "goto", "status", "explain"}, command) {
+		return fmt.Errorf("%w: unknown command %q", UsageError, command)
+	}
+	if err := ValidateConfig(config); err != nil {
+		return fmt.Errorf("%w: %v", UsageError, err)
 	}
 
-	log.Println("Ticket table created successfully")
+	migrator, db := OpenMigrator(config)
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Printf("Error closing database connection: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		count, errUp := migrator.Up(ctx)
+		if errUp != nil {
+			return errUp
+		}
+		log.Printf("Applied %d migration(s)", count)
+	case "down":
+		n := 1
+		if len(args) > 0 {
+			parsed, errParse := strconv.Atoi(args[0])
+			if errParse != nil || parsed < 1 {
+				return fmt.Errorf("%w: down takes a positive number of migrations, got %q", UsageError, args[0])
+			}
+			n = parsed
+		}
+		count, errDown := migrator.Down(ctx, n)
+		if errDown != nil {
+			return errDown
+		}
+		log.Printf("Rolled back %d migration(s)", count)
+	case "goto":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: goto takes exactly one version", UsageError)
+		}
+		version, errParse := strconv.ParseInt(args[0], 10, 64)
+		if errParse != nil || version < 0 {
+			return fmt.Errorf("%w: goto takes a version number, got %q", UsageError, args[0])
+		}
+		count, errGoto := migrator.Goto(ctx, version)
+		if errGoto != nil {
+			return errGoto
+		}
+		log.Printf("Migrated to version %d, %d migration(s) run", version, count)
+	case "status":
+		statuses, errStatus := migrator.Status(ctx)
+		if errStatus != nil {
+			return errStatus
+		}
+		PrintStatus(statuses)
+	case "explain":
+		// a seeder that could not be captured fails the command after the others are reported
+		plans, errCapture := migration.CaptureSeedPlans(ctx, db)
+		reports, errExplain := migration.Explain(ctx, db, plans, config.MinRows)
+		if errExplain != nil {
+			return errExplain
+		}
+		if PrintPlans(reports) {
+			return fmt.Errorf("a seeder query scans a table of %d or more rows sequentially", config.MinRows)
+		}
+		if errCapture != nil {
+			return fmt.Errorf("capture seeder queries: %w", errCapture)
+		}
+	}
+
+	return nil
 }
 
 func StartMigration() {
@@ -142,13 +257,19 @@ func StartMigration() {
 		return
 	}
 
-	if err := ValidateConfig(config); err != nil {
+	// no command keeps the old behaviour of bringing the schema up to date
+	command, args := "up", flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	if err := RunCommand(config, command, args); err != nil {
 		fmt.Printf("Error: %v\n\n", err)
-		ShowHelp()
+		if errors.Is(err, UsageError) {
+			ShowHelp()
+		}
 		os.Exit(1)
 	}
-
-	CreateTables(config)
 }
 
 func main() {
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ticket;
+DROP TABLE IF EXISTS account;
@@ -0,0 +1,25 @@
+-- Baseline: the account and ticket tables cmd/migrate used to create with
+-- CREATE TABLE IF NOT EXISTS. Every migration up to 0009 is idempotent so
+-- databases created by the old tool adopt them without changes, older ones
+-- are upgraded in place.
+
+CREATE TABLE IF NOT EXISTS account (
+    uuid varchar(36) PRIMARY KEY,
+    randid varchar(16) UNIQUE NOT NULL,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW(),
+    name varchar(255) NOT NULL,
+    email varchar(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ticket (
+    uuid varchar(36) PRIMARY KEY,
+    randid varchar(16) UNIQUE NOT NULL,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW(),
+    account_uuid varchar(36) NOT NULL,
+    description text NOT NULL,
+    resolved boolean NOT NULL DEFAULT false,
+    security_risk bigint NOT NULL DEFAULT 0,
+    FOREIGN KEY (account_uuid) REFERENCES account(uuid) ON DELETE CASCADE
+);
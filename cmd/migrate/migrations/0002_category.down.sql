@@ -0,0 +1,2 @@
+ALTER TABLE ticket DROP COLUMN IF EXISTS category_uuid;
+DROP TABLE IF EXISTS category;
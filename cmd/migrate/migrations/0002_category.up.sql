@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS category (
+    uuid varchar(36) PRIMARY KEY,
+    randid varchar(16) UNIQUE NOT NULL,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW(),
+    category varchar(255) UNIQUE NOT NULL
+);
+
+ALTER TABLE ticket
+    ADD COLUMN IF NOT EXISTS category_uuid varchar(36) REFERENCES category(uuid) ON DELETE SET NULL;
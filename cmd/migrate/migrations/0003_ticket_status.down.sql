@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS ticket_transition;
+
+ALTER TABLE ticket
+    ADD COLUMN IF NOT EXISTS resolved boolean NOT NULL DEFAULT false;
+UPDATE ticket SET resolved = true WHERE status = 'resolved';
+ALTER TABLE ticket DROP COLUMN IF EXISTS status;
@@ -0,0 +1,26 @@
+-- the resolved flag becomes a status, every move between statuses is kept
+ALTER TABLE ticket
+    ADD COLUMN IF NOT EXISTS status varchar(32) NOT NULL DEFAULT 'open';
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'ticket' AND column_name = 'resolved'
+    ) THEN
+        UPDATE ticket SET status = 'resolved' WHERE resolved;
+        ALTER TABLE ticket DROP COLUMN resolved;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS ticket_transition (
+    uuid varchar(36) PRIMARY KEY,
+    randid varchar(16) UNIQUE NOT NULL,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW(),
+    ticket_uuid varchar(36) NOT NULL,
+    from_status varchar(32) NOT NULL,
+    to_status varchar(32) NOT NULL,
+    note text NOT NULL DEFAULT '',
+    FOREIGN KEY (ticket_uuid) REFERENCES ticket(uuid) ON DELETE CASCADE
+);
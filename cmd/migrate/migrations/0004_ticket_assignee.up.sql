@@ -0,0 +1,2 @@
+ALTER TABLE ticket
+    ADD COLUMN IF NOT EXISTS assignee_uuid varchar(36) REFERENCES account(uuid) ON DELETE SET NULL;
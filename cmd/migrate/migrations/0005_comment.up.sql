@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS comment (
+    uuid varchar(36) PRIMARY KEY,
+    randid varchar(16) UNIQUE NOT NULL,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW(),
+    ticket_uuid varchar(36) NOT NULL,
+    account_uuid varchar(36) NOT NULL,
+    parent_uuid varchar(36),
+    body text NOT NULL,
+    FOREIGN KEY (ticket_uuid) REFERENCES ticket(uuid) ON DELETE CASCADE,
+    FOREIGN KEY (account_uuid) REFERENCES account(uuid) ON DELETE CASCADE,
+    FOREIGN KEY (parent_uuid) REFERENCES comment(uuid) ON DELETE CASCADE
+);
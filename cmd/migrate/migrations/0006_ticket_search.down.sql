@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ticket_description_search_idx;
@@ -0,0 +1,3 @@
+-- the expression must match the one used by the search query for the index to be picked
+CREATE INDEX IF NOT EXISTS ticket_description_search_idx
+    ON ticket USING GIN (to_tsvector('english', description));
@@ -0,0 +1,13 @@
+-- no foreign key, a delete leaves its outbox row behind until the relay has applied it
+CREATE TABLE IF NOT EXISTS ticket_outbox (
+    id bigserial PRIMARY KEY,
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    ticket_uuid varchar(36) NOT NULL,
+    previous jsonb,
+    current jsonb,
+    attempts integer NOT NULL DEFAULT 0,
+    last_error text,
+    next_attempt_at timestamp NOT NULL DEFAULT NOW(),
+    processed_at timestamp
+);
+CREATE INDEX IF NOT EXISTS ticket_outbox_pending_idx ON ticket_outbox (id) WHERE processed_at IS NULL;
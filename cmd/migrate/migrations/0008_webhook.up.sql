@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS webhook_subscription (
+    uuid varchar(36) PRIMARY KEY,
+    randid varchar(16) NOT NULL UNIQUE,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    url text NOT NULL,
+    secret varchar(64) NOT NULL,
+    event_types text[] NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    uuid varchar(36) PRIMARY KEY,
+    randid varchar(16) NOT NULL UNIQUE,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    subscription_uuid varchar(36) NOT NULL REFERENCES webhook_subscription(uuid) ON DELETE CASCADE,
+    event_id varchar(16) NOT NULL,
+    event_type varchar(64) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(16) NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp NOT NULL DEFAULT NOW(),
+    last_status_code integer,
+    last_error text,
+    delivered_at timestamp
+);
+CREATE INDEX IF NOT EXISTS webhook_delivery_due_idx ON webhook_delivery (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS webhook_delivery_subscription_idx ON webhook_delivery (subscription_uuid, created_at DESC);
@@ -0,0 +1,11 @@
+-- revoked keys stay in the table so admins can still see who used what
+CREATE TABLE IF NOT EXISTS api_key (
+    uuid varchar(36) PRIMARY KEY,
+    randid varchar(16) NOT NULL UNIQUE,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    name varchar(255) NOT NULL,
+    hash char(64) NOT NULL,
+    scopes text[] NOT NULL,
+    revoked_at timestamp
+);
@@ -0,0 +1,7 @@
+-- migrate:no-transaction
+DROP INDEX CONCURRENTLY IF EXISTS ticket_assignee_created_at_idx;
+DROP INDEX CONCURRENTLY IF EXISTS ticket_account_created_at_idx;
+DROP INDEX CONCURRENTLY IF EXISTS ticket_status_created_at_idx;
+DROP INDEX CONCURRENTLY IF EXISTS ticket_category_created_at_idx;
+DROP INDEX CONCURRENTLY IF EXISTS ticket_security_risk_idx;
+DROP INDEX CONCURRENTLY IF EXISTS ticket_created_at_idx;
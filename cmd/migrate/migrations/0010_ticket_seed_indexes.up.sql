@@ -0,0 +1,29 @@
+-- migrate:no-transaction
+-- One index per TicketRepository seeder, built concurrently so writes keep
+-- flowing on a live table. internal/repository/ticket_plan.go lists the
+-- queries each one serves, cmd/migrate explain checks they are picked.
+
+-- SeedTickets, SeedPage, SeedByDate
+CREATE INDEX CONCURRENTLY IF NOT EXISTS ticket_created_at_idx
+    ON ticket (created_at DESC);
+
+-- SeedTicketsBySecurityRisk, created_at breaks ties between equal risks
+CREATE INDEX CONCURRENTLY IF NOT EXISTS ticket_security_risk_idx
+    ON ticket (security_risk DESC, created_at DESC);
+
+-- SeedByCategory
+CREATE INDEX CONCURRENTLY IF NOT EXISTS ticket_category_created_at_idx
+    ON ticket (category_uuid, created_at DESC);
+
+-- SeedByStatus
+CREATE INDEX CONCURRENTLY IF NOT EXISTS ticket_status_created_at_idx
+    ON ticket (status, created_at DESC);
+
+-- SeedByAccount, also serves the account delete cascade
+CREATE INDEX CONCURRENTLY IF NOT EXISTS ticket_account_created_at_idx
+    ON ticket (account_uuid, created_at DESC);
+
+-- SeedByAssignee, most tickets are unassigned
+CREATE INDEX CONCURRENTLY IF NOT EXISTS ticket_assignee_created_at_idx
+    ON ticket (assignee_uuid, created_at DESC)
+    WHERE assignee_uuid IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE ticket_outbox DROP COLUMN IF EXISTS deferred_purge;
@@ -0,0 +1,4 @@
+-- bulk imports purge pages and searches once when they finish, their outbox
+-- rows skip the per-row purge
+ALTER TABLE ticket_outbox
+    ADD COLUMN IF NOT EXISTS deferred_purge boolean NOT NULL DEFAULT false;
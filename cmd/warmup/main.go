@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	_ "github.com/lib/pq"
+	"log"
+	"os"
+	"redifu-example/pkg/utils"
+	"redifu-example/pkg/warmup"
+	"time"
+)
+
+type WarmupConfig struct {
+	Timeline         bool
+	SecurityTimeline bool
+	Pages            int64
+	Categories       string
+	Windows          string
+	JSON             bool
+	Help             bool
+}
+
+func ParseWarmupArgs() *WarmupConfig {
+	config := &WarmupConfig{}
+
+	flag.BoolVar(&config.Timeline, "timeline", true, "Seed the first page of the ticket timeline")
+	flag.BoolVar(&config.SecurityTimeline, "security", true, "Seed the first page of the security risk timeline")
+	flag.Int64Var(&config.Pages, "pages", 3, "Number of ticket pages to seed")
+	flag.StringVar(&config.Categories, "categories", "", "Comma separated category randIds (default: every category)")
+	flag.StringVar(&config.Windows, "windows", "24h", "Comma separated date windows ending now")
+	flag.BoolVar(&config.JSON, "json", false, "Print the report as JSON")
+	flag.BoolVar(&config.Help, "help", false, "Show help message")
+	flag.BoolVar(&config.Help, "h", false, "Show help message")
+
+	flag.Parse()
+
+	return config
+}
+
+func ShowHelp() {
+	fmt.Println("Redifu Example Cache Warm-up Tool")
+	fmt.Println("=======================")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run main.go [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -timeline bool       Seed the first page of the ticket timeline (default: true)")
+	fmt.Println("  -security bool       Seed the first page of the security risk timeline (default: true)")
+	fmt.Println("  -pages int           Number of ticket pages to seed (default: 3)")
+	fmt.Println("  -categories string   Comma separated category randIds (default: every category)")
+	fmt.Println("  -windows string      Comma separated date windows ending now (default: 24h)")
+	fmt.Println("  -json                Print the report as JSON")
+	fmt.Println("  -help, -h            Show this help message")
+	fmt.Println()
+	fmt.Println("Connections are read from the same environment as the API:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME, DB_SSLMODE, REDIS_HOST, REDIS_USER, REDIS_PASS")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run main.go -pages 5 -windows 24h,168h")
+	fmt.Println("  go run main.go -security=false -categories abc123,def456 -json")
+}
+
+func ToConfig(config *WarmupConfig) (*warmup.Config, error) {
+	if config.Pages < 0 {
+		return nil, fmt.Errorf("pages must not be negative")
+	}
+
+	windows, errParse := warmup.ParseWindows(config.Windows)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	return &warmup.Config{
+		Timeline:         config.Timeline,
+		SecurityTimeline: config.SecurityTimeline,
+		Pages:            config.Pages,
+		Categories:       warmup.ParseCategories(config.Categories),
+		Windows:          windows,
+	}, nil
+}
+
+func NewWarmer() *warmup.Warmer {
+	db := utils.CreatePostgresConnection(os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_USER"),
+		os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), os.Getenv("DB_SSLMODE"))
+	redisClient := utils.ConnectRedis(os.Getenv("REDIS_HOST"), os.Getenv("REDIS_USER"),
+		os.Getenv("REDIS_PASS"), false)
+
+	return warmup.NewWarmerFromConnections(db, redisClient)
+}
+
+func PrintReport(reports []warmup.Report, total time.Duration, asJSON bool) {
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(map[string]interface{}{
+			"structures": reports,
+			"total":      total,
+		})
+		return
+	}
+
+	for _, report := range reports {
+		outcome := "ok"
+		if report.Error != "" {
+			outcome = "failed: " + report.Error
+		}
+		fmt.Printf("%-40s %12s  %s\n", report.Structure, report.Duration.Round(time.Millisecond), outcome)
+	}
+	fmt.Printf("%-40s %12s\n", "total", total.Round(time.Millisecond))
+}
+
+func StartWarmup() {
+	config := ParseWarmupArgs()
+
+	if config.Help {
+		ShowHelp()
+		return
+	}
+
+	warmupConfig, errConfig := ToConfig(config)
+	if errConfig != nil {
+		fmt.Printf("Error: %v\n\n", errConfig)
+		ShowHelp()
+		os.Exit(1)
+	}
+
+	warmer := NewWarmer()
+
+	startedAt := time.Now()
+	reports := warmer.Run(context.Background(), warmupConfig)
+	PrintReport(reports, time.Since(startedAt), config.JSON)
+
+	for _, report := range reports {
+		if report.Error != "" {
+			log.Println("Warm-up finished with errors")
+			os.Exit(1)
+		}
+	}
+}
+
+func main() {
+	StartWarmup()
+}
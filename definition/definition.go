@@ -7,6 +7,36 @@ import (
 
 var BaseTTL = 3 * time.Hour
 var SortedSetTTL = 1 * time.Hour
+var SearchTTL = 2 * time.Minute
 var ItemPerPage = int64(5)
+var SeedLockLease = 10 * time.Second
+var SeedLockPollInterval = 50 * time.Millisecond
+var BackgroundSeedWorkers = 4
+var BackgroundSeedQueueSize = 256
+var BackgroundSeedTimeout = 30 * time.Second
+var OutboxRelayInterval = 1 * time.Second
+var OutboxBatchSize = 100
+var OutboxRetryBase = 1 * time.Second
+var OutboxRetryMax = 1 * time.Minute
+var OutboxRetention = 24 * time.Hour
+var EventStream = "ticket-events"
+var EventStreamMaxLen = int64(100000)
+var StreamHeartbeatInterval = 15 * time.Second
+var StreamReadCount = int64(100)
+var StreamPoolSize = 500
+var WebhookMaxAttempts = 8
+var WebhookRetryBase = 5 * time.Second
+var WebhookRetryMax = 1 * time.Hour
+var WebhookTimeout = 10 * time.Second
+var WebhookPollInterval = 2 * time.Second
+var WebhookBatchSize = 50
+var ImportBatchSize = 500
+var ImportMaxErrors = 1000
+var BatchMaxOperations = 100
 
 var NotFound = errors.New("item not found")
+var Conflict = errors.New("item already exists")
+var InvalidStatus = errors.New("invalid ticket status")
+var InvalidTransition = errors.New("invalid ticket status transition")
+var Forbidden = errors.New("caller is not allowed to modify this item")
+var InvalidOperation = errors.New("invalid batch operation")
@@ -0,0 +1,35 @@
+package fetcher
+
+import (
+	"context"
+	"github.com/21strive/redifu"
+	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
+)
+
+type APIKeyFetcher struct {
+	base *redifu.Base[*model.APIKey]
+}
+
+func (a *APIKeyFetcher) Init(fetcherPool *pools.FetcherPool) {
+	a.base = fetcherPool.BaseAPIKey
+}
+
+func (a *APIKeyFetcher) Fetch(ctx context.Context, randId string) (*model.APIKey, error) {
+	apiKey, err := a.base.Get(ctx, randId)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+func (a *APIKeyFetcher) IsBlank(ctx context.Context, randId string) (bool, error) {
+	return a.base.IsMissing(ctx, randId)
+}
+
+func NewAPIKeyFetcher(fetcherPool *pools.FetcherPool) *APIKeyFetcher {
+	apiKeyFetcher := &APIKeyFetcher{}
+	apiKeyFetcher.Init(fetcherPool)
+	return apiKeyFetcher
+}
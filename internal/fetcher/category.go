@@ -0,0 +1,45 @@
+package fetcher
+
+import (
+	"context"
+	"github.com/21strive/redifu"
+	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
+)
+
+type CategoryFetcher struct {
+	base   *redifu.Base[*model.Category]
+	sorted *redifu.Sorted[*model.Category]
+}
+
+func (c *CategoryFetcher) Init(fetcherPool *pools.FetcherPool) {
+	c.base = fetcherPool.BaseCategory
+	c.sorted = fetcherPool.SortedCategory
+}
+
+func (c *CategoryFetcher) Fetch(ctx context.Context, randId string) (*model.Category, error) {
+	category, err := c.base.Get(ctx, randId)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (c *CategoryFetcher) IsBlank(ctx context.Context, randId string) (bool, error) {
+	return c.base.IsMissing(ctx, randId)
+}
+
+func (c *CategoryFetcher) FetchAll(ctx context.Context) ([]*model.Category, error) {
+	return c.sorted.Fetch(redifu.Descending).Exec(ctx)
+}
+
+func (c *CategoryFetcher) IsSortedSeedingRequired(ctx context.Context) (bool, error) {
+	return c.sorted.RequiresSeeding(ctx)
+}
+
+func NewCategoryFetcher(fetcherPool *pools.FetcherPool) *CategoryFetcher {
+	categoryFetcher := &CategoryFetcher{}
+	categoryFetcher.Init(fetcherPool)
+	return categoryFetcher
+}
@@ -0,0 +1,41 @@
+package fetcher
+
+import (
+	"context"
+	"github.com/21strive/redifu"
+	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
+)
+
+type CommentFetcher struct {
+	base     *redifu.Base[*model.Comment]
+	timeline *redifu.Timeline[*model.Comment]
+}
+
+func (c *CommentFetcher) Init(fetcherPool *pools.FetcherPool) {
+	c.base = fetcherPool.BaseComment
+	c.timeline = fetcherPool.CommentTimeline
+}
+
+func (c *CommentFetcher) Fetch(ctx context.Context, randId string) (*model.Comment, error) {
+	comment, err := c.base.Get(ctx, randId)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (c *CommentFetcher) FetchTimeline(ctx context.Context, ticketRandId string, lastRandId []string) *redifu.FetchOutput[*model.Comment] {
+	return c.timeline.Fetch(lastRandId).WithParams(ticketRandId).Exec(ctx)
+}
+
+func (c *CommentFetcher) IsTimelineSeedingRequired(ctx context.Context, ticketRandId string, totalReceivedItem int64) (bool, error) {
+	return c.timeline.RequiresSeeding(ctx, totalReceivedItem, ticketRandId)
+}
+
+func NewCommentFetcher(fetcherPool *pools.FetcherPool) *CommentFetcher {
+	commentFetcher := &CommentFetcher{}
+	commentFetcher.Init(fetcherPool)
+	return commentFetcher
+}
@@ -13,9 +13,13 @@ type TicketFetcher struct {
 	timeline               *redifu.Timeline[*model.Ticket]
 	timelineByCategory     *redifu.Timeline[*model.Ticket]
 	timelineBySecurityRisk *redifu.Timeline[*model.Ticket]
+	timelineByStatus       *redifu.Timeline[*model.Ticket]
 	sortedByAccount        *redifu.Sorted[*model.Ticket]
+	sortedByAssignee       *redifu.Sorted[*model.Ticket]
 	page                   *redifu.Page[*model.Ticket]
 	timeSeries             *redifu.TimeSeries[*model.Ticket]
+	search                 *pools.SearchPage
+	timelineByFilter       *redifu.Timeline[*model.Ticket]
 }
 
 func (t *TicketFetcher) Init(
@@ -23,17 +27,25 @@ func (t *TicketFetcher) Init(
 	timeline *redifu.Timeline[*model.Ticket],
 	timelineByCategory *redifu.Timeline[*model.Ticket],
 	timelineBySecurityRisk *redifu.Timeline[*model.Ticket],
+	timelineByStatus *redifu.Timeline[*model.Ticket],
 	sortedByAccount *redifu.Sorted[*model.Ticket],
+	sortedByAssignee *redifu.Sorted[*model.Ticket],
 	page *redifu.Page[*model.Ticket],
 	timeSeries *redifu.TimeSeries[*model.Ticket],
+	search *pools.SearchPage,
+	timelineByFilter *redifu.Timeline[*model.Ticket],
 ) {
 	t.base = base
 	t.timeline = timeline
 	t.timelineByCategory = timelineByCategory
 	t.timelineBySecurityRisk = timelineBySecurityRisk
+	t.timelineByStatus = timelineByStatus
 	t.sortedByAccount = sortedByAccount
+	t.sortedByAssignee = sortedByAssignee
 	t.page = page
 	t.timeSeries = timeSeries
+	t.search = search
+	t.timelineByFilter = timelineByFilter
 }
 
 func (t *TicketFetcher) Fetch(ctx context.Context, randid string) (*model.Ticket, error) {
@@ -73,6 +85,22 @@ func (t *TicketFetcher) IsTimelineBySecurityRiskSeedingRequired(ctx context.Cont
 	return t.timelineBySecurityRisk.RequiresSeeding(ctx, totalReceivedItem)
 }
 
+func (t *TicketFetcher) FetchTimelineByStatus(ctx context.Context, status string, lastRandId []string) *redifu.FetchOutput[*model.Ticket] {
+	return t.timelineByStatus.Fetch(lastRandId).WithParams(status).Exec(ctx)
+}
+
+func (t *TicketFetcher) IsTimelineByStatusSeedingRequired(ctx context.Context, status string, totalReceivedItem int64) (bool, error) {
+	return t.timelineByStatus.RequiresSeeding(ctx, totalReceivedItem, status)
+}
+
+func (t *TicketFetcher) FetchTimelineByFilter(ctx context.Context, filterKey string, lastRandId []string) *redifu.FetchOutput[*model.Ticket] {
+	return t.timelineByFilter.Fetch(lastRandId).WithParams(filterKey).Exec(ctx)
+}
+
+func (t *TicketFetcher) IsTimelineByFilterSeedingRequired(ctx context.Context, filterKey string, totalReceivedItem int64) (bool, error) {
+	return t.timelineByFilter.RequiresSeeding(ctx, totalReceivedItem, filterKey)
+}
+
 func (t *TicketFetcher) FetchSortedByReporter(ctx context.Context, reporterUUID string) ([]*model.Ticket, error) {
 	return t.sortedByAccount.Fetch(redifu.Descending).WithParams(reporterUUID).Exec(ctx)
 }
@@ -81,6 +109,14 @@ func (t *TicketFetcher) IsSortedByReporterSeedingRequired(ctx context.Context, r
 	return t.sortedByAccount.RequiresSeeding(ctx, reporterUUID)
 }
 
+func (t *TicketFetcher) FetchSortedByAssignee(ctx context.Context, assigneeUUID string) ([]*model.Ticket, error) {
+	return t.sortedByAssignee.Fetch(redifu.Descending).WithParams(assigneeUUID).Exec(ctx)
+}
+
+func (t *TicketFetcher) IsSortedByAssigneeSeedingRequired(ctx context.Context, assigneeUUID string) (bool, error) {
+	return t.sortedByAssignee.RequiresSeeding(ctx, assigneeUUID)
+}
+
 func (t *TicketFetcher) FetchByPage(ctx context.Context, page int64) ([]*model.Ticket, error) {
 	return t.page.Fetch(page).Exec(ctx)
 }
@@ -93,6 +129,10 @@ func (t *TicketFetcher) FetchByRange(ctx context.Context, lowerbound time.Time,
 	return t.timeSeries.Fetch(lowerbound, upperbound).Exec(ctx)
 }
 
+func (t *TicketFetcher) FetchSearch(ctx context.Context, query string, page int64) ([]*model.Ticket, bool, error) {
+	return t.search.Fetch(ctx, query, page)
+}
+
 func NewTicketFetcher(fetcherPool *pools.FetcherPool) *TicketFetcher {
 	ticketFetcher := &TicketFetcher{}
 	ticketFetcher.Init(
@@ -100,8 +140,12 @@ func NewTicketFetcher(fetcherPool *pools.FetcherPool) *TicketFetcher {
 		fetcherPool.Timeline,
 		fetcherPool.TimelineByCategory,
 		fetcherPool.TimelineSortBySecurityRisk,
+		fetcherPool.TimelineByStatus,
 		fetcherPool.SortedByAccount,
+		fetcherPool.SortedByAssignee,
 		fetcherPool.Page,
-		fetcherPool.TimeSeries)
+		fetcherPool.TimeSeries,
+		fetcherPool.TicketSearch,
+		fetcherPool.TimelineByFilter)
 	return ticketFetcher
 }
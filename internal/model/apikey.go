@@ -0,0 +1,44 @@
+package model
+
+import (
+	"github.com/21strive/redifu"
+	"time"
+)
+
+const (
+	ScopeTicketRead   = "ticket:read"
+	ScopeTicketWrite  = "ticket:write"
+	ScopeAccountRead  = "account:read"
+	ScopeAccountWrite = "account:write"
+)
+
+var Scopes = []string{ScopeTicketRead, ScopeTicketWrite, ScopeAccountRead, ScopeAccountWrite}
+
+// APIKey authenticates a service caller. Only the SHA-256 of the secret is
+// stored, it is cached with the key so verification stays in Redis.
+type APIKey struct {
+	*redifu.Record
+	Name      string     `json:"name"`
+	Hash      string     `json:"hash"`
+	Scopes    []string   `json:"scopes"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func NewAPIKey() *APIKey {
+	apiKey := &APIKey{}
+	redifu.InitRecord(apiKey)
+	return apiKey
+}
@@ -4,7 +4,7 @@ import "github.com/21strive/redifu"
 
 type Category struct {
 	*redifu.Record
-	Category string
+	Category string `json:"category"`
 }
 
 func (c *Category) SetCategory(category string) {
@@ -0,0 +1,36 @@
+package model
+
+import "github.com/21strive/redifu"
+
+type Comment struct {
+	*redifu.Record
+	TicketUUID    string `json:"ticket_uuid"`
+	AccountUUID   string `json:"account_uuid"`
+	AccountRandId string `json:",omitempty"`
+	ParentUUID    string `json:"parent_uuid"`
+	Body          string `json:"body"`
+
+	Account *Account
+}
+
+func (c *Comment) SetTicketUUID(ticketUUID string) {
+	c.TicketUUID = ticketUUID
+}
+
+func (c *Comment) SetAccountUUID(accountUUID string) {
+	c.AccountUUID = accountUUID
+}
+
+func (c *Comment) SetParentUUID(parentUUID string) {
+	c.ParentUUID = parentUUID
+}
+
+func (c *Comment) SetBody(body string) {
+	c.Body = body
+}
+
+func NewComment() *Comment {
+	comment := &Comment{}
+	redifu.InitRecord(comment)
+	return comment
+}
@@ -2,15 +2,26 @@ package model
 
 import "github.com/21strive/redifu"
 
+const (
+	StatusOpen             = "open"
+	StatusTriaged          = "triaged"
+	StatusInProgress       = "in_progress"
+	StatusAwaitingReporter = "awaiting_reporter"
+	StatusResolved         = "resolved"
+	StatusClosed           = "closed"
+	StatusReopened         = "reopened"
+)
+
 type Ticket struct {
 	*redifu.Record
 	Description    string `json:"description"`
-	Resolved       bool   `json:"action_taken"`
+	Status         string `json:"status"`
 	SecurityRisk   int64  `json:"security_risk"`
 	AccountUUID    string `json:"account_uuid"`
 	AccountRandId  string `json:",omitempty"`
 	CategoryUUID   string `json:"category_uuid"`
 	CategoryRandId string `json:",omitempty"`
+	AssigneeUUID   string `json:"assignee_uuid"`
 
 	Account  *Account
 	Category *Category
@@ -24,8 +35,17 @@ func (t *Ticket) SetAccountUUID(accountUUID string) {
 	t.AccountUUID = accountUUID
 }
 
-func (t *Ticket) SetResolved() {
-	t.Resolved = true
+func (t *Ticket) SetCategory(category *Category) {
+	t.CategoryUUID = category.GetUUID()
+	t.CategoryRandId = category.GetRandId()
+}
+
+func (t *Ticket) SetAssigneeUUID(assigneeUUID string) {
+	t.AssigneeUUID = assigneeUUID
+}
+
+func (t *Ticket) SetStatus(status string) {
+	t.Status = status
 }
 
 func (t *Ticket) SetSecurityRisk(risk int64) {
@@ -35,5 +55,6 @@ func (t *Ticket) SetSecurityRisk(risk int64) {
 func NewTicket() *Ticket {
 	ticket := &Ticket{}
 	redifu.InitRecord(ticket)
+	ticket.Status = StatusOpen
 	return ticket
 }
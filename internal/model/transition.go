@@ -0,0 +1,22 @@
+package model
+
+import "github.com/21strive/redifu"
+
+type TicketTransition struct {
+	*redifu.Record
+	TicketUUID string `json:"ticket_uuid"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Note       string `json:"note"`
+}
+
+func NewTicketTransition(ticketUUID string, fromStatus string, toStatus string, note string) *TicketTransition {
+	transition := &TicketTransition{
+		TicketUUID: ticketUUID,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		Note:       note,
+	}
+	redifu.InitRecord(transition)
+	return transition
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/21strive/redifu"
+	"time"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookSubscription is an integrator endpoint, Secret signs every payload
+// sent to it and is only shown once when the subscription is created.
+type WebhookSubscription struct {
+	*redifu.Record
+	URL        string   `json:"url"`
+	Secret     string   `json:"-"`
+	EventTypes []string `json:"event_types"`
+}
+
+func NewWebhookSubscription(url string, secret string, eventTypes []string) *WebhookSubscription {
+	subscription := &WebhookSubscription{
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	}
+	redifu.InitRecord(subscription)
+	return subscription
+}
+
+// WebhookDelivery is one attempt chain of one event to one subscription.
+type WebhookDelivery struct {
+	*redifu.Record
+	SubscriptionUUID string          `json:"subscription_uuid"`
+	EventID          string          `json:"event_id"`
+	EventType        string          `json:"event_type"`
+	Payload          json.RawMessage `json:"payload"`
+	Status           string          `json:"status"`
+	Attempts         int             `json:"attempts"`
+	NextAttemptAt    time.Time       `json:"next_attempt_at"`
+	LastStatusCode   int             `json:"last_status_code,omitempty"`
+	LastError        string          `json:"last_error,omitempty"`
+	DeliveredAt      *time.Time      `json:"delivered_at,omitempty"`
+
+	// URL and Secret are joined in from the subscription when a delivery is claimed
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+func NewWebhookDelivery(subscriptionUUID string, eventID string, eventType string, payload []byte) *WebhookDelivery {
+	delivery := &WebhookDelivery{
+		SubscriptionUUID: subscriptionUUID,
+		EventID:          eventID,
+		EventType:        eventType,
+		Payload:          payload,
+		Status:           DeliveryPending,
+	}
+	redifu.InitRecord(delivery)
+	return delivery
+}
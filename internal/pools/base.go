@@ -10,52 +10,97 @@ import (
 type FetcherPool struct {
 	BaseTicket                 *redifu.Base[*model.Ticket]
 	BaseAccount                *redifu.Base[*model.Account]
+	BaseCategory               *redifu.Base[*model.Category]
+	BaseComment                *redifu.Base[*model.Comment]
+	BaseAPIKey                 *redifu.Base[*model.APIKey]
 	Timeline                   *redifu.Timeline[*model.Ticket] // timeline
 	TimelineByCategory         *redifu.Timeline[*model.Ticket] // timeline with param, query & relation
 	TimelineSortBySecurityRisk *redifu.Timeline[*model.Ticket] // timeline sort by custom parameter
+	TimelineByStatus           *redifu.Timeline[*model.Ticket] // timeline with param, one per lifecycle status
+	TimelineByFilter           *redifu.Timeline[*model.Ticket] // timeline with param, one per filter combination
+	FilterIndex                *KeyIndex                       // filter combinations currently cached
 	SortedByAccount            *redifu.Sorted[*model.Ticket]
+	SortedByAssignee           *redifu.Sorted[*model.Ticket]
 	Page                       *redifu.Page[*model.Ticket]
 	TimeSeries                 *redifu.TimeSeries[*model.Ticket]
+	SortedCategory             *redifu.Sorted[*model.Category]
+	CommentTimeline            *redifu.Timeline[*model.Comment] // timeline with param, one per ticket
+	TicketSearch               *SearchPage                      // full-text search pages, one hash per normalized query
+	TicketSortedSets           *SortedSetWriter                 // pipelined writes to the ticket sorted sets above
 }
 
 func NewFetcherPool(redisClient redis.UniversalClient) *FetcherPool {
 	base := redifu.NewBase[*model.Ticket](redisClient, "ticket:%s", definition.BaseTTL)
 	baseAccount := redifu.NewBase[*model.Account](redisClient, "account:%s", definition.BaseTTL)
 	baseCategory := redifu.NewBase[*model.Category](redisClient, "category:%s", definition.BaseTTL)
+	baseComment := redifu.NewBase[*model.Comment](redisClient, "comment:%s", definition.BaseTTL)
+	baseAPIKey := redifu.NewBase[*model.APIKey](redisClient, "api-key:%s", definition.BaseTTL)
 
 	accountRelation := redifu.NewRelation[*model.Account](baseAccount, redifu.TypeOf[model.Ticket]())
 	categoryRelation := redifu.NewRelation[*model.Category](baseCategory, redifu.TypeOf[model.Ticket]())
 
-	timeline := redifu.NewTimeline[*model.Ticket](redisClient, base, "ticket-timeline", definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
+	timeline := redifu.NewTimeline[*model.Ticket](redisClient, base, TicketTimelineKey, definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
 	timeline.AddRelation("account", accountRelation)
 	timeline.AddRelation("category", categoryRelation)
 
-	timelineByCategory := redifu.NewTimeline[*model.Ticket](redisClient, base, "ticket-timeline:category:%s", definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
+	timelineByCategory := redifu.NewTimeline[*model.Ticket](redisClient, base, TicketTimelineByCategoryKey, definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
 	timelineByCategory.AddRelation("account", accountRelation)
 	timelineByCategory.AddRelation("category", categoryRelation)
 
-	timelineSortBySecurityRisk := redifu.NewTimeline[*model.Ticket](redisClient, base, "ticket-timeline-by-security", definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
+	timelineSortBySecurityRisk := redifu.NewTimeline[*model.Ticket](redisClient, base, TicketTimelineBySecurityKey, definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
 	timelineSortBySecurityRisk.AddRelation("account", accountRelation)
 	timelineSortBySecurityRisk.AddRelation("category", categoryRelation)
 	timelineSortBySecurityRisk.SetSortingReference("SecurityRisk")
 
-	sortedByAccount := redifu.NewSorted[*model.Ticket](redisClient, base, "ticket-sorted-by-account", definition.SortedSetTTL)
+	timelineByStatus := redifu.NewTimeline[*model.Ticket](redisClient, base, TicketTimelineByStatusKey, definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
+	timelineByStatus.AddRelation("account", accountRelation)
+	timelineByStatus.AddRelation("category", categoryRelation)
+
+	timelineByFilter := redifu.NewTimeline[*model.Ticket](redisClient, base, TicketTimelineByFilterKey, definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
+	timelineByFilter.AddRelation("account", accountRelation)
+	timelineByFilter.AddRelation("category", categoryRelation)
+	filterIndex := NewKeyIndex(redisClient, "ticket-timeline:filter-index", definition.SortedSetTTL)
+
+	sortedByAccount := redifu.NewSorted[*model.Ticket](redisClient, base, TicketSortedByAccountKey, definition.SortedSetTTL)
 	sortedByAccount.AddRelation("account", accountRelation)
 
+	sortedByAssignee := redifu.NewSorted[*model.Ticket](redisClient, base, TicketSortedByAssigneeKey, definition.SortedSetTTL)
+	sortedByAssignee.AddRelation("account", accountRelation)
+
 	page := redifu.NewPage[*model.Ticket](redisClient, base, "ticket-page", definition.ItemPerPage, redifu.Descending, definition.SortedSetTTL)
 	page.AddRelation("account", accountRelation)
 
-	timeSeries := redifu.NewTimeSeries[*model.Ticket](redisClient, base, "ticket-time-series", definition.SortedSetTTL)
+	timeSeries := redifu.NewTimeSeries[*model.Ticket](redisClient, base, TicketTimeSeriesKey, definition.SortedSetTTL)
 	timeSeries.AddRelation("account", accountRelation)
 
+	sortedCategory := redifu.NewSorted[*model.Category](redisClient, baseCategory, "category-sorted", definition.SortedSetTTL)
+
+	commentAccountRelation := redifu.NewRelation[*model.Account](baseAccount, redifu.TypeOf[model.Comment]())
+	commentTimeline := redifu.NewTimeline[*model.Comment](redisClient, baseComment, TicketCommentsKey, definition.ItemPerPage, redifu.Ascending, definition.SortedSetTTL)
+	commentTimeline.AddRelation("account", commentAccountRelation)
+
+	ticketSortedSets := NewSortedSetWriter(redisClient, definition.SortedSetTTL)
+	ticketSearch := NewSearchPage(redisClient, base, "ticket-search:query:%s", "ticket-search:index", definition.SearchTTL)
+
 	return &FetcherPool{
 		BaseTicket:                 base,
 		BaseAccount:                baseAccount,
+		BaseCategory:               baseCategory,
+		BaseComment:                baseComment,
+		BaseAPIKey:                 baseAPIKey,
 		Timeline:                   timeline,
 		TimelineByCategory:         timelineByCategory,
 		TimelineSortBySecurityRisk: timelineSortBySecurityRisk,
+		TimelineByStatus:           timelineByStatus,
+		TimelineByFilter:           timelineByFilter,
+		FilterIndex:                filterIndex,
 		SortedByAccount:            sortedByAccount,
+		SortedByAssignee:           sortedByAssignee,
 		Page:                       page,
 		TimeSeries:                 timeSeries,
+		SortedCategory:             sortedCategory,
+		CommentTimeline:            commentTimeline,
+		TicketSearch:               ticketSearch,
+		TicketSortedSets:           ticketSortedSets,
 	}
 }
@@ -0,0 +1,55 @@
+package pools
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// KeyIndex remembers which params of a parameterised structure are cached
+// right now, so writes can visit them without scanning Redis.
+type KeyIndex struct {
+	client redis.UniversalClient
+	key    string
+	ttl    time.Duration
+}
+
+// Add records the param and pushes the index TTL forward. Every write that
+// extends the TTL of a listed structure calls Touch after it, so the index
+// always outlives the structures it points to.
+func (i *KeyIndex) Add(ctx context.Context, param string) error {
+	pipe := i.client.TxPipeline()
+	pipe.SAdd(ctx, i.key, param)
+	pipe.Expire(ctx, i.key, i.ttl)
+	_, errExec := pipe.Exec(ctx)
+	return errExec
+}
+
+// Touch pushes the index TTL forward without adding a param.
+func (i *KeyIndex) Touch(ctx context.Context) error {
+	return i.client.Expire(ctx, i.key, i.ttl).Err()
+}
+
+func (i *KeyIndex) Members(ctx context.Context) ([]string, error) {
+	return i.client.SMembers(ctx, i.key).Result()
+}
+
+func (i *KeyIndex) Remove(ctx context.Context, params ...string) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, 0, len(params))
+	for _, param := range params {
+		members = append(members, param)
+	}
+	return i.client.SRem(ctx, i.key, members...).Err()
+}
+
+func NewKeyIndex(client redis.UniversalClient, key string, ttl time.Duration) *KeyIndex {
+	return &KeyIndex{
+		client: client,
+		key:    key,
+		ttl:    ttl,
+	}
+}
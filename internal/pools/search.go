@@ -0,0 +1,110 @@
+package pools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/21strive/redifu"
+	"github.com/redis/go-redis/v9"
+	"redifu-example/internal/model"
+	"strconv"
+	"time"
+)
+
+// SearchPage caches full-text search results the way redifu.Page caches the
+// plain listing: one hash per normalized query, one field per page holding
+// the ranked randIds, items themselves live in the shared base.
+// Every cached query is tracked in an index set so writes can find and purge
+// the queries they affect.
+type SearchPage struct {
+	client    redis.UniversalClient
+	base      *redifu.Base[*model.Ticket]
+	keyFormat string
+	indexKey  string
+	ttl       time.Duration
+}
+
+// Fetch returns the cached page, seedRequired is true when the page has
+// never been seeded or one of its items has been evicted from the base.
+func (s *SearchPage) Fetch(ctx context.Context, query string, page int64) ([]*model.Ticket, bool, error) {
+	raw, errGet := s.client.HGet(ctx, fmt.Sprintf(s.keyFormat, query), strconv.FormatInt(page, 10)).Result()
+	if errGet != nil {
+		if errGet == redis.Nil {
+			return nil, true, nil
+		}
+		return nil, false, errGet
+	}
+
+	var randIds []string
+	if errUnmarshal := json.Unmarshal([]byte(raw), &randIds); errUnmarshal != nil {
+		return nil, false, errUnmarshal
+	}
+
+	tickets := make([]*model.Ticket, 0, len(randIds))
+	for _, randId := range randIds {
+		ticket, errFetch := s.base.Get(ctx, randId)
+		if errFetch != nil {
+			return nil, false, errFetch
+		}
+		if ticket == nil {
+			return nil, true, nil
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, false, nil
+}
+
+func (s *SearchPage) Set(ctx context.Context, query string, page int64, tickets []*model.Ticket) error {
+	randIds := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		errSet := s.base.Set(ctx, ticket)
+		if errSet != nil {
+			return errSet
+		}
+		randIds = append(randIds, ticket.GetRandId())
+	}
+
+	encoded, errMarshal := json.Marshal(randIds)
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	// the index is refreshed on every seed so it always outlives the pages it points to
+	key := fmt.Sprintf(s.keyFormat, query)
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, key, strconv.FormatInt(page, 10), encoded)
+	pipe.Expire(ctx, key, s.ttl)
+	pipe.SAdd(ctx, s.indexKey, query)
+	pipe.Expire(ctx, s.indexKey, s.ttl)
+	_, errExec := pipe.Exec(ctx)
+	return errExec
+}
+
+func (s *SearchPage) Queries(ctx context.Context) ([]string, error) {
+	return s.client.SMembers(ctx, s.indexKey).Result()
+}
+
+func (s *SearchPage) Purge(ctx context.Context, queries ...string) error {
+	if len(queries) == 0 {
+		return nil
+	}
+
+	pipe := s.client.TxPipeline()
+	for _, query := range queries {
+		pipe.Del(ctx, fmt.Sprintf(s.keyFormat, query))
+		pipe.SRem(ctx, s.indexKey, query)
+	}
+	_, errExec := pipe.Exec(ctx)
+	return errExec
+}
+
+func NewSearchPage(client redis.UniversalClient, base *redifu.Base[*model.Ticket], keyFormat string, indexKey string, ttl time.Duration) *SearchPage {
+	return &SearchPage{
+		client:    client,
+		base:      base,
+		keyFormat: keyFormat,
+		indexKey:  indexKey,
+		ttl:       ttl,
+	}
+}
@@ -11,9 +11,14 @@ type SeederPool struct {
 	TimelineSeeder                   *redifu.TimelineSeeder[*model.Ticket]
 	TimelineSortBySecurityRiskSeeder *redifu.TimelineSeeder[*model.Ticket]
 	TimelineByCategorySeeder         *redifu.TimelineSeeder[*model.Ticket]
+	TimelineByStatusSeeder           *redifu.TimelineSeeder[*model.Ticket]
+	TimelineByFilterSeeder           *redifu.TimelineSeeder[*model.Ticket]
 	SortedByAccountSeeder            *redifu.SortedSeeder[*model.Ticket]
+	SortedByAssigneeSeeder           *redifu.SortedSeeder[*model.Ticket]
 	PageSeeder                       *redifu.PageSeeder[*model.Ticket]
 	TimeSeriesSeeder                 *redifu.TimeSeriesSeeder[*model.Ticket]
+	SortedCategorySeeder             *redifu.SortedSeeder[*model.Category]
+	CommentTimelineSeeder            *redifu.TimelineSeeder[*model.Comment]
 }
 
 func (s *SeederPool) InitTicketSeeder(redisClient redis.UniversalClient, readDB *sql.DB, baseTicket *redifu.Base[*model.Ticket], timelineTicket *redifu.Timeline[*model.Ticket]) {
@@ -28,10 +33,22 @@ func (s *SeederPool) InitTicketByCategorySeeder(redisClient redis.UniversalClien
 	s.TimelineByCategorySeeder = redifu.NewTimelineSeeder[*model.Ticket](redisClient, readDB, baseTicket, timelineTicket)
 }
 
+func (s *SeederPool) InitTicketByStatusSeeder(redisClient redis.UniversalClient, readDB *sql.DB, baseTicket *redifu.Base[*model.Ticket], timelineTicket *redifu.Timeline[*model.Ticket]) {
+	s.TimelineByStatusSeeder = redifu.NewTimelineSeeder[*model.Ticket](redisClient, readDB, baseTicket, timelineTicket)
+}
+
+func (s *SeederPool) InitTicketByFilterSeeder(redisClient redis.UniversalClient, readDB *sql.DB, baseTicket *redifu.Base[*model.Ticket], timelineTicket *redifu.Timeline[*model.Ticket]) {
+	s.TimelineByFilterSeeder = redifu.NewTimelineSeeder[*model.Ticket](redisClient, readDB, baseTicket, timelineTicket)
+}
+
 func (s *SeederPool) InitTicketByAccountSeeder(redisClient redis.UniversalClient, readDB *sql.DB, baseTicket *redifu.Base[*model.Ticket], sortedTicket *redifu.Sorted[*model.Ticket]) {
 	s.SortedByAccountSeeder = redifu.NewSortedSeeder[*model.Ticket](redisClient, readDB, baseTicket, sortedTicket)
 }
 
+func (s *SeederPool) InitTicketByAssigneeSeeder(redisClient redis.UniversalClient, readDB *sql.DB, baseTicket *redifu.Base[*model.Ticket], sortedTicket *redifu.Sorted[*model.Ticket]) {
+	s.SortedByAssigneeSeeder = redifu.NewSortedSeeder[*model.Ticket](redisClient, readDB, baseTicket, sortedTicket)
+}
+
 func (s *SeederPool) InitTicketPageSeeder(redisClient redis.UniversalClient, readDB *sql.DB, baseTicket *redifu.Base[*model.Ticket], pageTicket *redifu.Page[*model.Ticket]) {
 	s.PageSeeder = redifu.NewPageSeeder[*model.Ticket](redisClient, readDB, baseTicket, pageTicket)
 }
@@ -40,6 +57,27 @@ func (s *SeederPool) InitTicketTimeSeriesSeeder(redisClient redis.UniversalClien
 	s.TimeSeriesSeeder = redifu.NewTimeSeriesSeeder[*model.Ticket](redisClient, readDB, baseTicket, timeSeriesTicket)
 }
 
+func (s *SeederPool) InitCategorySeeder(redisClient redis.UniversalClient, readDB *sql.DB, baseCategory *redifu.Base[*model.Category], sortedCategory *redifu.Sorted[*model.Category]) {
+	s.SortedCategorySeeder = redifu.NewSortedSeeder[*model.Category](redisClient, readDB, baseCategory, sortedCategory)
+}
+
+func (s *SeederPool) InitCommentSeeder(redisClient redis.UniversalClient, readDB *sql.DB, baseComment *redifu.Base[*model.Comment], commentTimeline *redifu.Timeline[*model.Comment]) {
+	s.CommentTimelineSeeder = redifu.NewTimelineSeeder[*model.Comment](redisClient, readDB, baseComment, commentTimeline)
+}
+
+// InitTicketSeeders wires every ticket seeder to the structures of fetcherPool.
+func (s *SeederPool) InitTicketSeeders(redisClient redis.UniversalClient, readDB *sql.DB, fetcherPool *FetcherPool) {
+	s.InitTicketSeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.Timeline)
+	s.InitTicketBySecurityRiskSeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.TimelineSortBySecurityRisk)
+	s.InitTicketByCategorySeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.TimelineByCategory)
+	s.InitTicketByStatusSeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.TimelineByStatus)
+	s.InitTicketByFilterSeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.TimelineByFilter)
+	s.InitTicketByAccountSeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.SortedByAccount)
+	s.InitTicketByAssigneeSeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.SortedByAssignee)
+	s.InitTicketPageSeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.Page)
+	s.InitTicketTimeSeriesSeeder(redisClient, readDB, fetcherPool.BaseTicket, fetcherPool.TimeSeries)
+}
+
 func NewSeederPool() *SeederPool {
 	return &SeederPool{}
 }
@@ -0,0 +1,126 @@
+package pools
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// Key formats of the ticket sorted sets, shared by the redifu structures that
+// read them and the SortedSetWriter that keeps them current.
+const (
+	TicketTimelineKey           = "ticket-timeline"
+	TicketTimelineByCategoryKey = "ticket-timeline:category:%s"
+	TicketTimelineBySecurityKey = "ticket-timeline-by-security"
+	TicketTimelineByStatusKey   = "ticket-timeline:status:%s"
+	TicketTimelineByFilterKey   = "ticket-timeline:filter:%s"
+	TicketSortedByAccountKey    = "ticket-sorted-by-account:%s"
+	TicketSortedByAssigneeKey   = "ticket-sorted-by-assignee:%s"
+	TicketTimeSeriesKey         = "ticket-time-series"
+	TicketCommentsKey           = "ticket-comments:%s"
+)
+
+// The scripts below mirror redifu v0.13.0-rc.3, the version go.mod requires,
+// down to the page flag keys it keeps next to a set under these private
+// suffixes. Check them against redifu's Timeline and Sorted before upgrading.
+const (
+	firstPageSuffix = ":firstpage"
+	lastPageSuffix  = ":lastpage"
+	blankPageSuffix = ":blankpage"
+)
+
+// SortedSetChange moves one member in or out of a redifu sorted set. Window is
+// the page size of a timeline and 0 for a sorted set or a time series. Purge
+// drops the whole set with its page flags instead, the next read seeds it.
+type SortedSetChange struct {
+	Key    string
+	Member string
+	Score  float64
+	Remove bool
+	Purge  bool
+	Window int64
+}
+
+// timelineAdd follows redifu's Timeline.AddItem for a descending timeline: a
+// member is only added to a seeded set and only when it sorts inside the
+// cached window, a full first page stops being complete.
+var timelineAdd = redis.NewScript(`
+redis.call('DEL', KEYS[2])
+local total = redis.call('ZCARD', KEYS[1])
+if total == 0 then
+	return 0
+end
+local lowest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if tonumber(ARGV[1]) < tonumber(lowest[2]) then
+	return 0
+end
+if total == tonumber(ARGV[3]) then
+	redis.call('DEL', KEYS[3])
+end
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+
+// timelineRemove follows redifu's Timeline.RemoveItem, an emptied set loses
+// its page flags.
+var timelineRemove = redis.NewScript(`
+redis.call('ZREM', KEYS[1], ARGV[1])
+if redis.call('ZCARD', KEYS[1]) == 0 then
+	redis.call('DEL', KEYS[2], KEYS[3])
+end
+return 0
+`)
+
+// sortedAdd follows redifu's Sorted.AddItem, a member is only added to a
+// seeded set.
+var sortedAdd = redis.NewScript(`
+redis.call('DEL', KEYS[2])
+if redis.call('ZCARD', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
+// SortedSetWriter applies membership changes to redifu sorted sets in one
+// MULTI/EXEC. redifu structures send their commands one call at a time, a
+// write touching a dozen timelines goes through here instead.
+type SortedSetWriter struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+func (w *SortedSetWriter) Apply(ctx context.Context, changes []SortedSetChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	ttl := int64(w.ttl.Seconds())
+	_, errExec := w.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, change := range changes {
+			switch {
+			case change.Purge:
+				pipe.Del(ctx, change.Key, change.Key+firstPageSuffix, change.Key+lastPageSuffix, change.Key+blankPageSuffix)
+			case change.Remove && change.Window > 0:
+				timelineRemove.Eval(ctx, pipe, []string{change.Key, change.Key + firstPageSuffix, change.Key + lastPageSuffix}, change.Member)
+			case change.Remove:
+				pipe.ZRem(ctx, change.Key, change.Member)
+			case change.Window > 0:
+				timelineAdd.Eval(ctx, pipe, []string{change.Key, change.Key + blankPageSuffix, change.Key + firstPageSuffix}, change.Score, change.Member, change.Window, ttl)
+			default:
+				sortedAdd.Eval(ctx, pipe, []string{change.Key, change.Key + blankPageSuffix}, change.Score, change.Member, ttl)
+			}
+		}
+		return nil
+	})
+	return errExec
+}
+
+func NewSortedSetWriter(client redis.UniversalClient, ttl time.Duration) *SortedSetWriter {
+	return &SortedSetWriter{
+		client: client,
+		ttl:    ttl,
+	}
+}
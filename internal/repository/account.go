@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/21strive/redifu"
+	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 	"redifu-example/definition"
 	"redifu-example/internal/model"
@@ -43,11 +45,11 @@ func (ar *AccountRepository) Create(ctx context.Context, account *model.Account)
 }
 
 func (ar *AccountRepository) FindByUUID(accountUUID string) (*model.Account, error) {
-	query := "SELECT uuid, randid, name, email FROM account WHERE uuid = $1"
+	query := "SELECT uuid, randid, created_at, updated_at, name, email FROM account WHERE uuid = $1"
 	row := ar.db.QueryRow(query, accountUUID)
 
 	account := model.NewAccount()
-	err := row.Scan(&account.UUID, &account.RandId, &account.Name, &account.Email)
+	err := row.Scan(&account.UUID, &account.RandId, &account.CreatedAt, &account.UpdatedAt, &account.Name, &account.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, definition.NotFound
@@ -58,6 +60,42 @@ func (ar *AccountRepository) FindByUUID(accountUUID string) (*model.Account, err
 	return account, nil
 }
 
+func (ar *AccountRepository) FindByRandId(randId string) (*model.Account, error) {
+	query := "SELECT uuid, randid, created_at, updated_at, name, email FROM account WHERE randid = $1"
+	row := ar.db.QueryRow(query, randId)
+
+	account := model.NewAccount()
+	err := row.Scan(&account.UUID, &account.RandId, &account.CreatedAt, &account.UpdatedAt, &account.Name, &account.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// FindExisting returns which of accountUUIDs belong to an account.
+func (ar *AccountRepository) FindExisting(ctx context.Context, accountUUIDs []string) (map[string]bool, error) {
+	rows, errQuery := ar.db.QueryContext(ctx, "SELECT uuid FROM account WHERE uuid = ANY($1)", pq.Array(accountUUIDs))
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool, len(accountUUIDs))
+	for rows.Next() {
+		var accountUUID string
+		if errScan := rows.Scan(&accountUUID); errScan != nil {
+			return nil, errScan
+		}
+		existing[accountUUID] = true
+	}
+
+	return existing, rows.Err()
+}
+
 func (ar *AccountRepository) SeedByUUID(ctx context.Context, accountUUID string) error {
 	accountFromDB, errFind := ar.FindByUUID(accountUUID)
 	if errFind != nil {
@@ -72,15 +110,32 @@ func (ar *AccountRepository) SeedByUUID(ctx context.Context, accountUUID string)
 	return ar.base.Set(ctx, accountFromDB)
 }
 
+func (ar *AccountRepository) SeedByRandId(ctx context.Context, randId string) error {
+	accountFromDB, errFind := ar.FindByRandId(randId)
+	if errFind != nil {
+		if errFind == definition.NotFound {
+			ar.base.MarkAsMissing(ctx, randId)
+		}
+		return errFind
+	}
+
+	errSet := ar.redisClient.Set(ctx, "account:pointer:"+accountFromDB.GetUUID(), accountFromDB.GetRandId(), definition.BaseTTL).Err()
+	if errSet != nil {
+		return errSet
+	}
+
+	return ar.base.Set(ctx, accountFromDB)
+}
+
 func (ar *AccountRepository) Update(ctx context.Context, account *model.Account) error {
-	query := "UPDATE account SET name = $1, email = $2 WHERE uuid = $3"
+	query := "UPDATE account SET name = $1, email = $2, updated_at = $3 WHERE uuid = $4"
 	stmt, err := ar.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, errUpdate := stmt.Exec(account.Name, account.Email, account.GetUUID())
+	_, errUpdate := stmt.Exec(account.Name, account.Email, account.GetUpdatedAt(), account.GetUUID())
 	if errUpdate != nil {
 		return errUpdate
 	}
@@ -93,6 +148,64 @@ func (ar *AccountRepository) Update(ctx context.Context, account *model.Account)
 	return nil
 }
 
+// Delete removes the account row in the transaction detach ran in, so the
+// account and whatever detach cleared go together or not at all. The comments
+// the account wrote go with it through ON DELETE CASCADE, the comment
+// timelines of their tickets are dropped and reseed on the next read.
+func (ar *AccountRepository) Delete(ctx context.Context, account *model.Account, detach func(tx *sql.Tx) error) error {
+	tx, errBegin := ar.db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
+	}
+	defer tx.Rollback()
+
+	errDetach := detach(tx)
+	if errDetach != nil {
+		return errDetach
+	}
+
+	commentedQuery := `
+		SELECT DISTINCT t.randid
+		FROM comment c
+		JOIN ticket t ON c.ticket_uuid = t.uuid
+		WHERE c.account_uuid = $1
+	`
+	rows, errQuery := tx.QueryContext(ctx, commentedQuery, account.GetUUID())
+	if errQuery != nil {
+		return errQuery
+	}
+	var commentTimelines []string
+	for rows.Next() {
+		var ticketRandId string
+		if errScan := rows.Scan(&ticketRandId); errScan != nil {
+			rows.Close()
+			return errScan
+		}
+		commentTimelines = append(commentTimelines, fmt.Sprintf(pools.TicketCommentsKey, ticketRandId))
+	}
+	rows.Close()
+	if errRows := rows.Err(); errRows != nil {
+		return errRows
+	}
+
+	_, errDelete := tx.ExecContext(ctx, "DELETE FROM account WHERE uuid = $1", account.GetUUID())
+	if errDelete != nil {
+		return errDelete
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	errDel := ar.redisClient.Del(ctx, append(commentTimelines, "account:pointer:"+account.GetUUID())...).Err()
+	if errDel != nil {
+		return errDel
+	}
+
+	return ar.base.MarkAsMissing(ctx, account.GetRandId())
+}
+
 func NewAccountRepository(db *sql.DB, redisClient redis.UniversalClient, fetcherPool *pools.FetcherPool) *AccountRepository {
 	accountRepository := &AccountRepository{}
 	accountRepository.Init(db, fetcherPool)
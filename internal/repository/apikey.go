@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/21strive/redifu"
+	"github.com/lib/pq"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
+	"time"
+)
+
+type APIKeyRepository struct {
+	db   *sql.DB
+	base *redifu.Base[*model.APIKey]
+}
+
+func (ar *APIKeyRepository) Init(db *sql.DB, fetcherPool *pools.FetcherPool) {
+	ar.db = db
+	ar.base = fetcherPool.BaseAPIKey
+}
+
+func (ar *APIKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
+	query := "INSERT INTO api_key (uuid, randid, created_at, updated_at, name, hash, scopes) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	_, errCreate := ar.db.ExecContext(ctx, query, apiKey.GetUUID(), apiKey.GetRandId(), apiKey.GetCreatedAt(), apiKey.GetUpdatedAt(),
+		apiKey.Name, apiKey.Hash, pq.Array(apiKey.Scopes))
+	if errCreate != nil {
+		return errCreate
+	}
+
+	return ar.base.Set(ctx, apiKey)
+}
+
+// Revoke keeps the row for auditing and overwrites the cached copy, so every
+// node rejects the key on its next request instead of after the TTL.
+func (ar *APIKeyRepository) Revoke(ctx context.Context, apiKey *model.APIKey) error {
+	revokedAt := time.Now().UTC()
+	_, errUpdate := ar.db.ExecContext(ctx, "UPDATE api_key SET revoked_at = $1, updated_at = $1 WHERE uuid = $2", revokedAt, apiKey.GetUUID())
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	apiKey.RevokedAt = &revokedAt
+	apiKey.SetUpdatedAt(revokedAt)
+	return ar.base.Set(ctx, apiKey)
+}
+
+func (ar *APIKeyRepository) FindByUUID(ctx context.Context, uuid string) (*model.APIKey, error) {
+	return ar.findOne(ctx, "SELECT uuid, randid, created_at, updated_at, name, hash, scopes, revoked_at FROM api_key WHERE uuid = $1", uuid)
+}
+
+func (ar *APIKeyRepository) FindByRandId(ctx context.Context, randId string) (*model.APIKey, error) {
+	return ar.findOne(ctx, "SELECT uuid, randid, created_at, updated_at, name, hash, scopes, revoked_at FROM api_key WHERE randid = $1", randId)
+}
+
+func (ar *APIKeyRepository) List(ctx context.Context) ([]*model.APIKey, error) {
+	rows, errQuery := ar.db.QueryContext(ctx, "SELECT uuid, randid, created_at, updated_at, name, hash, scopes, revoked_at FROM api_key ORDER BY created_at DESC")
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var apiKeys []*model.APIKey
+	for rows.Next() {
+		apiKey, errScan := apiKeyScanner(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, rows.Err()
+}
+
+func (ar *APIKeyRepository) SeedByRandId(ctx context.Context, randId string) error {
+	apiKey, errFind := ar.FindByRandId(ctx, randId)
+	if errFind != nil {
+		if errFind == definition.NotFound {
+			ar.base.MarkAsMissing(ctx, randId)
+		}
+		return errFind
+	}
+
+	return ar.base.Set(ctx, apiKey)
+}
+
+func (ar *APIKeyRepository) findOne(ctx context.Context, query string, arg string) (*model.APIKey, error) {
+	apiKey, errScan := apiKeyScanner(ar.db.QueryRowContext(ctx, query, arg))
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, errScan
+	}
+
+	return apiKey, nil
+}
+
+func apiKeyScanner(row scannable) (*model.APIKey, error) {
+	apiKey := model.NewAPIKey()
+	var scopes pq.StringArray
+	var revokedAt sql.NullTime
+	errScan := row.Scan(&apiKey.UUID, &apiKey.RandId, &apiKey.CreatedAt, &apiKey.UpdatedAt, &apiKey.Name, &apiKey.Hash, &scopes, &revokedAt)
+	apiKey.Scopes = scopes
+	if revokedAt.Valid {
+		apiKey.RevokedAt = &revokedAt.Time
+	}
+	return apiKey, errScan
+}
+
+func NewAPIKeyRepository(db *sql.DB, fetcherPool *pools.FetcherPool) *APIKeyRepository {
+	apiKeyRepository := &APIKeyRepository{}
+	apiKeyRepository.Init(db, fetcherPool)
+	return apiKeyRepository
+}
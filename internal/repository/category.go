@@ -3,16 +3,87 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"github.com/21strive/redifu"
+	"github.com/lib/pq"
 	"redifu-example/definition"
 	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
 )
 
 type CategoryRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	base         *redifu.Base[*model.Category]
+	sorted       *redifu.Sorted[*model.Category]
+	sortedSeeder *redifu.SortedSeeder[*model.Category]
 }
 
-func (c *CategoryRepository) Init(db *sql.DB) {
+func (c *CategoryRepository) Init(db *sql.DB, base *redifu.Base[*model.Category], sorted *redifu.Sorted[*model.Category], sortedSeeder *redifu.SortedSeeder[*model.Category]) {
 	c.db = db
+	c.base = base
+	c.sorted = sorted
+	c.sortedSeeder = sortedSeeder
+}
+
+func (c *CategoryRepository) Create(ctx context.Context, category *model.Category) error {
+	query := "INSERT INTO category (uuid, randid, created_at, updated_at, category) VALUES ($1, $2, $3, $4, $5)"
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, errCreate := stmt.ExecContext(ctx, category.GetUUID(), category.GetRandId(), category.GetCreatedAt(), category.GetUpdatedAt(), category.Category)
+	if errCreate != nil {
+		if isUniqueViolation(errCreate) {
+			return definition.Conflict
+		}
+		return errCreate
+	}
+
+	errSet := c.base.Set(ctx, category)
+	if errSet != nil {
+		return errSet
+	}
+	c.sorted.AddItem(ctx, category)
+
+	return nil
+}
+
+func (c *CategoryRepository) Update(ctx context.Context, category *model.Category) error {
+	query := "UPDATE category SET category = $1, updated_at = $2 WHERE uuid = $3"
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, errUpdate := stmt.ExecContext(ctx, category.Category, category.GetUpdatedAt(), category.GetUUID())
+	if errUpdate != nil {
+		if isUniqueViolation(errUpdate) {
+			return definition.Conflict
+		}
+		return errUpdate
+	}
+
+	return c.base.Set(ctx, category)
+}
+
+func (c *CategoryRepository) Delete(ctx context.Context, category *model.Category) error {
+	query := "DELETE FROM category WHERE uuid = $1"
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, errDelete := stmt.ExecContext(ctx, category.GetUUID())
+	if errDelete != nil {
+		return errDelete
+	}
+
+	c.sorted.RemoveItem(ctx, category)
+	return c.base.MarkAsMissing(ctx, category.GetRandId())
 }
 
 func (c *CategoryRepository) FindByRandId(ctx context.Context, randId string) (*model.Category, error) {
@@ -36,8 +107,81 @@ func (c *CategoryRepository) FindByRandId(ctx context.Context, randId string) (*
 	return category, nil
 }
 
-func NewCategoryRepository(db *sql.DB) *CategoryRepository {
+func (c *CategoryRepository) FindByUUID(ctx context.Context, uuid string) (*model.Category, error) {
+	query := "SELECT * FROM category WHERE uuid = $1"
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, uuid)
+	category := model.NewCategory()
+	errScan := row.Scan(&category.UUID, &category.RandId, &category.CreatedAt, &category.UpdatedAt, &category.Category)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, errScan
+	}
+
+	return category, nil
+}
+
+// FindByRandIds returns the categories among randIds keyed by randid, unknown
+// randids are left out.
+func (c *CategoryRepository) FindByRandIds(ctx context.Context, randIds []string) (map[string]*model.Category, error) {
+	rows, errQuery := c.db.QueryContext(ctx, "SELECT * FROM category WHERE randid = ANY($1)", pq.Array(randIds))
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	categories := make(map[string]*model.Category, len(randIds))
+	for rows.Next() {
+		category, errScan := categoryRowsScanner(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		categories[category.GetRandId()] = category
+	}
+
+	return categories, rows.Err()
+}
+
+func categoryRowsScanner(rows *sql.Rows) (*model.Category, error) {
+	category := model.NewCategory()
+	errScan := rows.Scan(&category.UUID, &category.RandId, &category.CreatedAt, &category.UpdatedAt, &category.Category)
+	return category, errScan
+}
+
+func (c *CategoryRepository) SeedCategory(ctx context.Context, randId string) error {
+	category, errFind := c.FindByRandId(ctx, randId)
+	if errFind != nil {
+		if errFind == definition.NotFound {
+			c.base.MarkAsMissing(ctx, randId)
+		}
+		return errFind
+	}
+
+	return c.base.Set(ctx, category)
+}
+
+func (c *CategoryRepository) SeedCategories(ctx context.Context) error {
+	query := redifu.NewQuery("category")
+
+	return c.sortedSeeder.Seed(query).Exec(ctx, categoryRowsScanner)
+}
+
+// isUniqueViolation reports whether err is Postgres rejecting a duplicate
+// value on a UNIQUE column.
+func isUniqueViolation(err error) bool {
+	var errPq *pq.Error
+	return errors.As(err, &errPq) && errPq.Code == "23505"
+}
+
+func NewCategoryRepository(db *sql.DB, fetcherPool *pools.FetcherPool, seederPool *pools.SeederPool) *CategoryRepository {
 	categoryRepository := &CategoryRepository{}
-	categoryRepository.Init(db)
+	categoryRepository.Init(db, fetcherPool.BaseCategory, fetcherPool.SortedCategory, seederPool.SortedCategorySeeder)
 	return categoryRepository
 }
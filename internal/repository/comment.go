@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/21strive/redifu"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
+)
+
+type CommentRepository struct {
+	db             *sql.DB
+	base           *redifu.Base[*model.Comment]
+	timeline       *redifu.Timeline[*model.Comment]
+	timelineSeeder *redifu.TimelineSeeder[*model.Comment]
+}
+
+func (cr *CommentRepository) Init(db *sql.DB, base *redifu.Base[*model.Comment], timeline *redifu.Timeline[*model.Comment], timelineSeeder *redifu.TimelineSeeder[*model.Comment]) {
+	cr.db = db
+	cr.base = base
+	cr.timeline = timeline
+	cr.timelineSeeder = timelineSeeder
+}
+
+// Create stores the comment and appends it to the ticket's comment timeline,
+// ticketRandId is the timeline param.
+func (cr *CommentRepository) Create(ctx context.Context, comment *model.Comment, ticketRandId string) error {
+	query := "INSERT INTO comment (uuid, randid, created_at, updated_at, ticket_uuid, account_uuid, parent_uuid, body) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	stmt, err := cr.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, errCreate := stmt.ExecContext(ctx, comment.GetUUID(), comment.GetRandId(), comment.GetCreatedAt(), comment.GetUpdatedAt(),
+		comment.TicketUUID, comment.AccountUUID, nullableUUID(comment.ParentUUID), comment.Body)
+	if errCreate != nil {
+		return errCreate
+	}
+
+	errSet := cr.base.Set(ctx, comment)
+	if errSet != nil {
+		return errSet
+	}
+
+	return cr.timeline.AddItem(ctx, comment, ticketRandId)
+}
+
+func (cr *CommentRepository) Update(ctx context.Context, comment *model.Comment) error {
+	query := "UPDATE comment SET body = $1, updated_at = $2 WHERE uuid = $3"
+	stmt, err := cr.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, errUpdate := stmt.ExecContext(ctx, comment.Body, comment.GetUpdatedAt(), comment.GetUUID())
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	return cr.base.Set(ctx, comment)
+}
+
+// Delete removes the comment together with every reply beneath it, the
+// recursive CTE returns the whole thread so each one leaves the timeline.
+func (cr *CommentRepository) Delete(ctx context.Context, comment *model.Comment, ticketRandId string) error {
+	query := `
+		WITH RECURSIVE thread AS (
+		    SELECT * FROM comment WHERE uuid = $1
+		    UNION ALL
+		    SELECT c.* FROM comment c JOIN thread ON c.parent_uuid = thread.uuid
+		)
+		DELETE FROM comment WHERE uuid IN (SELECT uuid FROM thread)
+		RETURNING *
+	`
+	stmt, err := cr.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, errDelete := stmt.QueryContext(ctx, comment.GetUUID())
+	if errDelete != nil {
+		return errDelete
+	}
+	defer rows.Close()
+
+	var deleted []*model.Comment
+	for rows.Next() {
+		deletedComment, errScan := commentRowsScanner(rows)
+		if errScan != nil {
+			return errScan
+		}
+		deleted = append(deleted, deletedComment)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return errRows
+	}
+
+	var errs []error
+	for _, deletedComment := range deleted {
+		errs = append(errs,
+			cr.timeline.RemoveItem(ctx, deletedComment, ticketRandId),
+			cr.base.MarkAsMissing(ctx, deletedComment.GetRandId()),
+		)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (cr *CommentRepository) FindByUUID(ctx context.Context, uuid string) (*model.Comment, error) {
+	query := "SELECT * FROM comment WHERE uuid = $1"
+	stmt, err := cr.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, uuid)
+	comment, errScan := commentRowScanner(row)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, errScan
+	}
+
+	return comment, nil
+}
+
+func (cr *CommentRepository) FindByRandId(ctx context.Context, randId string) (*model.Comment, error) {
+	query := "SELECT * FROM comment WHERE randid = $1"
+	stmt, err := cr.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, randId)
+	comment, errScan := commentRowScanner(row)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, errScan
+	}
+
+	return comment, nil
+}
+
+func commentRowScanner(row *sql.Row) (*model.Comment, error) {
+	comment := model.NewComment()
+	var parentUUID sql.NullString
+	errScan := row.Scan(&comment.UUID, &comment.RandId, &comment.CreatedAt, &comment.UpdatedAt, &comment.TicketUUID, &comment.AccountUUID, &parentUUID, &comment.Body)
+	comment.ParentUUID = parentUUID.String
+	return comment, errScan
+}
+
+func commentRowsScanner(rows *sql.Rows) (*model.Comment, error) {
+	comment := model.NewComment()
+	var parentUUID sql.NullString
+	errScan := rows.Scan(&comment.UUID, &comment.RandId, &comment.CreatedAt, &comment.UpdatedAt, &comment.TicketUUID, &comment.AccountUUID, &parentUUID, &comment.Body)
+	comment.ParentUUID = parentUUID.String
+	return comment, errScan
+}
+
+func commentRowsScannerWithRelation(ctx context.Context, rows *sql.Rows, relation map[string]redifu.Relation) (*model.Comment, error) {
+	account := model.NewAccount()
+	comment := model.NewComment()
+
+	var parentUUID sql.NullString
+
+	// Use sql.Null* types for nullable fields from the join
+	var accountUUID sql.NullString
+	var accountRandId sql.NullString
+	var accountCreatedAt sql.NullTime
+	var accountUpdatedAt sql.NullTime
+	var accountName sql.NullString
+	var accountEmail sql.NullString
+
+	errScan := rows.Scan(
+		&comment.UUID,
+		&comment.RandId,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.TicketUUID,
+		&comment.AccountUUID,
+		&parentUUID,
+		&comment.Body,
+		&accountUUID,
+		&accountRandId,
+		&accountCreatedAt,
+		&accountUpdatedAt,
+		&accountName,
+		&accountEmail,
+	)
+
+	if errScan != nil {
+		return comment, errScan
+	}
+	comment.ParentUUID = parentUUID.String
+
+	// Only populate account if the join returned data (not NULL)
+	if accountRandId.Valid {
+		account.UUID = accountUUID.String
+		account.RandId = accountRandId.String
+		account.CreatedAt = accountCreatedAt.Time
+		account.UpdatedAt = accountUpdatedAt.Time
+		account.Name = accountName.String
+		account.Email = accountEmail.String
+
+		comment.AccountRandId = account.RandId
+		errSet := relation["account"].SetItem(ctx, account)
+		if errSet != nil {
+			return comment, errSet
+		}
+	}
+
+	return comment, nil
+}
+
+func (cr *CommentRepository) SeedComments(ctx context.Context, subtraction int64, lastRandId string, ticketRandId string, ticketUUID string) error {
+	query := redifu.NewQuery("comment", "cm").
+		Select("cm.*, a.*").
+		LeftJoin("account", "a", "cm.account_uuid = a.uuid").
+		Where("cm.ticket_uuid", redifu.Equal).
+		OrderBy("cm.created_at", redifu.Ascending)
+
+	return cr.timelineSeeder.Seed(subtraction, lastRandId, query).
+		WithParams(ticketRandId).WithQueryArgs(ticketUUID).
+		ExecWithRelation(
+			ctx,
+			commentRowScanner,
+			commentRowsScannerWithRelation,
+		)
+}
+
+func NewCommentRepository(db *sql.DB, fetcherPool *pools.FetcherPool, seederPool *pools.SeederPool) *CommentRepository {
+	commentRepository := &CommentRepository{}
+	commentRepository.Init(db, fetcherPool.BaseComment, fetcherPool.CommentTimeline, seederPool.CommentTimelineSeeder)
+	return commentRepository
+}
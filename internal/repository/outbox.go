@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/internal/model"
+	"time"
+)
+
+// outboxLockKey serializes drains across nodes, rows must reach redifu in
+// the order they were written.
+const outboxLockKey = 7_310_254
+
+// ticketSnapshot is the outbox form of a ticket row, it keeps the columns
+// planCache diffs on.
+type ticketSnapshot struct {
+	UUID           string    `json:"uuid"`
+	RandId         string    `json:"randid"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	AccountUUID    string    `json:"account_uuid"`
+	Description    string    `json:"description"`
+	SecurityRisk   int64     `json:"security_risk"`
+	CategoryUUID   string    `json:"category_uuid"`
+	CategoryRandId string    `json:"category_rand_id"`
+	Status         string    `json:"status"`
+	AssigneeUUID   string    `json:"assignee_uuid"`
+}
+
+func encodeSnapshot(ticket *model.Ticket) ([]byte, error) {
+	if ticket == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(ticketSnapshot{
+		UUID:           ticket.GetUUID(),
+		RandId:         ticket.GetRandId(),
+		CreatedAt:      ticket.GetCreatedAt(),
+		UpdatedAt:      ticket.GetUpdatedAt(),
+		AccountUUID:    ticket.AccountUUID,
+		Description:    ticket.Description,
+		SecurityRisk:   ticket.SecurityRisk,
+		CategoryUUID:   ticket.CategoryUUID,
+		CategoryRandId: ticket.CategoryRandId,
+		Status:         ticket.Status,
+		AssigneeUUID:   ticket.AssigneeUUID,
+	})
+}
+
+func decodeSnapshot(raw []byte) (*model.Ticket, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	var snapshot ticketSnapshot
+	if errUnmarshal := json.Unmarshal(raw, &snapshot); errUnmarshal != nil {
+		return nil, errUnmarshal
+	}
+
+	ticket := model.NewTicket()
+	ticket.UUID = snapshot.UUID
+	ticket.RandId = snapshot.RandId
+	ticket.CreatedAt = snapshot.CreatedAt
+	ticket.UpdatedAt = snapshot.UpdatedAt
+	ticket.AccountUUID = snapshot.AccountUUID
+	ticket.Description = snapshot.Description
+	ticket.SecurityRisk = snapshot.SecurityRisk
+	ticket.CategoryUUID = snapshot.CategoryUUID
+	ticket.CategoryRandId = snapshot.CategoryRandId
+	ticket.Status = snapshot.Status
+	ticket.AssigneeUUID = snapshot.AssigneeUUID
+	return ticket, nil
+}
+
+func nullableSnapshot(snapshot []byte) sql.NullString {
+	return sql.NullString{String: string(snapshot), Valid: snapshot != nil}
+}
+
+// enqueueCacheChange records a ticket write in the outbox, it must run inside
+// the transaction of the write itself so the row and the cache change commit
+// or roll back together.
+func enqueueCacheChange(ctx context.Context, tx *sql.Tx, previous *model.Ticket, current *model.Ticket) error {
+	_, errEnqueue := enqueue(ctx, tx, previous, current, false)
+	return errEnqueue
+}
+
+// enqueueDeferredCacheChange is enqueueCacheChange for bulk writes, the row
+// leaves pages and searches alone and the writer enqueues a listing purge.
+func enqueueDeferredCacheChange(ctx context.Context, tx *sql.Tx, previous *model.Ticket, current *model.Ticket) error {
+	_, errEnqueue := enqueue(ctx, tx, previous, current, true)
+	return errEnqueue
+}
+
+// enqueueListingPurge records a purge of every cached page and search, bulk
+// writes add one in their transaction in place of the per-row purge their
+// deferred rows skip. It carries no snapshot, which tells it apart from a
+// ticket row, and is retried by the relay like any other row.
+func enqueueListingPurge(ctx context.Context, tx *sql.Tx) (int64, error) {
+	var id int64
+	query := "INSERT INTO ticket_outbox (ticket_uuid, previous, current, deferred_purge) VALUES ('', NULL, NULL, false) RETURNING id"
+	errInsert := tx.QueryRowContext(ctx, query).Scan(&id)
+	return id, errInsert
+}
+
+// enqueue inserts the outbox row and returns its id.
+func enqueue(ctx context.Context, tx *sql.Tx, previous *model.Ticket, current *model.Ticket, deferredPurge bool) (int64, error) {
+	previousSnapshot, errEncode := encodeSnapshot(previous)
+	if errEncode != nil {
+		return 0, errEncode
+	}
+	currentSnapshot, errEncode := encodeSnapshot(current)
+	if errEncode != nil {
+		return 0, errEncode
+	}
+
+	ticketUUID := ""
+	if current != nil {
+		ticketUUID = current.GetUUID()
+	} else if previous != nil {
+		ticketUUID = previous.GetUUID()
+	}
+
+	var id int64
+	query := "INSERT INTO ticket_outbox (ticket_uuid, previous, current, deferred_purge) VALUES ($1, $2, $3, $4) RETURNING id"
+	errInsert := tx.QueryRowContext(ctx, query, ticketUUID, nullableSnapshot(previousSnapshot), nullableSnapshot(currentSnapshot), deferredPurge).Scan(&id)
+	return id, errInsert
+}
+
+// relayOutbox applies what the write just committed. A failure is only
+// logged, the row stays in the outbox and OutboxRelay retries it. A drain
+// already running on another connection leaves the row to OutboxRelay.
+func (t *TicketRepository) relayOutbox(ctx context.Context) {
+	if _, errDrain := t.DrainOutbox(ctx); errDrain != nil {
+		logger.Logger.Error("outbox-relay-error", "error", errDrain.Error())
+	}
+}
+
+// relayOutboxThrough is relayOutbox for a batch, it waits for a drain already
+// running and then drains every pending row up to and including through, so
+// the rows the batch committed are planned together and go out in a single
+// pipeline however many there are.
+func (t *TicketRepository) relayOutboxThrough(ctx context.Context, through int64) {
+	if _, errDrain := t.drainOutbox(ctx, through); errDrain != nil {
+		logger.Logger.Error("outbox-relay-error", "error", errDrain.Error())
+	}
+}
+
+// DrainOutbox replays pending outbox rows in write order and returns how many
+// were applied. The due rows are planned into one cacheUpdate and go out in a
+// single pipeline, only then are they marked processed. Every cache operation
+// is an overwrite or a set add/remove, so a row applied twice after a failed
+// mark leaves the cache in the same state. The first row that fails is
+// rescheduled with backoff and holds back every row after it, applying later
+// writes first could leave the cache diverged for good.
+// Drains are serialized across nodes by a session advisory lock taken with
+// pg_try_advisory_lock, a drain finding it held returns right away and leaves
+// its rows to the drain holding it or to the next OutboxRelay tick. No
+// transaction is open while Redis is written.
+func (t *TicketRepository) DrainOutbox(ctx context.Context) (int, error) {
+	return t.drainOutbox(ctx, 0)
+}
+
+// drainOutbox is DrainOutbox, with through above zero it waits for the lock
+// instead of giving up and reads every pending row up to through instead of
+// one OutboxBatchSize batch.
+func (t *TicketRepository) drainOutbox(ctx context.Context, through int64) (int, error) {
+	conn, errConn := t.db.Conn(ctx)
+	if errConn != nil {
+		return 0, errConn
+	}
+	defer conn.Close()
+
+	if through > 0 {
+		_, errLock := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", outboxLockKey)
+		if errLock != nil {
+			return 0, errLock
+		}
+	} else {
+		var isLocked bool
+		errLock := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", outboxLockKey).Scan(&isLocked)
+		if errLock != nil {
+			return 0, errLock
+		}
+		if !isLocked {
+			return 0, nil
+		}
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", outboxLockKey)
+
+	pending, errPending := loadOutbox(ctx, conn, through)
+	if errPending != nil {
+		return 0, errPending
+	}
+
+	replay := t.replayOutbox(ctx, pending)
+
+	for _, retired := range replay.retired {
+		// a row that cannot be decoded will never apply, retire it instead of blocking the rest
+		_, errMark := conn.ExecContext(ctx, "UPDATE ticket_outbox SET processed_at = NOW(), last_error = $2 WHERE id = $1", retired.id, retired.err.Error())
+		if errMark != nil {
+			return 0, errMark
+		}
+	}
+
+	planned, failed, errApply := replay.applied, replay.failed, replay.err
+	if len(planned) > 0 {
+		ids := make([]int64, 0, len(planned))
+		for _, row := range planned {
+			ids = append(ids, row.id)
+		}
+		_, errMark := conn.ExecContext(ctx, "UPDATE ticket_outbox SET processed_at = NOW(), last_error = NULL WHERE id = ANY($1)", pq.Array(ids))
+		if errMark != nil {
+			return 0, errMark
+		}
+	}
+
+	if failed != nil {
+		retryIn := outboxBackoff(failed.attempts + 1)
+		_, errMark := conn.ExecContext(ctx, `
+			UPDATE ticket_outbox
+			SET attempts = attempts + 1, last_error = $2, next_attempt_at = NOW() + $3 * INTERVAL '1 millisecond'
+			WHERE id = $1
+		`, failed.id, errApply.Error(), retryIn.Milliseconds())
+		if errMark != nil {
+			return len(planned), errMark
+		}
+		errApply = fmt.Errorf("outbox row %d: %w", failed.id, errApply)
+	}
+
+	return len(planned), errApply
+}
+
+// outboxReplay is what replayOutbox made of a batch of rows: the rows whose
+// changes went out, the rows that cannot be decoded and the first row that
+// failed with its error.
+type outboxReplay struct {
+	applied []*outboxRow
+	retired []retiredRow
+	failed  *outboxRow
+	err     error
+}
+
+type retiredRow struct {
+	id  int64
+	err error
+}
+
+// replayOutbox plans the due rows of pending in write order into one
+// cacheUpdate and applies it. It stops at the first row that is not due or
+// whose plan fails, a failed apply fails every planned row.
+func (t *TicketRepository) replayOutbox(ctx context.Context, pending []outboxRow) *outboxReplay {
+	replay := &outboxReplay{}
+	update := &cacheUpdate{}
+	for i := range pending {
+		row := &pending[i]
+		if !row.isDue {
+			break
+		}
+
+		previous, errPrevious := decodeSnapshot(row.previous)
+		current, errCurrent := decodeSnapshot(row.current)
+		if errDecode := errors.Join(errPrevious, errCurrent); errDecode != nil {
+			replay.retired = append(replay.retired, retiredRow{id: row.id, err: errDecode})
+			continue
+		}
+		if previous == nil && current == nil {
+			update.purgeListings = true
+			replay.applied = append(replay.applied, row)
+			continue
+		}
+
+		// a row whose plan failed part way still goes out with what it did plan,
+		// it is retried as a whole later
+		errPlan := t.planCache(ctx, update, previous, current, !row.deferredPurge)
+		if errPlan != nil {
+			replay.failed, replay.err = row, errPlan
+			break
+		}
+		replay.applied = append(replay.applied, row)
+	}
+
+	if errCache := t.applyCache(ctx, update); errCache != nil {
+		if len(replay.applied) > 0 {
+			replay.failed = replay.applied[0]
+		}
+		replay.applied, replay.err = nil, errCache
+	}
+
+	return replay
+}
+
+type outboxRow struct {
+	id            int64
+	previous      []byte
+	current       []byte
+	deferredPurge bool
+	attempts      int
+	isDue         bool
+}
+
+// loadOutbox reads the next batch of pending rows in write order, or every
+// pending row up to through when it is above zero.
+func loadOutbox(ctx context.Context, conn *sql.Conn, through int64) ([]outboxRow, error) {
+	query := `
+		SELECT id, previous, current, deferred_purge, attempts, next_attempt_at <= NOW()
+		FROM ticket_outbox
+		WHERE processed_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+	arg := int64(definition.OutboxBatchSize)
+	if through > 0 {
+		query = `
+			SELECT id, previous, current, deferred_purge, attempts, next_attempt_at <= NOW()
+			FROM ticket_outbox
+			WHERE processed_at IS NULL AND id <= $1
+			ORDER BY id
+		`
+		arg = through
+	}
+	rows, errQuery := conn.QueryContext(ctx, query, arg)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var pending []outboxRow
+	for rows.Next() {
+		var row outboxRow
+		if errScan := rows.Scan(&row.id, &row.previous, &row.current, &row.deferredPurge, &row.attempts, &row.isDue); errScan != nil {
+			return nil, errScan
+		}
+		pending = append(pending, row)
+	}
+
+	return pending, rows.Err()
+}
+
+// PruneOutbox deletes applied rows older than the retention window.
+func (t *TicketRepository) PruneOutbox(ctx context.Context) error {
+	query := "DELETE FROM ticket_outbox WHERE processed_at < NOW() - $1 * INTERVAL '1 millisecond'"
+	_, errDelete := t.db.ExecContext(ctx, query, definition.OutboxRetention.Milliseconds())
+	return errDelete
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	backoff := definition.OutboxRetryBase
+	for i := 1; i < attempts && backoff < definition.OutboxRetryMax; i++ {
+		backoff *= 2
+	}
+	if backoff > definition.OutboxRetryMax {
+		backoff = definition.OutboxRetryMax
+	}
+	return backoff
+}
+
+// OutboxRelay drains the ticket outbox on an interval, picking up whatever the
+// writes could not apply themselves while Redis was unreachable.
+type OutboxRelay struct {
+	ticketRepository *TicketRepository
+	interval         time.Duration
+}
+
+// Run blocks until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			applied, errDrain := r.ticketRepository.DrainOutbox(ctx)
+			if errDrain != nil {
+				logger.Logger.Error("outbox-relay-error", "error", errDrain.Error(), "applied", applied)
+			}
+			if errPrune := r.ticketRepository.PruneOutbox(ctx); errPrune != nil {
+				logger.Logger.Error("outbox-prune-error", "error", errPrune.Error())
+			}
+		}
+	}
+}
+
+func NewOutboxRelay(ticketRepository *TicketRepository) *OutboxRelay {
+	return &OutboxRelay{
+		ticketRepository: ticketRepository,
+		interval:         definition.OutboxRelayInterval,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/21strive/redifu"
+	"github.com/lib/pq"
 	"redifu-example/definition"
 	"redifu-example/internal/model"
 	"redifu-example/internal/pools"
@@ -19,12 +20,50 @@ type TicketRepository struct {
 	timelineBySecurityRiskSeeder *redifu.TimelineSeeder[*model.Ticket]
 	timelineByCategory           *redifu.Timeline[*model.Ticket]
 	timelineByCategorySeeder     *redifu.TimelineSeeder[*model.Ticket]
+	timelineByStatus             *redifu.Timeline[*model.Ticket]
+	timelineByStatusSeeder       *redifu.TimelineSeeder[*model.Ticket]
 	sortedByReporter             *redifu.Sorted[*model.Ticket]
 	sortedByReporterSeeder       *redifu.SortedSeeder[*model.Ticket]
-	page                         *redifu.Page[*model.Ticket]
+	sortedByAssignee             *redifu.Sorted[*model.Ticket]
+	sortedByAssigneeSeeder       *redifu.SortedSeeder[*model.Ticket]
+	page                         listingPage
 	pageSeeder                   *redifu.PageSeeder[*model.Ticket]
 	timeSeries                   *redifu.TimeSeries[*model.Ticket]
 	timeSeriesSeeder             *redifu.TimeSeriesSeeder[*model.Ticket]
+	search                       *pools.SearchPage
+	timelineByFilter             *redifu.Timeline[*model.Ticket]
+	timelineByFilterSeeder       *redifu.TimelineSeeder[*model.Ticket]
+	filterIndex                  *pools.KeyIndex
+	sortedSets                   *pools.SortedSetWriter
+	decodeFilter                 FilterDecoder
+	writeHook                    WriteHook
+}
+
+// TicketFilter is a cached filter combination, implemented by ticket.Filter.
+type TicketFilter interface {
+	Matches(ticket *model.Ticket, categoryRandId string) bool
+}
+
+// FilterDecoder rebuilds a filter from the key its timeline is cached under.
+type FilterDecoder func(key string) (TicketFilter, error)
+
+// TicketWrite is one ticket row change, Previous is nil for a create and
+// Current for a delete. Transition is set when the write moved the status.
+type TicketWrite struct {
+	Previous   *model.Ticket
+	Current    *model.Ticket
+	Transition *model.TicketTransition
+}
+
+// WriteHook runs inside the transaction of a ticket write, after the row and
+// its outbox row, an error rolls the write back. Imports skip it, imported
+// tickets are history from another tracker.
+type WriteHook func(ctx context.Context, tx *sql.Tx, write *TicketWrite) error
+
+// listingPage is the part of redifu.Page a write needs, pages are only ever
+// purged here and reseeded on the next read.
+type listingPage interface {
+	Purge(ctx context.Context, param ...string) error
 }
 
 func (t *TicketRepository) Init(
@@ -36,12 +75,21 @@ func (t *TicketRepository) Init(
 	timelineByCategorySeeder *redifu.TimelineSeeder[*model.Ticket],
 	timelineBySecurityRisk *redifu.Timeline[*model.Ticket],
 	timelineBySecurityRiskSeeder *redifu.TimelineSeeder[*model.Ticket],
+	timelineByStatus *redifu.Timeline[*model.Ticket],
+	timelineByStatusSeeder *redifu.TimelineSeeder[*model.Ticket],
 	sortedByReporter *redifu.Sorted[*model.Ticket],
 	sortedByReporterSeeder *redifu.SortedSeeder[*model.Ticket],
+	sortedByAssignee *redifu.Sorted[*model.Ticket],
+	sortedByAssigneeSeeder *redifu.SortedSeeder[*model.Ticket],
 	page *redifu.Page[*model.Ticket],
 	pageSeeder *redifu.PageSeeder[*model.Ticket],
 	timeSeries *redifu.TimeSeries[*model.Ticket],
 	timeSeriesSeeder *redifu.TimeSeriesSeeder[*model.Ticket],
+	search *pools.SearchPage,
+	timelineByFilter *redifu.Timeline[*model.Ticket],
+	timelineByFilterSeeder *redifu.TimelineSeeder[*model.Ticket],
+	filterIndex *pools.KeyIndex,
+	sortedSets *pools.SortedSetWriter,
 ) {
 	t.db = db
 	t.base = base
@@ -51,75 +99,373 @@ func (t *TicketRepository) Init(
 	t.timelineByCategorySeeder = timelineByCategorySeeder
 	t.timelineBySecurityRisk = timelineBySecurityRisk
 	t.timelineBySecurityRiskSeeder = timelineBySecurityRiskSeeder
+	t.timelineByStatus = timelineByStatus
+	t.timelineByStatusSeeder = timelineByStatusSeeder
 	t.sortedByReporter = sortedByReporter
 	t.sortedByReporterSeeder = sortedByReporterSeeder
+	t.sortedByAssignee = sortedByAssignee
+	t.sortedByAssigneeSeeder = sortedByAssigneeSeeder
 	t.page = page
 	t.pageSeeder = pageSeeder
 	t.timeSeries = timeSeries
 	t.timeSeriesSeeder = timeSeriesSeeder
+	t.search = search
+	t.timelineByFilter = timelineByFilter
+	t.timelineByFilterSeeder = timelineByFilterSeeder
+	t.filterIndex = filterIndex
+	t.sortedSets = sortedSets
+}
+
+func (t *TicketRepository) InitFilterDecoder(decodeFilter FilterDecoder) {
+	t.decodeFilter = decodeFilter
+}
+
+func (t *TicketRepository) InitWriteHook(writeHook WriteHook) {
+	t.writeHook = writeHook
+}
+
+// runWriteHook hands write to the hook with the category randid of the
+// current row filled in, the hook announces it.
+func (t *TicketRepository) runWriteHook(ctx context.Context, tx *sql.Tx, write *TicketWrite) error {
+	if t.writeHook == nil {
+		return nil
+	}
+	if _, errResolve := t.resolveCategoryRandId(ctx, write.Current); errResolve != nil {
+		return errResolve
+	}
+	return t.writeHook(ctx, tx, write)
 }
 
 func (t *TicketRepository) Create(ctx context.Context, ticket *model.Ticket) error {
-	query := "INSERT INTO ticket (uuid, randid, created_at, updated_at, account_uuid, description, resolved, security_risk) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
-	stmt, err := t.db.Prepare(query)
-	if err != nil {
-		return err
+	tx, errBegin := t.db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
 
-	_, errCreate := stmt.Exec(ticket.GetUUID(), ticket.GetRandId(), ticket.GetCreatedAt(), ticket.GetUpdatedAt(), ticket.AccountUUID, ticket.Description, ticket.Resolved, ticket.SecurityRisk)
+	query := "INSERT INTO ticket (uuid, randid, created_at, updated_at, account_uuid, description, security_risk, category_uuid, status, assignee_uuid) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)"
+	_, errCreate := tx.ExecContext(ctx, query, ticket.GetUUID(), ticket.GetRandId(), ticket.GetCreatedAt(), ticket.GetUpdatedAt(), ticket.AccountUUID, ticket.Description, ticket.SecurityRisk, nullableUUID(ticket.CategoryUUID), ticket.Status, nullableUUID(ticket.AssigneeUUID))
 	if errCreate != nil {
 		return errCreate
 	}
 
-	t.timeline.AddItem(ctx, ticket)
-	t.sortedByReporter.AddItem(ctx, ticket, ticket.AccountUUID)
-	t.timelineBySecurityRisk.AddItem(ctx, ticket)
-	t.page.Purge(ctx)
-	t.timeSeries.AddItem(ctx, ticket)
+	errEnqueue := enqueueCacheChange(ctx, tx, nil, ticket)
+	if errEnqueue != nil {
+		return errEnqueue
+	}
+
+	errHook := t.runWriteHook(ctx, tx, &TicketWrite{Current: ticket})
+	if errHook != nil {
+		return errHook
+	}
 
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	t.relayOutbox(ctx)
 	return nil
 }
 
+// Update persists every mutable field except status, which only moves through Transition.
 func (t *TicketRepository) Update(ctx context.Context, ticket *model.Ticket) error {
-	query := "UPDATE ticket SET description = $1, resolved = $2, security_risk = $3, updated_at = $4 WHERE uuid = $5"
-	stmt, err := t.db.Prepare(query)
-	if err != nil {
-		return err
+	tx, errBegin := t.db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
 
-	_, errUpdate := stmt.Exec(ticket.Description, ticket.Resolved, ticket.SecurityRisk, ticket.GetUpdatedAt(), ticket.GetUUID())
+	previous, errUpdate := updateRow(ctx, tx, ticket)
 	if errUpdate != nil {
 		return errUpdate
 	}
 
-	errUpset := t.base.Set(ctx, ticket)
-	return errUpset
+	errEnqueue := enqueueCacheChange(ctx, tx, previous, ticket)
+	if errEnqueue != nil {
+		return errEnqueue
+	}
+
+	errHook := t.runWriteHook(ctx, tx, &TicketWrite{Previous: previous, Current: ticket})
+	if errHook != nil {
+		return errHook
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	t.relayOutbox(ctx)
+	return nil
+}
+
+// updateRow writes the Update columns and returns the row as it was before.
+func updateRow(ctx context.Context, tx *sql.Tx, ticket *model.Ticket) (*model.Ticket, error) {
+	// the CTE hands back the row as it was before the update so the cache can be diffed against it
+	query := `
+		WITH previous AS (
+		    SELECT * FROM ticket WHERE uuid = $6 FOR UPDATE
+		)
+		UPDATE ticket t
+		SET description = $1, security_risk = $2, category_uuid = $3, assignee_uuid = $4, updated_at = $5
+		FROM previous
+		WHERE t.uuid = previous.uuid
+		RETURNING previous.*
+	`
+	row := tx.QueryRowContext(ctx, query, ticket.Description, ticket.SecurityRisk, nullableUUID(ticket.CategoryUUID), nullableUUID(ticket.AssigneeUUID), ticket.GetUpdatedAt(), ticket.GetUUID())
+	previous, errUpdate := rowScanner(row)
+	if errUpdate != nil {
+		if errUpdate == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, errUpdate
+	}
+	return previous, nil
+}
+
+// Transition writes the new ticket status and its history row in one transaction.
+func (t *TicketRepository) Transition(ctx context.Context, ticket *model.Ticket, transition *model.TicketTransition) error {
+	tx, errBegin := t.db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
+	}
+	defer tx.Rollback()
+
+	previous, errTransition := transitionRow(ctx, tx, ticket, transition)
+	if errTransition != nil {
+		return errTransition
+	}
+
+	errEnqueue := enqueueCacheChange(ctx, tx, previous, ticket)
+	if errEnqueue != nil {
+		return errEnqueue
+	}
+
+	errHook := t.runWriteHook(ctx, tx, &TicketWrite{Previous: previous, Current: ticket, Transition: transition})
+	if errHook != nil {
+		return errHook
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	t.relayOutbox(ctx)
+	return nil
+}
+
+// transitionRow writes the status and its history row and returns the ticket
+// row as it was before. The update only applies while the row still holds
+// transition.FromStatus, a concurrent transition that got there first makes it
+// fail with definition.InvalidTransition instead of recording a stale history row.
+func transitionRow(ctx context.Context, tx *sql.Tx, ticket *model.Ticket, transition *model.TicketTransition) (*model.Ticket, error) {
+	updateQuery := `
+		WITH previous AS (
+		    SELECT * FROM ticket WHERE uuid = $3 FOR UPDATE
+		)
+		UPDATE ticket t
+		SET status = $1, updated_at = $2
+		FROM previous
+		WHERE t.uuid = previous.uuid AND previous.status = $4
+		RETURNING previous.*
+	`
+	row := tx.QueryRowContext(ctx, updateQuery, ticket.Status, ticket.GetUpdatedAt(), ticket.GetUUID(), transition.FromStatus)
+	previous, errUpdate := rowScanner(row)
+	if errUpdate != nil {
+		if errUpdate == sql.ErrNoRows {
+			return nil, transitionMiss(ctx, tx, ticket.GetUUID())
+		}
+		return nil, errUpdate
+	}
+
+	insertQuery := "INSERT INTO ticket_transition (uuid, randid, created_at, updated_at, ticket_uuid, from_status, to_status, note) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	_, errInsert := tx.ExecContext(ctx, insertQuery, transition.GetUUID(), transition.GetRandId(), transition.GetCreatedAt(), transition.GetUpdatedAt(),
+		transition.TicketUUID, transition.FromStatus, transition.ToStatus, transition.Note)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+	return previous, nil
+}
+
+// transitionMiss tells a missing ticket apart from one whose status moved on
+// since the caller read it.
+func transitionMiss(ctx context.Context, tx *sql.Tx, ticketUUID string) error {
+	var exists bool
+	errScan := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM ticket WHERE uuid = $1)", ticketUUID).Scan(&exists)
+	if errScan != nil {
+		return errScan
+	}
+	if !exists {
+		return definition.NotFound
+	}
+	return definition.InvalidTransition
 }
 
 func (t *TicketRepository) Delete(ctx context.Context, ticket *model.Ticket) error {
-	query := "DELETE FROM ticket WHERE uuid = $1"
-	stmt, err := t.db.Prepare(query)
-	if err != nil {
-		return err
+	tx, errBegin := t.db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
 
-	_, errDelete := stmt.Exec(ticket.GetUUID())
+	previous, errDelete := deleteRow(ctx, tx, ticket)
 	if errDelete != nil {
 		return errDelete
 	}
 
-	t.timeline.RemoveItem(ctx, ticket)
-	t.sortedByReporter.RemoveItem(ctx, ticket, ticket.AccountUUID)
-	t.timelineBySecurityRisk.RemoveItem(ctx, ticket)
-	t.page.Purge(ctx)
-	t.timeSeries.RemoveItem(ctx, ticket)
+	errEnqueue := enqueueCacheChange(ctx, tx, previous, nil)
+	if errEnqueue != nil {
+		return errEnqueue
+	}
+
+	errHook := t.runWriteHook(ctx, tx, &TicketWrite{Previous: previous})
+	if errHook != nil {
+		return errHook
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
 
+	t.relayOutbox(ctx)
 	return nil
 }
 
+func deleteRow(ctx context.Context, tx *sql.Tx, ticket *model.Ticket) (*model.Ticket, error) {
+	row := tx.QueryRowContext(ctx, "DELETE FROM ticket WHERE uuid = $1 RETURNING *", ticket.GetUUID())
+	previous, errDelete := rowScanner(row)
+	if errDelete != nil {
+		if errDelete == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, errDelete
+	}
+	return previous, nil
+}
+
+// DetachCategory clears category_uuid from every ticket in the category and
+// drops them from its timeline, it must run before the category row is deleted.
+// It returns the detached tickets so the caller can announce them.
+func (t *TicketRepository) DetachCategory(ctx context.Context, categoryUUID string, categoryRandId string) ([]*model.Ticket, error) {
+	tx, errBegin := t.db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+	defer tx.Rollback()
+
+	tickets, errUpdate := queryTickets(ctx, tx, "UPDATE ticket SET category_uuid = NULL WHERE category_uuid = $1 RETURNING *", categoryUUID)
+	if errUpdate != nil {
+		return nil, errUpdate
+	}
+
+	for _, ticket := range tickets {
+		previous := *ticket
+		previous.CategoryUUID = categoryUUID
+		previous.CategoryRandId = categoryRandId
+		errEnqueue := enqueueCacheChange(ctx, tx, &previous, ticket)
+		if errEnqueue != nil {
+			return nil, errEnqueue
+		}
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	t.relayOutbox(ctx)
+	return tickets, nil
+}
+
+// DetachAccount removes every trace of an account from the ticket table ahead
+// of the account delete: reported tickets are deleted here instead of through
+// ON DELETE CASCADE so each one can leave its timelines, assigned tickets are
+// unassigned the same way. It runs inside the transaction deleting the account,
+// call RelayOutbox once that committed. The deleted and unassigned tickets are
+// returned for the caller to announce after the commit.
+func (t *TicketRepository) DetachAccount(ctx context.Context, tx *sql.Tx, accountUUID string) (deleted []*model.Ticket, unassigned []*model.Ticket, err error) {
+	deleted, errDelete := queryTickets(ctx, tx, "DELETE FROM ticket WHERE account_uuid = $1 RETURNING *", accountUUID)
+	if errDelete != nil {
+		return nil, nil, errDelete
+	}
+
+	unassigned, errUnassign := queryTickets(ctx, tx, "UPDATE ticket SET assignee_uuid = NULL WHERE assignee_uuid = $1 RETURNING *", accountUUID)
+	if errUnassign != nil {
+		return nil, nil, errUnassign
+	}
+
+	for _, ticket := range deleted {
+		errEnqueue := enqueueCacheChange(ctx, tx, ticket, nil)
+		if errEnqueue != nil {
+			return nil, nil, errEnqueue
+		}
+	}
+	for _, ticket := range unassigned {
+		previous := *ticket
+		previous.AssigneeUUID = accountUUID
+		errEnqueue := enqueueCacheChange(ctx, tx, &previous, ticket)
+		if errEnqueue != nil {
+			return nil, nil, errEnqueue
+		}
+	}
+
+	return deleted, unassigned, nil
+}
+
+// RelayOutbox applies what a transaction handed to DetachAccount committed.
+func (t *TicketRepository) RelayOutbox(ctx context.Context) {
+	t.relayOutbox(ctx)
+}
+
+func queryTickets(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]*model.Ticket, error) {
+	rows, errQuery := tx.QueryContext(ctx, query, args...)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var tickets []*model.Ticket
+	for rows.Next() {
+		ticket, errScan := rowsScanner(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
+// resolveCategoryRandId returns the randid used as the per-category timeline
+// param, looking it up when the ticket only carries category_uuid.
+func (t *TicketRepository) resolveCategoryRandId(ctx context.Context, ticket *model.Ticket) (string, error) {
+	if ticket == nil || ticket.CategoryUUID == "" {
+		return "", nil
+	}
+	if ticket.CategoryRandId != "" {
+		return ticket.CategoryRandId, nil
+	}
+
+	var categoryRandId string
+	errScan := t.db.QueryRowContext(ctx, "SELECT randid FROM category WHERE uuid = $1", ticket.CategoryUUID).Scan(&categoryRandId)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", errScan
+	}
+
+	ticket.CategoryRandId = categoryRandId
+	return categoryRandId, nil
+}
+
+func nullableUUID(uuid string) sql.NullString {
+	return sql.NullString{String: uuid, Valid: uuid != ""}
+}
+
 func (t *TicketRepository) FindByUUID(ctx context.Context, uuid string) (*model.Ticket, error) {
 	query := "SELECT * FROM ticket WHERE uuid = $1"
 	stmt, err := t.db.PrepareContext(ctx, query)
@@ -131,6 +477,9 @@ func (t *TicketRepository) FindByUUID(ctx context.Context, uuid string) (*model.
 	row := stmt.QueryRowContext(ctx, uuid)
 	ticket, errScan := rowScanner(row)
 	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
 		return nil, errScan
 	}
 
@@ -159,13 +508,20 @@ func (t *TicketRepository) FindByRandId(randid string) (*model.Ticket, error) {
 
 func rowScanner(row *sql.Row) (*model.Ticket, error) {
 	ticket := model.NewTicket()
-	errScan := row.Scan(&ticket.UUID, &ticket.RandId, &ticket.CreatedAt, &ticket.UpdatedAt, &ticket.AccountUUID, &ticket.Description, &ticket.Resolved, &ticket.SecurityRisk, &ticket.CategoryUUID)
+	// category_uuid and assignee_uuid are nullable, unset values scan as an empty string
+	var categoryUUID, assigneeUUID sql.NullString
+	errScan := row.Scan(&ticket.UUID, &ticket.RandId, &ticket.CreatedAt, &ticket.UpdatedAt, &ticket.AccountUUID, &ticket.Description, &ticket.SecurityRisk, &categoryUUID, &ticket.Status, &assigneeUUID)
+	ticket.CategoryUUID = categoryUUID.String
+	ticket.AssigneeUUID = assigneeUUID.String
 	return ticket, errScan
 }
 
 func rowsScanner(rows *sql.Rows) (*model.Ticket, error) {
 	ticket := model.NewTicket()
-	errScan := rows.Scan(&ticket.UUID, &ticket.RandId, &ticket.CreatedAt, &ticket.UpdatedAt, &ticket.AccountUUID, &ticket.Description, &ticket.Resolved, &ticket.SecurityRisk, &ticket.CategoryUUID)
+	var categoryUUID, assigneeUUID sql.NullString
+	errScan := rows.Scan(&ticket.UUID, &ticket.RandId, &ticket.CreatedAt, &ticket.UpdatedAt, &ticket.AccountUUID, &ticket.Description, &ticket.SecurityRisk, &categoryUUID, &ticket.Status, &assigneeUUID)
+	ticket.CategoryUUID = categoryUUID.String
+	ticket.AssigneeUUID = assigneeUUID.String
 	return ticket, errScan
 }
 
@@ -174,6 +530,9 @@ func rowsScannerWithRelation(ctx context.Context, rows *sql.Rows, relation map[s
 	category := model.NewCategory()
 	ticket := model.NewTicket()
 
+	var ticketCategoryUUID sql.NullString
+	var ticketAssigneeUUID sql.NullString
+
 	// Use sql.Null* types for nullable fields from the join
 	var accountUUID sql.NullString
 	var accountRandId sql.NullString
@@ -195,9 +554,10 @@ func rowsScannerWithRelation(ctx context.Context, rows *sql.Rows, relation map[s
 		&ticket.UpdatedAt,
 		&ticket.AccountUUID,
 		&ticket.Description,
-		&ticket.Resolved,
 		&ticket.SecurityRisk,
-		&ticket.CategoryUUID,
+		&ticketCategoryUUID,
+		&ticket.Status,
+		&ticketAssigneeUUID,
 		&accountUUID,
 		&accountRandId,
 		&accountCreatedAt,
@@ -214,6 +574,8 @@ func rowsScannerWithRelation(ctx context.Context, rows *sql.Rows, relation map[s
 	if errScan != nil {
 		return ticket, errScan
 	}
+	ticket.CategoryUUID = ticketCategoryUUID.String
+	ticket.AssigneeUUID = ticketAssigneeUUID.String
 
 	// Only populate account if the join returned data (not NULL)
 	if accountRandId.Valid {
@@ -319,6 +681,23 @@ func (t *TicketRepository) SeedByCategory(ctx context.Context, subtraction int64
 		)
 }
 
+func (t *TicketRepository) SeedByStatus(ctx context.Context, subtraction int64, lastRandId string, status string) error {
+	query := redifu.NewQuery("ticket", "t").
+		Select("t.*, a.*, c.*").
+		LeftJoin("account", "a", "t.account_uuid = a.uuid").
+		LeftJoin("category", "c", "t.category_uuid = c.uuid").
+		Where("t.status", redifu.Equal).
+		OrderBy("t.created_at", redifu.Descending)
+
+	return t.timelineByStatusSeeder.Seed(subtraction, lastRandId, query).
+		WithParams(status).WithQueryArgs(status).
+		ExecWithRelation(
+			ctx,
+			rowScanner,
+			rowsScannerWithRelation,
+		)
+}
+
 func (t *TicketRepository) SeedTicketsBySecurityRisk(ctx context.Context, subtraction int64, lastRandId string) error {
 	query := redifu.NewQuery("ticket", "t").
 		Select("t.*, a.*, c.*").
@@ -345,6 +724,15 @@ func (t *TicketRepository) SeedByAccount(ctx context.Context, reporterUUID strin
 		Exec(ctx, rowsScanner)
 }
 
+func (t *TicketRepository) SeedByAssignee(ctx context.Context, assigneeUUID string) error {
+	query := redifu.NewQuery("ticket").
+		Where("assignee_uuid", redifu.Equal)
+
+	return t.sortedByAssigneeSeeder.Seed(query).
+		WithQueryArgs(assigneeUUID).
+		Exec(ctx, rowsScanner)
+}
+
 func (t *TicketRepository) SeedPage(ctx context.Context, page int64) error {
 	//query := `
 	//	  SELECT t.*, a.*
@@ -378,6 +766,105 @@ func (t *TicketRepository) SeedByDate(ctx context.Context, lowerbound time.Time,
 
 }
 
+// SeedByFilter seeds the timeline of one filter combination, query and args
+// come from ticket.Filter so the WHERE clauses stay next to Matches.
+func (t *TicketRepository) SeedByFilter(ctx context.Context, subtraction int64, lastRandId string, key string, query *redifu.Query, args []interface{}) error {
+	errIndex := t.filterIndex.Add(ctx, key)
+	if errIndex != nil {
+		return errIndex
+	}
+
+	errSeed := t.timelineByFilterSeeder.Seed(subtraction, lastRandId, query).
+		WithParams(key).WithQueryArgs(args...).
+		ExecWithRelation(
+			ctx,
+			rowScanner,
+			rowsScannerWithRelation,
+		)
+	if errSeed != nil {
+		return errSeed
+	}
+
+	// the seed set the timeline TTL after Add, the index has to outlive it
+	return t.filterIndex.Touch(ctx)
+}
+
+// SeedSearch ranks matching tickets against the GIN index on
+// to_tsvector('english', description), the expression has to stay identical
+// to the indexed one or Postgres falls back to a sequential scan.
+func (t *TicketRepository) SeedSearch(ctx context.Context, query string, page int64) error {
+	searchQuery := `
+		SELECT * FROM ticket
+		WHERE to_tsvector('english', description) @@ plainto_tsquery('english', $1)
+		ORDER BY ts_rank(to_tsvector('english', description), plainto_tsquery('english', $1)) DESC, created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	stmt, err := t.db.PrepareContext(ctx, searchQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, errQuery := stmt.QueryContext(ctx, query, definition.ItemPerPage, (page-1)*definition.ItemPerPage)
+	if errQuery != nil {
+		return errQuery
+	}
+	defer rows.Close()
+
+	var tickets []*model.Ticket
+	for rows.Next() {
+		ticket, errScan := rowsScanner(rows)
+		if errScan != nil {
+			return errScan
+		}
+		tickets = append(tickets, ticket)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return errRows
+	}
+
+	return t.search.Set(ctx, query, page, tickets)
+}
+
+// purgeMatchingSearches lets Postgres decide which cached queries either
+// description matches, so invalidation follows the same parser as the search.
+func (t *TicketRepository) purgeMatchingSearches(ctx context.Context, descriptions ...string) error {
+	queries, errQueries := t.search.Queries(ctx)
+	if errQueries != nil {
+		return errQueries
+	}
+	if len(queries) == 0 {
+		return nil
+	}
+
+	matchQuery := `
+		SELECT q FROM unnest($1::text[]) AS q
+		WHERE EXISTS (
+		    SELECT 1 FROM unnest($2::text[]) AS d
+		    WHERE to_tsvector('english', d) @@ plainto_tsquery('english', q)
+		)
+	`
+	rows, errMatch := t.db.QueryContext(ctx, matchQuery, pq.Array(queries), pq.Array(descriptions))
+	if errMatch != nil {
+		return errMatch
+	}
+	defer rows.Close()
+
+	var matched []string
+	for rows.Next() {
+		var query string
+		if errScan := rows.Scan(&query); errScan != nil {
+			return errScan
+		}
+		matched = append(matched, query)
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return errRows
+	}
+
+	return t.search.Purge(ctx, matched...)
+}
+
 func NewTicketRepository(db *sql.DB, fetcherPool *pools.FetcherPool, seederPool *pools.SeederPool) *TicketRepository {
 	ticketRepository := &TicketRepository{}
 	ticketRepository.Init(
@@ -389,12 +876,21 @@ func NewTicketRepository(db *sql.DB, fetcherPool *pools.FetcherPool, seederPool
 		seederPool.TimelineByCategorySeeder,
 		fetcherPool.TimelineSortBySecurityRisk,
 		seederPool.TimelineSortBySecurityRiskSeeder,
+		fetcherPool.TimelineByStatus,
+		seederPool.TimelineByStatusSeeder,
 		fetcherPool.SortedByAccount,
 		seederPool.SortedByAccountSeeder,
+		fetcherPool.SortedByAssignee,
+		seederPool.SortedByAssigneeSeeder,
 		fetcherPool.Page,
 		seederPool.PageSeeder,
 		fetcherPool.TimeSeries,
 		seederPool.TimeSeriesSeeder,
+		fetcherPool.TicketSearch,
+		fetcherPool.TimelineByFilter,
+		seederPool.TimelineByFilterSeeder,
+		fetcherPool.FilterIndex,
+		fetcherPool.TicketSortedSets,
 	)
 
 	return ticketRepository
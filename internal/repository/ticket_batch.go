@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+)
+
+// TicketBatch writes several tickets in one transaction. Each write enqueues a
+// deferred outbox row, Batch adds a single listing purge row behind them.
+type TicketBatch struct {
+	repository *TicketRepository
+	tx         *sql.Tx
+	// lastOutboxId is the newest outbox row an item that was kept wrote
+	lastOutboxId int64
+}
+
+// Item runs apply under a savepoint, a failing apply is rolled back alone and
+// the batch goes on with the next item.
+func (b *TicketBatch) Item(ctx context.Context, apply func() error) error {
+	_, errSavepoint := b.tx.ExecContext(ctx, "SAVEPOINT batch_item")
+	if errSavepoint != nil {
+		return errSavepoint
+	}
+
+	lastOutboxId := b.lastOutboxId
+	if errApply := apply(); errApply != nil {
+		// the rollback takes the outbox rows of the item with it
+		b.lastOutboxId = lastOutboxId
+		_, errRollback := b.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_item")
+		return errors.Join(errApply, errRollback)
+	}
+
+	_, errRelease := b.tx.ExecContext(ctx, "RELEASE SAVEPOINT batch_item")
+	return errRelease
+}
+
+// Find locks the ticket for the rest of the batch, a later item on the same
+// ticket sees what the earlier ones wrote.
+func (b *TicketBatch) Find(ctx context.Context, ticketUUID string) (*model.Ticket, error) {
+	row := b.tx.QueryRowContext(ctx, "SELECT * FROM ticket WHERE uuid = $1 FOR UPDATE", ticketUUID)
+	ticket, errScan := rowScanner(row)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, errScan
+	}
+
+	return ticket, nil
+}
+
+func (b *TicketBatch) Update(ctx context.Context, ticket *model.Ticket) error {
+	previous, errUpdate := updateRow(ctx, b.tx, ticket)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	return b.enqueue(ctx, &TicketWrite{Previous: previous, Current: ticket})
+}
+
+func (b *TicketBatch) Transition(ctx context.Context, ticket *model.Ticket, transition *model.TicketTransition) error {
+	previous, errTransition := transitionRow(ctx, b.tx, ticket, transition)
+	if errTransition != nil {
+		return errTransition
+	}
+
+	return b.enqueue(ctx, &TicketWrite{Previous: previous, Current: ticket, Transition: transition})
+}
+
+func (b *TicketBatch) Delete(ctx context.Context, ticket *model.Ticket) error {
+	previous, errDelete := deleteRow(ctx, b.tx, ticket)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return b.enqueue(ctx, &TicketWrite{Previous: previous})
+}
+
+// enqueue writes the outbox row of write and runs the write hook, both go
+// under the savepoint of the item.
+func (b *TicketBatch) enqueue(ctx context.Context, write *TicketWrite) error {
+	id, errEnqueue := enqueue(ctx, b.tx, write.Previous, write.Current, true)
+	if errEnqueue != nil {
+		return errEnqueue
+	}
+
+	errHook := b.repository.runWriteHook(ctx, b.tx, write)
+	if errHook != nil {
+		return errHook
+	}
+
+	b.lastOutboxId = max(b.lastOutboxId, id)
+	return nil
+}
+
+// Batch runs apply in one transaction and commits what its items wrote, only
+// an error returned by apply itself rolls the whole batch back. When an item
+// was kept a listing purge row is written last, after the commit the outbox is
+// drained through it, the timeline changes of every committed item and the
+// purge go out together.
+func (t *TicketRepository) Batch(ctx context.Context, apply func(batch *TicketBatch) error) error {
+	tx, errBegin := t.db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
+	}
+	defer tx.Rollback()
+
+	batch := &TicketBatch{repository: t, tx: tx}
+	errApply := apply(batch)
+	if errApply != nil {
+		return errApply
+	}
+
+	if batch.lastOutboxId > 0 {
+		purgeId, errPurge := enqueueListingPurge(ctx, tx)
+		if errPurge != nil {
+			return errPurge
+		}
+		batch.lastOutboxId = purgeId
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	if batch.lastOutboxId > 0 {
+		t.relayOutboxThrough(ctx, batch.lastOutboxId)
+	}
+	return nil
+}
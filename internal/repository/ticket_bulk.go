@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"redifu-example/internal/model"
+	"strings"
+)
+
+// Import inserts tickets in one transaction. Their outbox rows defer the page
+// and search purge to a single listing purge row written with them, a purge
+// that fails is retried by the relay instead of being lost.
+func (t *TicketRepository) Import(ctx context.Context, tickets []*model.Ticket) error {
+	tx, errBegin := t.db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
+	}
+	defer tx.Rollback()
+
+	stmt, errPrepare := tx.PrepareContext(ctx, "INSERT INTO ticket (uuid, randid, created_at, updated_at, account_uuid, description, security_risk, category_uuid, status, assignee_uuid) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)")
+	if errPrepare != nil {
+		return errPrepare
+	}
+	defer stmt.Close()
+
+	for _, ticket := range tickets {
+		_, errCreate := stmt.ExecContext(ctx, ticket.GetUUID(), ticket.GetRandId(), ticket.GetCreatedAt(), ticket.GetUpdatedAt(), ticket.AccountUUID, ticket.Description, ticket.SecurityRisk, nullableUUID(ticket.CategoryUUID), ticket.Status, nullableUUID(ticket.AssigneeUUID))
+		if errCreate != nil {
+			return errCreate
+		}
+
+		errEnqueue := enqueueDeferredCacheChange(ctx, tx, nil, ticket)
+		if errEnqueue != nil {
+			return errEnqueue
+		}
+	}
+
+	_, errPurge := enqueueListingPurge(ctx, tx)
+	if errPurge != nil {
+		return errPurge
+	}
+
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	t.relayOutbox(ctx)
+	return nil
+}
+
+// purgeListings drops every cached page and search, what a listing purge row
+// applies in place of the purge planCache adds per row.
+func (t *TicketRepository) purgeListings(ctx context.Context) error {
+	errPage := t.page.Purge(ctx)
+
+	queries, errQueries := t.search.Queries(ctx)
+	if errQueries != nil {
+		return errors.Join(errPage, errQueries)
+	}
+	if len(queries) == 0 {
+		return errPage
+	}
+
+	return errors.Join(errPage, t.search.Purge(ctx, queries...))
+}
+
+// Export streams the tickets matching conditions, newest first, straight from
+// Postgres. Conditions are ANDed and reference the ticket as t, each is handed
+// its category randid so callers never need the category table.
+func (t *TicketRepository) Export(ctx context.Context, conditions []string, args []interface{}, each func(ticket *model.Ticket) error) error {
+	query := `
+		SELECT t.*, c.randid
+		FROM ticket t
+		LEFT JOIN category c ON t.category_uuid = c.uuid
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY t.created_at DESC"
+
+	rows, errQuery := t.db.QueryContext(ctx, query, args...)
+	if errQuery != nil {
+		return errQuery
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		ticket := model.NewTicket()
+		// category_uuid, assignee_uuid and the joined randid are nullable
+		var categoryUUID, assigneeUUID, categoryRandId sql.NullString
+		errScan := rows.Scan(&ticket.UUID, &ticket.RandId, &ticket.CreatedAt, &ticket.UpdatedAt, &ticket.AccountUUID, &ticket.Description, &ticket.SecurityRisk, &categoryUUID, &ticket.Status, &assigneeUUID, &categoryRandId)
+		if errScan != nil {
+			return errScan
+		}
+		ticket.CategoryUUID = categoryUUID.String
+		ticket.AssigneeUUID = assigneeUUID.String
+		ticket.CategoryRandId = categoryRandId.String
+
+		if errEach := each(ticket); errEach != nil {
+			return errEach
+		}
+	}
+
+	return rows.Err()
+}
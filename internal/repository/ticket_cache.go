@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
+)
+
+// cacheUpdate collects what one or more ticket writes change in Redis. It is
+// planned without writing anything, so the sorted set changes of every write
+// applied together go out in a single pipeline.
+type cacheUpdate struct {
+	set           []*model.Ticket
+	missing       []string
+	changes       []pools.SortedSetChange
+	staleFilters  []string
+	touchFilters  bool
+	purgePage     bool
+	purgeSearches bool
+	descriptions  []string
+	// purgeListings drops every page and search, it covers the two above
+	purgeListings bool
+}
+
+func (u *cacheUpdate) add(key string, ticket *model.Ticket, score float64, window int64) {
+	u.changes = append(u.changes, pools.SortedSetChange{Key: key, Member: ticket.GetRandId(), Score: score, Window: window})
+}
+
+func (u *cacheUpdate) remove(key string, ticket *model.Ticket, window int64) {
+	u.changes = append(u.changes, pools.SortedSetChange{Key: key, Member: ticket.GetRandId(), Remove: true, Window: window})
+}
+
+func (u *cacheUpdate) purge(key string) {
+	u.changes = append(u.changes, pools.SortedSetChange{Key: key, Purge: true})
+}
+
+// createdScore and riskScore are the scores redifu gives a ticket, by
+// created_at in milliseconds or by the SecurityRisk sorting reference.
+func createdScore(ticket *model.Ticket) float64 {
+	return float64(ticket.GetCreatedAt().UnixMilli())
+}
+
+func riskScore(ticket *model.Ticket) float64 {
+	return float64(ticket.SecurityRisk)
+}
+
+// planCache adds the changes of one write to update. previous is the row
+// before the write (nil on create) and current is the row after it (nil on
+// delete). purge is false for bulk writes, which purge pages and searches once
+// themselves. A lookup that fails only leaves out the structures depending on it.
+func (t *TicketRepository) planCache(ctx context.Context, update *cacheUpdate, previous *model.Ticket, current *model.Ticket, purge bool) error {
+	var errs []error
+
+	if current == nil {
+		update.missing = append(update.missing, previous.GetRandId())
+	} else {
+		update.set = append(update.set, current)
+	}
+
+	// timeline, sortedByReporter and timeSeries are keyed by created_at and reporter,
+	// neither of which can change, so only membership has to be maintained
+	switch {
+	case previous == nil:
+		update.add(pools.TicketTimelineKey, current, createdScore(current), definition.ItemPerPage)
+		update.add(fmt.Sprintf(pools.TicketSortedByAccountKey, current.AccountUUID), current, createdScore(current), 0)
+		update.add(pools.TicketTimelineBySecurityKey, current, riskScore(current), definition.ItemPerPage)
+		update.add(pools.TicketTimeSeriesKey, current, createdScore(current), 0)
+	case current == nil:
+		update.remove(pools.TicketTimelineKey, previous, definition.ItemPerPage)
+		update.remove(fmt.Sprintf(pools.TicketSortedByAccountKey, previous.AccountUUID), previous, 0)
+		update.remove(pools.TicketTimelineBySecurityKey, previous, definition.ItemPerPage)
+		update.remove(pools.TicketTimeSeriesKey, previous, 0)
+		// the comments went with the row through ON DELETE CASCADE
+		update.purge(fmt.Sprintf(pools.TicketCommentsKey, previous.GetRandId()))
+	case previous.SecurityRisk != current.SecurityRisk:
+		// rescore by removing the stale member before adding it back with the new risk
+		update.remove(pools.TicketTimelineBySecurityKey, previous, definition.ItemPerPage)
+		update.add(pools.TicketTimelineBySecurityKey, current, riskScore(current), definition.ItemPerPage)
+	}
+
+	previousCategoryRandId, errResolve := t.resolveCategoryRandId(ctx, previous)
+	errs = append(errs, errResolve)
+	currentCategoryRandId, errResolve := t.resolveCategoryRandId(ctx, current)
+	errs = append(errs, errResolve)
+	if previousCategoryRandId != currentCategoryRandId {
+		if previousCategoryRandId != "" {
+			update.remove(fmt.Sprintf(pools.TicketTimelineByCategoryKey, previousCategoryRandId), previous, definition.ItemPerPage)
+		}
+		if currentCategoryRandId != "" {
+			update.add(fmt.Sprintf(pools.TicketTimelineByCategoryKey, currentCategoryRandId), current, createdScore(current), definition.ItemPerPage)
+		}
+	}
+
+	var previousStatus, currentStatus string
+	if previous != nil {
+		previousStatus = previous.Status
+	}
+	if current != nil {
+		currentStatus = current.Status
+	}
+	if previousStatus != currentStatus {
+		if previousStatus != "" {
+			update.remove(fmt.Sprintf(pools.TicketTimelineByStatusKey, previousStatus), previous, definition.ItemPerPage)
+		}
+		if currentStatus != "" {
+			update.add(fmt.Sprintf(pools.TicketTimelineByStatusKey, currentStatus), current, createdScore(current), definition.ItemPerPage)
+		}
+	}
+
+	var previousAssignee, currentAssignee string
+	if previous != nil {
+		previousAssignee = previous.AssigneeUUID
+	}
+	if current != nil {
+		currentAssignee = current.AssigneeUUID
+	}
+	if previousAssignee != currentAssignee {
+		if previousAssignee != "" {
+			update.remove(fmt.Sprintf(pools.TicketSortedByAssigneeKey, previousAssignee), previous, 0)
+		}
+		if currentAssignee != "" {
+			update.add(fmt.Sprintf(pools.TicketSortedByAssigneeKey, currentAssignee), current, createdScore(current), 0)
+		}
+	}
+
+	errs = append(errs, t.planFilterTimelines(ctx, update, previous, previousCategoryRandId, current, currentCategoryRandId))
+
+	if !purge {
+		return errors.Join(errs...)
+	}
+
+	// page seeds snapshot joined rows, any mutation invalidates them
+	update.purgePage = true
+
+	var previousDescription, currentDescription string
+	if previous != nil {
+		previousDescription = previous.Description
+	}
+	if current != nil {
+		currentDescription = current.Description
+	}
+	if previous == nil || current == nil || previousDescription != currentDescription {
+		update.purgeSearches = true
+		update.descriptions = append(update.descriptions, previousDescription, currentDescription)
+	}
+
+	return errors.Join(errs...)
+}
+
+// planFilterTimelines walks every cached filter combination and moves the
+// ticket in or out of the ones whose match result changed with this write.
+func (t *TicketRepository) planFilterTimelines(ctx context.Context, update *cacheUpdate, previous *model.Ticket, previousCategoryRandId string, current *model.Ticket, currentCategoryRandId string) error {
+	if t.decodeFilter == nil {
+		return nil
+	}
+
+	keys, errKeys := t.filterIndex.Members(ctx)
+	if errKeys != nil {
+		return errKeys
+	}
+
+	for _, key := range keys {
+		filter, errDecode := t.decodeFilter(key)
+		if errDecode != nil {
+			update.staleFilters = append(update.staleFilters, key)
+			continue
+		}
+
+		wasMatching := previous != nil && filter.Matches(previous, previousCategoryRandId)
+		isMatching := current != nil && filter.Matches(current, currentCategoryRandId)
+		if wasMatching && !isMatching {
+			update.remove(fmt.Sprintf(pools.TicketTimelineByFilterKey, key), previous, definition.ItemPerPage)
+		}
+		if isMatching && !wasMatching {
+			// the add extends the timeline TTL, the index has to follow
+			update.add(fmt.Sprintf(pools.TicketTimelineByFilterKey, key), current, createdScore(current), definition.ItemPerPage)
+			update.touchFilters = true
+		}
+	}
+
+	return nil
+}
+
+// applyCache writes update out. Items go first so no sorted set points at an
+// item that is not there yet, every sorted set change then goes out in one
+// pipeline and pages and searches are purged last. Every step is attempted
+// even if one fails.
+func (t *TicketRepository) applyCache(ctx context.Context, update *cacheUpdate) error {
+	var errs []error
+
+	for _, ticket := range update.set {
+		errs = append(errs, t.base.Set(ctx, ticket))
+	}
+	for _, randId := range update.missing {
+		errs = append(errs, t.base.MarkAsMissing(ctx, randId))
+	}
+
+	errs = append(errs,
+		t.sortedSets.Apply(ctx, update.changes),
+		t.filterIndex.Remove(ctx, update.staleFilters...),
+	)
+	if update.touchFilters {
+		errs = append(errs, t.filterIndex.Touch(ctx))
+	}
+
+	if update.purgeListings {
+		errs = append(errs, t.purgeListings(ctx))
+		return errors.Join(errs...)
+	}
+	if update.purgePage {
+		errs = append(errs, t.page.Purge(ctx))
+	}
+	if update.purgeSearches {
+		errs = append(errs, t.purgeMatchingSearches(ctx, update.descriptions...))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/21strive/redifu"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
+	"testing"
+	"time"
+)
+
+// countingPage stands in for redifu.Page, the listing is only ever purged.
+type countingPage struct {
+	purges int
+}
+
+func (p *countingPage) Purge(ctx context.Context, param ...string) error {
+	p.purges++
+	return nil
+}
+
+func newCacheTestRepository(t *testing.T) (*TicketRepository, *miniredis.Miniredis, *countingPage) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	fetcherPool := pools.NewFetcherPool(client)
+	page := &countingPage{}
+	ticketRepository := &TicketRepository{
+		base:        fetcherPool.BaseTicket,
+		page:        page,
+		search:      fetcherPool.TicketSearch,
+		filterIndex: fetcherPool.FilterIndex,
+		sortedSets:  fetcherPool.TicketSortedSets,
+	}
+	return ticketRepository, server, page
+}
+
+var cacheTestCreatedAt = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func cacheTestTicket() *model.Ticket {
+	return &model.Ticket{
+		Record: &redifu.Record{
+			UUID:      "7f0c1d5e-0000-4000-8000-000000000001",
+			RandId:    "tkt0001",
+			CreatedAt: cacheTestCreatedAt,
+			UpdatedAt: cacheTestCreatedAt,
+		},
+		Description:    "login page returns 500",
+		Status:         model.StatusOpen,
+		SecurityRisk:   3,
+		AccountUUID:    "reporter",
+		CategoryUUID:   "category-a-uuid",
+		CategoryRandId: "category-a",
+	}
+}
+
+// cacheTestKeys are every sorted set the tests look at, seeded up front as if
+// a read had cached them already.
+var cacheTestKeys = []string{
+	pools.TicketTimelineKey,
+	pools.TicketTimelineBySecurityKey,
+	fmt.Sprintf(pools.TicketTimelineByCategoryKey, "category-a"),
+	fmt.Sprintf(pools.TicketTimelineByCategoryKey, "category-b"),
+	fmt.Sprintf(pools.TicketTimelineByStatusKey, model.StatusOpen),
+	fmt.Sprintf(pools.TicketTimelineByStatusKey, model.StatusResolved),
+	fmt.Sprintf(pools.TicketSortedByAccountKey, "reporter"),
+	pools.TicketTimeSeriesKey,
+}
+
+// seedCache caches every set with an older neighbour, so the ticket always
+// sorts inside the cached window, and puts ticket where it belongs.
+func seedCache(t *testing.T, server *miniredis.Miniredis, ticket *model.Ticket) {
+	t.Helper()
+
+	for _, key := range cacheTestKeys {
+		if _, errAdd := server.ZAdd(key, 0, "neighbour"); errAdd != nil {
+			t.Fatal(errAdd)
+		}
+	}
+
+	created := float64(ticket.GetCreatedAt().UnixMilli())
+	memberships := map[string]float64{
+		pools.TicketTimelineKey:                                               created,
+		pools.TicketTimelineBySecurityKey:                                     float64(ticket.SecurityRisk),
+		fmt.Sprintf(pools.TicketTimelineByCategoryKey, ticket.CategoryRandId): created,
+		fmt.Sprintf(pools.TicketTimelineByStatusKey, ticket.Status):           created,
+		fmt.Sprintf(pools.TicketSortedByAccountKey, ticket.AccountUUID):       created,
+		pools.TicketTimeSeriesKey:                                             created,
+	}
+	for key, score := range memberships {
+		if _, errAdd := server.ZAdd(key, score, ticket.GetRandId()); errAdd != nil {
+			t.Fatal(errAdd)
+		}
+	}
+
+	if _, errAdd := server.ZAdd(fmt.Sprintf(pools.TicketCommentsKey, ticket.GetRandId()), created, "cmt0001"); errAdd != nil {
+		t.Fatal(errAdd)
+	}
+}
+
+func score(server *miniredis.Miniredis, key string, member string) (float64, bool) {
+	members, errRead := server.SortedSet(key)
+	if errRead != nil {
+		return 0, false
+	}
+	value, isMember := members[member]
+	return value, isMember
+}
+
+// assertViews checks every view against current, the row after the write,
+// or that the ticket left all of them when current is nil.
+func assertViews(t *testing.T, server *miniredis.Miniredis, page *countingPage, randId string, current *model.Ticket) {
+	t.Helper()
+
+	if page.purges != 1 {
+		t.Errorf("page purged %d times, want 1", page.purges)
+	}
+
+	if current == nil {
+		for _, key := range cacheTestKeys {
+			if _, isMember := score(server, key, randId); isMember {
+				t.Errorf("%s still holds the deleted ticket", key)
+			}
+		}
+		if commentsKey := fmt.Sprintf(pools.TicketCommentsKey, randId); server.Exists(commentsKey) {
+			t.Errorf("%s outlived its ticket", commentsKey)
+		}
+		return
+	}
+
+	created := float64(current.GetCreatedAt().UnixMilli())
+	if risk, isMember := score(server, pools.TicketTimelineBySecurityKey, randId); !isMember || risk != float64(current.SecurityRisk) {
+		t.Errorf("security risk score is %v (member %t), want %d", risk, isMember, current.SecurityRisk)
+	}
+	if entry, isMember := score(server, pools.TicketTimeSeriesKey, randId); !isMember || entry != created {
+		t.Errorf("time series entry is %v (member %t), want %v", entry, isMember, created)
+	}
+
+	for _, categoryRandId := range []string{"category-a", "category-b"} {
+		key := fmt.Sprintf(pools.TicketTimelineByCategoryKey, categoryRandId)
+		_, isMember := score(server, key, randId)
+		if want := categoryRandId == current.CategoryRandId; isMember != want {
+			t.Errorf("%s membership is %t, want %t", key, isMember, want)
+		}
+	}
+	for _, status := range []string{model.StatusOpen, model.StatusResolved} {
+		key := fmt.Sprintf(pools.TicketTimelineByStatusKey, status)
+		_, isMember := score(server, key, randId)
+		if want := status == current.Status; isMember != want {
+			t.Errorf("%s membership is %t, want %t", key, isMember, want)
+		}
+	}
+}
+
+// outboxTestRow is the row a write enqueues, as drainOutbox loads it.
+func outboxTestRow(t *testing.T, id int64, previous *model.Ticket, current *model.Ticket) outboxRow {
+	t.Helper()
+
+	previousSnapshot, errPrevious := encodeSnapshot(previous)
+	currentSnapshot, errCurrent := encodeSnapshot(current)
+	if errPrevious != nil || errCurrent != nil {
+		t.Fatal(errPrevious, errCurrent)
+	}
+	return outboxRow{id: id, previous: previousSnapshot, current: currentSnapshot, isDue: true}
+}
+
+func TestReplayOutbox(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(ticket *model.Ticket) *model.Ticket
+	}{
+		{
+			name: "update",
+			write: func(ticket *model.Ticket) *model.Ticket {
+				ticket.SetDescription("login page returns 500 for admins")
+				ticket.SetSecurityRisk(8)
+				return ticket
+			},
+		},
+		{
+			name: "resolve",
+			write: func(ticket *model.Ticket) *model.Ticket {
+				ticket.SetStatus(model.StatusResolved)
+				return ticket
+			},
+		},
+		{
+			name: "recategorize",
+			write: func(ticket *model.Ticket) *model.Ticket {
+				ticket.SetCategory(&model.Category{Record: &redifu.Record{UUID: "category-b-uuid", RandId: "category-b"}})
+				return ticket
+			},
+		},
+		{
+			name: "delete",
+			write: func(ticket *model.Ticket) *model.Ticket {
+				return nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ticketRepository, server, page := newCacheTestRepository(t)
+			previous := cacheTestTicket()
+			seedCache(t, server, previous)
+
+			current := test.write(cacheTestTicket())
+			replay := ticketRepository.replayOutbox(context.Background(), []outboxRow{outboxTestRow(t, 1, previous, current)})
+			if replay.err != nil {
+				t.Fatal(replay.err)
+			}
+			if len(replay.applied) != 1 || len(replay.retired) != 0 {
+				t.Fatalf("applied %d rows and retired %d, want the one row applied", len(replay.applied), len(replay.retired))
+			}
+
+			assertViews(t, server, page, previous.GetRandId(), current)
+		})
+	}
+}
+
+func TestReplayOutboxInWriteOrder(t *testing.T) {
+	ticketRepository, server, page := newCacheTestRepository(t)
+	original := cacheTestTicket()
+	seedCache(t, server, original)
+
+	resolved := cacheTestTicket()
+	resolved.SetStatus(model.StatusResolved)
+	moved := cacheTestTicket()
+	moved.SetStatus(model.StatusResolved)
+	moved.SetCategory(&model.Category{Record: &redifu.Record{UUID: "category-b-uuid", RandId: "category-b"}})
+	undecodable := outboxRow{id: 3, current: []byte("{"), isDue: true}
+	later := outboxTestRow(t, 4, moved, nil)
+	later.isDue = false
+
+	replay := ticketRepository.replayOutbox(context.Background(), []outboxRow{
+		outboxTestRow(t, 1, original, resolved),
+		outboxTestRow(t, 2, resolved, moved),
+		undecodable,
+		later,
+	})
+	if replay.err != nil {
+		t.Fatal(replay.err)
+	}
+	if len(replay.applied) != 2 || replay.applied[0].id != 1 || replay.applied[1].id != 2 {
+		t.Fatalf("applied %d rows, want rows 1 and 2", len(replay.applied))
+	}
+	if len(replay.retired) != 1 || replay.retired[0].id != 3 {
+		t.Errorf("retired %v, want row 3", replay.retired)
+	}
+
+	// both rows went out in one update, the page is purged once and the
+	// delete still waiting on its backoff is left alone
+	assertViews(t, server, page, original.GetRandId(), moved)
+}
+
+// statusFilter matches one status, it stands in for ticket.Filter.
+type statusFilter string
+
+func (f statusFilter) Matches(ticket *model.Ticket, categoryRandId string) bool {
+	return ticket.Status == string(f)
+}
+
+func TestReplayOutboxKeepsFilterIndexAlive(t *testing.T) {
+	ticketRepository, server, _ := newCacheTestRepository(t)
+	ticketRepository.InitFilterDecoder(func(key string) (TicketFilter, error) {
+		return statusFilter(key), nil
+	})
+
+	const indexKey = "ticket-timeline:filter-index"
+	timelineKey := fmt.Sprintf(pools.TicketTimelineByFilterKey, model.StatusResolved)
+	server.SAdd(indexKey, model.StatusResolved)
+	server.SetTTL(indexKey, time.Minute)
+	server.ZAdd(timelineKey, 0, "neighbour")
+
+	previous := cacheTestTicket()
+	current := cacheTestTicket()
+	current.SetStatus(model.StatusResolved)
+	replay := ticketRepository.replayOutbox(context.Background(), []outboxRow{outboxTestRow(t, 1, previous, current)})
+	if replay.err != nil {
+		t.Fatal(replay.err)
+	}
+
+	if _, isMember := score(server, timelineKey, current.GetRandId()); !isMember {
+		t.Fatalf("%s is missing the resolved ticket", timelineKey)
+	}
+	if indexTTL, timelineTTL := server.TTL(indexKey), server.TTL(timelineKey); indexTTL < timelineTTL {
+		t.Errorf("index expires in %s, before the timeline it lists in %s", indexTTL, timelineTTL)
+	}
+}
+
+func TestReplayOutboxPurgesListingsOnce(t *testing.T) {
+	ticketRepository, server, page := newCacheTestRepository(t)
+	ctx := context.Background()
+	server.ZAdd(pools.TicketTimelineKey, 0, "neighbour")
+	if errSet := ticketRepository.search.Set(ctx, "login", 1, nil); errSet != nil {
+		t.Fatal(errSet)
+	}
+
+	imported := cacheTestTicket()
+	deferred := outboxTestRow(t, 1, nil, imported)
+	deferred.deferredPurge = true
+	listingPurge := outboxRow{id: 2, isDue: true}
+
+	replay := ticketRepository.replayOutbox(ctx, []outboxRow{deferred, listingPurge})
+	if replay.err != nil {
+		t.Fatal(replay.err)
+	}
+	if len(replay.applied) != 2 || len(replay.retired) != 0 {
+		t.Fatalf("applied %d rows and retired %d, want both rows applied", len(replay.applied), len(replay.retired))
+	}
+
+	if _, isMember := score(server, pools.TicketTimelineKey, imported.GetRandId()); !isMember {
+		t.Error("the imported ticket is missing from the timeline")
+	}
+	if page.purges != 1 {
+		t.Errorf("page purged %d times, want 1", page.purges)
+	}
+	if queries, _ := ticketRepository.search.Queries(ctx); len(queries) != 0 {
+		t.Errorf("searches %v outlived the purge", queries)
+	}
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// SeedPlan is one query a TicketRepository seeder sent, recorded as redifu
+// built it. cmd/migrate explain runs EXPLAIN on each of them.
+type SeedPlan struct {
+	Name  string
+	Query string
+	Args  []interface{}
+}
+
+// QueryRecorder keeps every query sent through the *sql.DB NewRecordingDB
+// returns. Reads are answered by the wrapped database, so a seeder looking up
+// the row a next page starts after finds it, writes are recorded and dropped.
+type QueryRecorder struct {
+	db      *sql.DB
+	mu      sync.Mutex
+	queries []SeedPlan
+}
+
+// Take returns the queries recorded since the last Take, named after name.
+func (r *QueryRecorder) Take(name string) []SeedPlan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	plans := r.queries
+	r.queries = nil
+	for i := range plans {
+		plans[i].Name = name
+	}
+	return plans
+}
+
+func (r *QueryRecorder) record(query string, args []driver.NamedValue) []interface{} {
+	values := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queries = append(r.queries, SeedPlan{Query: query, Args: values})
+	return values
+}
+
+// NewRecordingDB wraps db for seeders whose SQL is built at runtime, see
+// QueryRecorder.
+func NewRecordingDB(db *sql.DB) (*sql.DB, *QueryRecorder) {
+	recorder := &QueryRecorder{db: db}
+	return sql.OpenDB(recordingConnector{recorder: recorder}), recorder
+}
+
+type recordingConnector struct {
+	recorder *QueryRecorder
+}
+
+func (c recordingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &recordingConn{recorder: c.recorder}, nil
+}
+
+func (c recordingConnector) Driver() driver.Driver {
+	return recordingDriver{}
+}
+
+type recordingDriver struct{}
+
+func (d recordingDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("recording driver is only opened through NewRecordingDB")
+}
+
+type recordingConn struct {
+	recorder *QueryRecorder
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{conn: c, query: query}, nil
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("recording driver does not run transactions")
+}
+
+func (c *recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := c.recorder.record(query, args)
+
+	rows, errQuery := c.recorder.db.QueryContext(ctx, query, values...)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	return bufferRows(rows)
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.recorder.record(query, args)
+	return driver.RowsAffected(0), nil
+}
+
+type recordingStmt struct {
+	conn  *recordingConn
+	query string
+}
+
+func (s *recordingStmt) Close() error {
+	return nil
+}
+
+func (s *recordingStmt) NumInput() int {
+	return -1
+}
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, namedValues(args))
+}
+
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, namedValues(args))
+}
+
+func (s *recordingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *recordingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, 0, len(args))
+	for i, arg := range args {
+		named = append(named, driver.NamedValue{Ordinal: i + 1, Value: arg})
+	}
+	return named
+}
+
+// bufferedRows hands back rows read from the wrapped database, scanning into
+// *interface{} keeps the values the underlying driver produced.
+type bufferedRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func bufferRows(rows *sql.Rows) (*bufferedRows, error) {
+	columns, errColumns := rows.Columns()
+	if errColumns != nil {
+		return nil, errColumns
+	}
+
+	buffered := &bufferedRows{columns: columns}
+	for rows.Next() {
+		raw := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range raw {
+			dest[i] = &raw[i]
+		}
+		if errScan := rows.Scan(dest...); errScan != nil {
+			return nil, errScan
+		}
+
+		values := make([]driver.Value, len(columns))
+		for i, value := range raw {
+			values[i] = value
+		}
+		buffered.values = append(buffered.values, values)
+	}
+
+	return buffered, rows.Err()
+}
+
+func (r *bufferedRows) Columns() []string {
+	return r.columns
+}
+
+func (r *bufferedRows) Close() error {
+	return nil
+}
+
+func (r *bufferedRows) Next(dest []driver.Value) error {
+	if r.next == len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/lib/pq"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"time"
+)
+
+// WebhookRepository keeps subscriptions and their delivery log in Postgres
+// only, both are read by integrators and admins, never on a hot path.
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func (w *WebhookRepository) Init(db *sql.DB) {
+	w.db = db
+}
+
+func (w *WebhookRepository) CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	query := "INSERT INTO webhook_subscription (uuid, randid, created_at, updated_at, url, secret, event_types) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	_, errCreate := w.db.ExecContext(ctx, query, subscription.GetUUID(), subscription.GetRandId(), subscription.GetCreatedAt(), subscription.GetUpdatedAt(),
+		subscription.URL, subscription.Secret, pq.Array(subscription.EventTypes))
+	return errCreate
+}
+
+func (w *WebhookRepository) DeleteSubscription(ctx context.Context, subscriptionUUID string) error {
+	result, errDelete := w.db.ExecContext(ctx, "DELETE FROM webhook_subscription WHERE uuid = $1", subscriptionUUID)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	affected, errAffected := result.RowsAffected()
+	if errAffected != nil {
+		return errAffected
+	}
+	if affected == 0 {
+		return definition.NotFound
+	}
+
+	return nil
+}
+
+func (w *WebhookRepository) FindSubscription(ctx context.Context, subscriptionUUID string) (*model.WebhookSubscription, error) {
+	row := w.db.QueryRowContext(ctx, "SELECT uuid, randid, created_at, updated_at, url, secret, event_types FROM webhook_subscription WHERE uuid = $1", subscriptionUUID)
+	subscription, errScan := subscriptionScanner(row)
+	if errScan != nil {
+		if errScan == sql.ErrNoRows {
+			return nil, definition.NotFound
+		}
+		return nil, errScan
+	}
+
+	return subscription, nil
+}
+
+func (w *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	return w.querySubscriptions(ctx, "SELECT uuid, randid, created_at, updated_at, url, secret, event_types FROM webhook_subscription ORDER BY created_at DESC")
+}
+
+// ListSubscriptionsByEventType reads inside the transaction of the ticket write
+// announcing eventType.
+func (w *WebhookRepository) ListSubscriptionsByEventType(ctx context.Context, tx *sql.Tx, eventType string) ([]*model.WebhookSubscription, error) {
+	query := "SELECT uuid, randid, created_at, updated_at, url, secret, event_types FROM webhook_subscription WHERE $1 = ANY(event_types)"
+	rows, errQuery := tx.QueryContext(ctx, query, eventType)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return scanSubscriptions(rows)
+}
+
+func (w *WebhookRepository) querySubscriptions(ctx context.Context, query string, args ...interface{}) ([]*model.WebhookSubscription, error) {
+	rows, errQuery := w.db.QueryContext(ctx, query, args...)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return scanSubscriptions(rows)
+}
+
+func scanSubscriptions(rows *sql.Rows) ([]*model.WebhookSubscription, error) {
+	defer rows.Close()
+
+	var subscriptions []*model.WebhookSubscription
+	for rows.Next() {
+		subscription, errScan := subscriptionScanner(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// EnqueueDeliveries writes one pending delivery per subscription inside the
+// transaction of the ticket write they announce, they commit or roll back with it.
+func (w *WebhookRepository) EnqueueDeliveries(ctx context.Context, tx *sql.Tx, deliveries []*model.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_delivery (uuid, randid, created_at, updated_at, subscription_uuid, event_id, event_type, payload, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	for _, delivery := range deliveries {
+		_, errInsert := tx.ExecContext(ctx, query, delivery.GetUUID(), delivery.GetRandId(), delivery.GetCreatedAt(), delivery.GetUpdatedAt(),
+			delivery.SubscriptionUUID, delivery.EventID, delivery.EventType, string(delivery.Payload), delivery.Status)
+		if errInsert != nil {
+			return errInsert
+		}
+	}
+
+	return nil
+}
+
+// ClaimDue leases up to limit due deliveries by pushing their next attempt
+// past the lease, concurrent workers skip the locked rows and a worker that
+// dies mid-delivery only delays the retry by the lease.
+func (w *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	query := `
+		WITH claimed AS (
+		    UPDATE webhook_delivery
+		    SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		    WHERE uuid IN (
+		        SELECT uuid FROM webhook_delivery
+		        WHERE status = $3 AND next_attempt_at <= NOW()
+		        ORDER BY next_attempt_at
+		        LIMIT $1
+		        FOR UPDATE SKIP LOCKED
+		    )
+		    RETURNING *
+		)
+		SELECT c.uuid, c.randid, c.created_at, c.updated_at, c.subscription_uuid, c.event_id, c.event_type, c.payload,
+		       c.status, c.attempts, c.next_attempt_at, c.last_status_code, c.last_error, c.delivered_at, s.url, s.secret
+		FROM claimed c
+		JOIN webhook_subscription s ON s.uuid = c.subscription_uuid
+	`
+	rows, errQuery := w.db.QueryContext(ctx, query, limit, lease.Milliseconds(), model.DeliveryPending)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		delivery, errScan := deliveryScanner(rows, true)
+		if errScan != nil {
+			return nil, errScan
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (w *WebhookRepository) MarkDelivered(ctx context.Context, delivery *model.WebhookDelivery, statusCode int) error {
+	query := `
+		UPDATE webhook_delivery
+		SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = NULL, delivered_at = NOW(), updated_at = NOW()
+		WHERE uuid = $1
+	`
+	_, errUpdate := w.db.ExecContext(ctx, query, delivery.GetUUID(), model.DeliveryDelivered, statusCode)
+	return errUpdate
+}
+
+// MarkFailed reschedules the delivery after retryIn, or moves it to the
+// dead-letter list once it has used up its attempts.
+func (w *WebhookRepository) MarkFailed(ctx context.Context, delivery *model.WebhookDelivery, statusCode int, errDeliver error, retryIn time.Duration, isDead bool) error {
+	status := model.DeliveryPending
+	if isDead {
+		status = model.DeliveryDead
+	}
+
+	query := `
+		UPDATE webhook_delivery
+		SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = $4,
+		    next_attempt_at = NOW() + $5 * INTERVAL '1 millisecond', updated_at = NOW()
+		WHERE uuid = $1
+	`
+	_, errUpdate := w.db.ExecContext(ctx, query, delivery.GetUUID(), status, sql.NullInt64{Int64: int64(statusCode), Valid: statusCode != 0},
+		errDeliver.Error(), retryIn.Milliseconds())
+	return errUpdate
+}
+
+// Redeliver puts a delivery back in the queue with a fresh attempt budget.
+func (w *WebhookRepository) Redeliver(ctx context.Context, deliveryUUID string) error {
+	query := "UPDATE webhook_delivery SET status = $2, attempts = 0, next_attempt_at = NOW(), updated_at = NOW() WHERE uuid = $1"
+	result, errUpdate := w.db.ExecContext(ctx, query, deliveryUUID, model.DeliveryPending)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	affected, errAffected := result.RowsAffected()
+	if errAffected != nil {
+		return errAffected
+	}
+	if affected == 0 {
+		return definition.NotFound
+	}
+
+	return nil
+}
+
+func (w *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionUUID string, limit int) ([]*model.WebhookDelivery, error) {
+	query := `
+		SELECT uuid, randid, created_at, updated_at, subscription_uuid, event_id, event_type, payload,
+		       status, attempts, next_attempt_at, last_status_code, last_error, delivered_at
+		FROM webhook_delivery
+		WHERE subscription_uuid = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+	return w.queryDeliveries(ctx, query, subscriptionUUID, limit)
+}
+
+func (w *WebhookRepository) ListDead(ctx context.Context, limit int) ([]*model.WebhookDelivery, error) {
+	query := `
+		SELECT uuid, randid, created_at, updated_at, subscription_uuid, event_id, event_type, payload,
+		       status, attempts, next_attempt_at, last_status_code, last_error, delivered_at
+		FROM webhook_delivery
+		WHERE status = $1
+		ORDER BY updated_at DESC
+		LIMIT $2
+	`
+	return w.queryDeliveries(ctx, query, model.DeliveryDead, limit)
+}
+
+func (w *WebhookRepository) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]*model.WebhookDelivery, error) {
+	rows, errQuery := w.db.QueryContext(ctx, query, args...)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		delivery, errScan := deliveryScanner(rows, false)
+		if errScan != nil {
+			return nil, errScan
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// scannable is satisfied by both *sql.Row and *sql.Rows.
+type scannable interface {
+	Scan(dest ...interface{}) error
+}
+
+func subscriptionScanner(row scannable) (*model.WebhookSubscription, error) {
+	subscription := model.NewWebhookSubscription("", "", nil)
+	var eventTypes pq.StringArray
+	errScan := row.Scan(&subscription.UUID, &subscription.RandId, &subscription.CreatedAt, &subscription.UpdatedAt,
+		&subscription.URL, &subscription.Secret, &eventTypes)
+	subscription.EventTypes = eventTypes
+	return subscription, errScan
+}
+
+func deliveryScanner(row scannable, withSubscription bool) (*model.WebhookDelivery, error) {
+	delivery := model.NewWebhookDelivery("", "", "", nil)
+	var lastStatusCode sql.NullInt64
+	var lastError sql.NullString
+	var deliveredAt sql.NullTime
+
+	dest := []interface{}{&delivery.UUID, &delivery.RandId, &delivery.CreatedAt, &delivery.UpdatedAt, &delivery.SubscriptionUUID,
+		&delivery.EventID, &delivery.EventType, &delivery.Payload, &delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt,
+		&lastStatusCode, &lastError, &deliveredAt}
+	if withSubscription {
+		dest = append(dest, &delivery.URL, &delivery.Secret)
+	}
+
+	errScan := row.Scan(dest...)
+	delivery.LastStatusCode = int(lastStatusCode.Int64)
+	delivery.LastError = lastError.String
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	return delivery, errScan
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	webhookRepository := &WebhookRepository{}
+	webhookRepository.Init(db)
+	return webhookRepository
+}
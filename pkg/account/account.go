@@ -2,30 +2,123 @@ package account
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"redifu-example/definition"
 	"redifu-example/internal/fetcher"
 	"redifu-example/internal/model"
 	"redifu-example/internal/repository"
+	"redifu-example/pkg/events"
+	"time"
 )
 
 type AccountService struct {
 	accountRepository *repository.AccountRepository
+	ticketRepository  *repository.TicketRepository
 	accountFetcher    *fetcher.AccountFetcher
+	publisher         events.Publisher
 }
 
-func (s *AccountService) InitRepository(accountRepository *repository.AccountRepository) {
+func (s *AccountService) InitRepository(accountRepository *repository.AccountRepository, ticketRepository *repository.TicketRepository) {
 	s.accountRepository = accountRepository
+	s.ticketRepository = ticketRepository
 }
 
 func (s *AccountService) InitFetcher(accountFetcher *fetcher.AccountFetcher) {
 	s.accountFetcher = accountFetcher
 }
 
+func (s *AccountService) InitPublisher(publisher events.Publisher) {
+	s.publisher = publisher
+}
+
 func (s *AccountService) Create(ctx context.Context, name, email string) error {
 	account := model.NewAccount()
 	account.Name = name
 	account.Email = email
 
-	return s.accountRepository.Create(ctx, account)
+	errCreate := s.accountRepository.Create(ctx, account)
+	if errCreate != nil {
+		return errCreate
+	}
+
+	events.Emit(ctx, s.publisher, events.NewAccountCreated(account))
+	return nil
+}
+
+func (s *AccountService) Find(accountUUID string) (*model.Account, error) {
+	return s.accountRepository.FindByUUID(accountUUID)
+}
+
+// ExistingAccounts reports which of accountUUIDs belong to an account with a
+// single query, for validating many rows at once.
+func (s *AccountService) ExistingAccounts(ctx context.Context, accountUUIDs []string) (map[string]bool, error) {
+	return s.accountRepository.FindExisting(ctx, accountUUIDs)
+}
+
+func (s *AccountService) Update(ctx context.Context, accountUUID string, name string, email string) error {
+	account, errFind := s.Find(accountUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	// a field left out of the request keeps its value
+	if name != "" {
+		account.Name = name
+	}
+	if email != "" {
+		account.Email = email
+	}
+	account.SetUpdatedAt(time.Now().In(time.UTC))
+	return s.accountRepository.Update(ctx, account)
+}
+
+func (s *AccountService) Delete(ctx context.Context, accountUUID string) error {
+	account, errFind := s.Find(accountUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	// tickets have to leave their timelines before the cascade would drop them silently
+	var deleted, unassigned []*model.Ticket
+	errDelete := s.accountRepository.Delete(ctx, account, func(tx *sql.Tx) error {
+		var errDetach error
+		deleted, unassigned, errDetach = s.ticketRepository.DetachAccount(ctx, tx, account.GetUUID())
+		return errDetach
+	})
+	if errDelete != nil {
+		return errDelete
+	}
+
+	s.ticketRepository.RelayOutbox(ctx)
+	for _, ticket := range deleted {
+		events.Emit(ctx, s.publisher, events.TicketDeleted{Ticket: events.NewTicket(ticket)})
+	}
+	for _, ticket := range unassigned {
+		events.Emit(ctx, s.publisher, events.TicketUpdated{Ticket: events.NewTicket(ticket), Changed: []string{"assignee_uuid"}})
+	}
+	return nil
+}
+
+func (s *AccountService) GetAccount(ctx context.Context, randId string) (*model.Account, bool, error) {
+	isBlank, err := s.accountFetcher.IsBlank(ctx, randId)
+	if err != nil {
+		return nil, false, err
+	}
+	if isBlank {
+		return nil, true, nil
+	}
+
+	account, errFetch := s.accountFetcher.Fetch(ctx, randId)
+	if errFetch != nil {
+		return nil, false, errFetch
+	}
+
+	return account, false, nil
+}
+
+func (s *AccountService) SeedAccount(ctx context.Context, randId string) error {
+	return s.accountRepository.SeedByRandId(ctx, randId)
 }
 
 func (s *AccountService) SeedAccountByUUID(ctx context.Context, accountUUID string) error {
@@ -36,6 +129,24 @@ func (s *AccountService) GetAccountByUUID(ctx context.Context, accountUUID strin
 	return s.accountFetcher.FetchByUUID(ctx, accountUUID)
 }
 
+// ResolveAccount returns the account behind an authenticated caller, seeding
+// its cache entry on first use like GetAccountByUUID does.
+func (s *AccountService) ResolveAccount(ctx context.Context, accountUUID string) (*model.Account, error) {
+	account, errFetch := s.GetAccountByUUID(ctx, accountUUID)
+	if errFetch == nil {
+		return account, nil
+	}
+	if !errors.Is(errFetch, definition.NotFound) {
+		return nil, errFetch
+	}
+
+	errSeed := s.SeedAccountByUUID(ctx, accountUUID)
+	if errSeed != nil {
+		return nil, errSeed
+	}
+	return s.GetAccountByUUID(ctx, accountUUID)
+}
+
 func NewAccountService() *AccountService {
-	return &AccountService{}
+	return &AccountService{publisher: events.NewNoopPublisher()}
 }
@@ -0,0 +1,134 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"redifu-example/internal/fetcher"
+	"redifu-example/internal/model"
+	"redifu-example/internal/repository"
+	"slices"
+	"strings"
+)
+
+// prefix marks the plaintext keys so they are easy to spot in logs and
+// secret scanners, the randid after it locates the cached record.
+const prefix = "rk_"
+
+var InvalidAPIKey = errors.New("invalid api key")
+var MalformedAPIKey = errors.New("malformed api key")
+
+type APIKeyService struct {
+	apiKeyRepository *repository.APIKeyRepository
+	apiKeyFetcher    *fetcher.APIKeyFetcher
+}
+
+func (s *APIKeyService) InitRepository(apiKeyRepository *repository.APIKeyRepository) {
+	s.apiKeyRepository = apiKeyRepository
+}
+
+func (s *APIKeyService) InitFetcher(apiKeyFetcher *fetcher.APIKeyFetcher) {
+	s.apiKeyFetcher = apiKeyFetcher
+}
+
+// Create stores a new key and returns it with its plaintext form, which is
+// never persisted and cannot be shown again.
+func (s *APIKeyService) Create(ctx context.Context, name string, scopes []string) (*model.APIKey, string, error) {
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is empty", InvalidAPIKey)
+	}
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: scopes is empty", InvalidAPIKey)
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(model.Scopes, scope) {
+			return nil, "", fmt.Errorf("%w: unknown scope %q", InvalidAPIKey, scope)
+		}
+	}
+
+	buf := make([]byte, 32)
+	if _, errRand := rand.Read(buf); errRand != nil {
+		return nil, "", errRand
+	}
+	secret := hex.EncodeToString(buf)
+
+	apiKey := model.NewAPIKey()
+	apiKey.Name = name
+	apiKey.Scopes = scopes
+	apiKey.Hash = hashSecret(secret)
+
+	errCreate := s.apiKeyRepository.Create(ctx, apiKey)
+	if errCreate != nil {
+		return nil, "", errCreate
+	}
+
+	return apiKey, prefix + apiKey.GetRandId() + "_" + secret, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context) ([]*model.APIKey, error) {
+	return s.apiKeyRepository.List(ctx)
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, apiKeyUUID string) error {
+	apiKey, errFind := s.apiKeyRepository.FindByUUID(ctx, apiKeyUUID)
+	if errFind != nil {
+		return errFind
+	}
+	if apiKey.IsRevoked() {
+		return nil
+	}
+
+	return s.apiKeyRepository.Revoke(ctx, apiKey)
+}
+
+// GetAPIKey reads the cached record, a nil key that is not blank still has
+// to be seeded.
+func (s *APIKeyService) GetAPIKey(ctx context.Context, randId string) (*model.APIKey, bool, error) {
+	isBlank, errBlank := s.apiKeyFetcher.IsBlank(ctx, randId)
+	if errBlank != nil {
+		return nil, false, errBlank
+	}
+	if isBlank {
+		return nil, true, nil
+	}
+
+	apiKey, errFetch := s.apiKeyFetcher.Fetch(ctx, randId)
+	if errFetch != nil {
+		return nil, false, errFetch
+	}
+
+	return apiKey, false, nil
+}
+
+func (s *APIKeyService) SeedAPIKey(ctx context.Context, randId string) error {
+	return s.apiKeyRepository.SeedByRandId(ctx, randId)
+}
+
+// ParseKey splits a presented key into the record randid and its secret.
+func ParseKey(presented string) (string, string, error) {
+	body, hasPrefix := strings.CutPrefix(presented, prefix)
+	separator := strings.LastIndex(body, "_")
+	if !hasPrefix || separator <= 0 || separator == len(body)-1 {
+		return "", "", MalformedAPIKey
+	}
+	return body[:separator], body[separator+1:], nil
+}
+
+// Matches compares in constant time so response timing does not leak how
+// much of a guessed secret was right.
+func Matches(apiKey *model.APIKey, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(hashSecret(secret))) == 1
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func NewAPIKeyService() *APIKeyService {
+	return &APIKeyService{}
+}
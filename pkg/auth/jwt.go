@@ -0,0 +1,214 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+const RoleAdmin = "admin"
+
+var InvalidToken = errors.New("invalid token")
+var ExpiredToken = errors.New("token expired")
+
+// leeway absorbs clock skew between the token issuer and this node
+const leeway = 30 * time.Second
+
+// Claims are the registered claims the API relies on plus the caller's role,
+// Subject is the account uuid.
+type Claims struct {
+	Subject   string          `json:"sub"`
+	Role      string          `json:"role"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt float64         `json:"exp"`
+	NotBefore float64         `json:"nbf"`
+}
+
+func (c *Claims) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
+
+func (c *Claims) audiences() []string {
+	var single string
+	if json.Unmarshal(c.Audience, &single) == nil {
+		return []string{single}
+	}
+	var multiple []string
+	json.Unmarshal(c.Audience, &multiple)
+	return multiple
+}
+
+// Verifier checks compact JWS tokens signed with one configured algorithm,
+// the alg header must match it so an RS256 public key can never be used as
+// an HS256 secret.
+type Verifier struct {
+	algorithm string
+	secret    []byte
+	publicKey *rsa.PublicKey
+	issuer    string
+	audience  string
+}
+
+// WithIssuer and WithAudience make the matching claim mandatory.
+func (v *Verifier) WithIssuer(issuer string) *Verifier {
+	v.issuer = issuer
+	return v
+}
+
+func (v *Verifier) WithAudience(audience string) *Verifier {
+	v.audience = audience
+	return v
+}
+
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", InvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if errHeader := decodeSegment(parts[0], &header); errHeader != nil {
+		return nil, fmt.Errorf("%w: header: %v", InvalidToken, errHeader)
+	}
+	if header.Alg != v.algorithm {
+		return nil, fmt.Errorf("%w: unexpected alg %q", InvalidToken, header.Alg)
+	}
+
+	signature, errSignature := base64.RawURLEncoding.DecodeString(parts[2])
+	if errSignature != nil {
+		return nil, fmt.Errorf("%w: signature encoding", InvalidToken)
+	}
+	if !v.verifySignature(parts[0]+"."+parts[1], signature) {
+		return nil, fmt.Errorf("%w: signature mismatch", InvalidToken)
+	}
+
+	var claims Claims
+	if errClaims := decodeSegment(parts[1], &claims); errClaims != nil {
+		return nil, fmt.Errorf("%w: claims: %v", InvalidToken, errClaims)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: sub is empty", InvalidToken)
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, fmt.Errorf("%w: unexpected iss", InvalidToken)
+	}
+	if v.audience != "" && !slices.Contains(claims.audiences(), v.audience) {
+		return nil, fmt.Errorf("%w: unexpected aud", InvalidToken)
+	}
+
+	now := time.Now()
+	if claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("%w: exp is required", InvalidToken)
+	}
+	if now.Add(-leeway).After(numericDate(claims.ExpiresAt)) {
+		return nil, ExpiredToken
+	}
+	if claims.NotBefore != 0 && now.Add(leeway).Before(numericDate(claims.NotBefore)) {
+		return nil, fmt.Errorf("%w: not valid yet", InvalidToken)
+	}
+
+	return &claims, nil
+}
+
+func (v *Verifier) verifySignature(signed string, signature []byte) bool {
+	switch v.algorithm {
+	case HS256:
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signed))
+		return hmac.Equal(mac.Sum(nil), signature)
+	case RS256:
+		digest := sha256.Sum256([]byte(signed))
+		return rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
+
+func decodeSegment(segment string, target interface{}) error {
+	decoded, errDecode := base64.RawURLEncoding.DecodeString(segment)
+	if errDecode != nil {
+		return errDecode
+	}
+	return json.Unmarshal(decoded, target)
+}
+
+func numericDate(value float64) time.Time {
+	return time.Unix(0, int64(value*float64(time.Second)))
+}
+
+// NewVerifierFromFile loads the key for algorithm from keyFile: the shared
+// secret for HS256, a PEM public key or certificate for RS256.
+func NewVerifierFromFile(algorithm string, keyFile string) (*Verifier, error) {
+	raw, errRead := os.ReadFile(keyFile)
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	switch algorithm {
+	case HS256:
+		secret := []byte(strings.TrimSpace(string(raw)))
+		if len(secret) < 32 {
+			return nil, errors.New("HS256 secret must be at least 32 bytes")
+		}
+		return &Verifier{algorithm: HS256, secret: secret}, nil
+	case RS256:
+		publicKey, errParse := parseRSAPublicKey(raw)
+		if errParse != nil {
+			return nil, errParse
+		}
+		return &Verifier{algorithm: RS256, publicKey: publicKey}, nil
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+}
+
+func parseRSAPublicKey(raw []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("key file is not PEM encoded")
+	}
+
+	var parsed interface{}
+	var errParse error
+	switch block.Type {
+	case "PUBLIC KEY":
+		parsed, errParse = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, errParse = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		certificate, errCertificate := x509.ParseCertificate(block.Bytes)
+		if errCertificate != nil {
+			return nil, errCertificate
+		}
+		parsed = certificate.PublicKey
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("key is not an RSA public key")
+	}
+	return publicKey, nil
+}
@@ -0,0 +1,677 @@
+package cachecheck
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"redifu-example/internal/model"
+	"redifu-example/internal/pools"
+	"redifu-example/pkg/ticket"
+	"strings"
+	"time"
+)
+
+// Kinds of drift between a cached entry and its row.
+const (
+	Dangling   = "dangling"    // cached, but the row no longer exists
+	StaleScore = "stale_score" // sorted by a column that has since changed
+	Misplaced  = "misplaced"   // member of a parameterized key it no longer belongs to
+	StaleItem  = "stale_item"  // cached item fields differ from the row
+)
+
+const scanCount = 200
+
+// Options controls how deep the check goes and what it fixes. Sample caps the
+// members read per sorted set and the keys read per scanned family, 0 reads
+// everything. Repair fixes each drifting entry in place, Purge deletes every
+// drifting key instead so the next request seeds it from scratch.
+type Options struct {
+	Sample int
+	Repair bool
+	Purge  bool
+}
+
+type Drift struct {
+	Key      string `json:"key"`
+	Member   string `json:"member,omitempty"`
+	Kind     string `json:"kind"`
+	Detail   string `json:"detail,omitempty"`
+	Repaired bool   `json:"repaired"`
+}
+
+type FamilyReport struct {
+	Family  string  `json:"family"`
+	Keys    int     `json:"keys"`
+	Checked int     `json:"checked"`
+	Purged  int     `json:"purged"`
+	Drifts  []Drift `json:"drifts"`
+	Error   string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Families []*FamilyReport `json:"families"`
+	Duration time.Duration   `json:"duration"`
+}
+
+// DriftCount is the number of drifting entries across every family.
+func (r *Report) DriftCount() int {
+	count := 0
+	for _, family := range r.Families {
+		count += len(family.Drifts)
+	}
+	return count
+}
+
+// Checker compares the redifu key families against the ticket and account
+// tables. Sorted sets are read raw, their members are ticket randIds.
+type Checker struct {
+	db          *sql.DB
+	redisClient redis.UniversalClient
+	fetcherPool *pools.FetcherPool
+	options     Options
+}
+
+type member struct {
+	randId string
+	score  float64
+}
+
+// ticketRow is the row side of a comparison, categoryRandId is joined in
+// since the per-category timelines are keyed by it.
+type ticketRow struct {
+	ticket         *model.Ticket
+	categoryRandId string
+}
+
+func (c *Checker) Run(ctx context.Context) *Report {
+	startedAt := time.Now()
+	report := &Report{}
+
+	report.Families = append(report.Families,
+		c.checkTimeline(ctx, "ticket-timeline", nil),
+		c.checkTimeline(ctx, "ticket-timeline-by-security", nil),
+		c.checkTimeline(ctx, "ticket-timeline:category:*", func(param string, row *ticketRow) string {
+			if row.categoryRandId != param {
+				return fmt.Sprintf("ticket is in category %q", row.categoryRandId)
+			}
+			return ""
+		}),
+		c.checkTimeline(ctx, "ticket-timeline:status:*", func(param string, row *ticketRow) string {
+			if row.ticket.Status != param {
+				return fmt.Sprintf("ticket is %q", row.ticket.Status)
+			}
+			return ""
+		}),
+		c.checkTimeline(ctx, "ticket-timeline:filter:*", func(param string, row *ticketRow) string {
+			filter, errDecode := ticket.DecodeFilter(param)
+			if errDecode != nil {
+				return "undecodable filter key"
+			}
+			if !filter.Matches(row.ticket, row.categoryRandId) {
+				return "ticket no longer matches the filter"
+			}
+			return ""
+		}),
+		c.checkTimeline(ctx, "ticket-sorted-by-account:*", func(param string, row *ticketRow) string {
+			if row.ticket.AccountUUID != param {
+				return fmt.Sprintf("ticket is reported by %q", row.ticket.AccountUUID)
+			}
+			return ""
+		}),
+		c.checkTimeline(ctx, "ticket-sorted-by-assignee:*", func(param string, row *ticketRow) string {
+			if row.ticket.AssigneeUUID != param {
+				return fmt.Sprintf("ticket is assigned to %q", row.ticket.AssigneeUUID)
+			}
+			return ""
+		}),
+		c.checkTimeline(ctx, "ticket-time-series", nil),
+		c.checkPages(ctx),
+		c.checkComments(ctx),
+		c.checkCategories(ctx),
+		c.checkBaseTickets(ctx),
+		c.checkAccountPointers(ctx),
+	)
+
+	report.Duration = time.Since(startedAt)
+	return report
+}
+
+// checkTimeline verifies one family of ticket sorted sets. A pattern ending
+// in * is a parameterized family, belongs then tells whether a row may sit
+// under the key's param.
+func (c *Checker) checkTimeline(ctx context.Context, pattern string, belongs func(param string, row *ticketRow) string) *FamilyReport {
+	family := &FamilyReport{Family: pattern}
+	prefix := strings.TrimSuffix(pattern, "*")
+
+	c.eachSortedSet(ctx, family, func(key string, members []member) error {
+		rows, errLoad := c.loadTickets(ctx, memberRandIds(members))
+		if errLoad != nil {
+			return errLoad
+		}
+
+		var drifts []Drift
+		for _, m := range members {
+			row, exists := rows[m.randId]
+			switch {
+			case !exists:
+				drifts = append(drifts, Drift{Key: key, Member: m.randId, Kind: Dangling})
+			case key == "ticket-timeline-by-security" && int64(m.score) != row.ticket.SecurityRisk:
+				drifts = append(drifts, Drift{Key: key, Member: m.randId, Kind: StaleScore,
+					Detail: fmt.Sprintf("cached %d, row %d", int64(m.score), row.ticket.SecurityRisk)})
+			case belongs != nil:
+				if detail := belongs(strings.TrimPrefix(key, prefix), row); detail != "" {
+					drifts = append(drifts, Drift{Key: key, Member: m.randId, Kind: Misplaced, Detail: detail})
+				}
+			}
+		}
+
+		return c.settle(ctx, family, key, drifts, func(drift *Drift) error {
+			switch drift.Kind {
+			case StaleScore:
+				// rescored the same way the repository does it, remove then add back
+				row := rows[drift.Member].ticket
+				errRemove := c.fetcherPool.TimelineSortBySecurityRisk.RemoveItem(ctx, row)
+				if errRemove != nil {
+					return errRemove
+				}
+				return c.fetcherPool.TimelineSortBySecurityRisk.AddItem(ctx, row)
+			case Dangling:
+				errRemove := c.redisClient.ZRem(ctx, key, drift.Member).Err()
+				if errRemove != nil {
+					return errRemove
+				}
+				return c.fetcherPool.BaseTicket.MarkAsMissing(ctx, drift.Member)
+			default:
+				return c.redisClient.ZRem(ctx, key, drift.Member).Err()
+			}
+		})
+	})
+
+	return family
+}
+
+// checkPages verifies the cached listing pages. A page holds its rows in
+// seed order, so one is never patched member by member: Repair purges the
+// listing through redifu the way a write does and the next read reseeds it.
+func (c *Checker) checkPages(ctx context.Context) *FamilyReport {
+	family := &FamilyReport{Family: "ticket-page*"}
+
+	isPurged := false
+	c.eachSortedSet(ctx, family, func(key string, members []member) error {
+		rows, errLoad := c.loadTickets(ctx, memberRandIds(members))
+		if errLoad != nil {
+			return errLoad
+		}
+
+		var drifts []Drift
+		for _, m := range members {
+			if _, exists := rows[m.randId]; !exists {
+				drifts = append(drifts, Drift{Key: key, Member: m.randId, Kind: Dangling})
+			}
+		}
+
+		return c.settle(ctx, family, key, drifts, func(drift *Drift) error {
+			if isPurged {
+				return nil
+			}
+			errPurge := c.fetcherPool.Page.Purge(ctx)
+			if errPurge != nil {
+				return errPurge
+			}
+			isPurged = true
+			return nil
+		})
+	})
+
+	return family
+}
+
+// checkComments verifies the per-ticket comment timelines, keyed
+// ticket-comments:<ticketRandId>, whose members are comment randIds.
+func (c *Checker) checkComments(ctx context.Context) *FamilyReport {
+	family := &FamilyReport{Family: "ticket-comments:*"}
+
+	c.eachSortedSet(ctx, family, func(key string, members []member) error {
+		ticketRandIds, errLoad := c.loadCommentTickets(ctx, memberRandIds(members))
+		if errLoad != nil {
+			return errLoad
+		}
+
+		param := strings.TrimPrefix(key, "ticket-comments:")
+		var drifts []Drift
+		for _, m := range members {
+			ticketRandId, exists := ticketRandIds[m.randId]
+			switch {
+			case !exists:
+				drifts = append(drifts, Drift{Key: key, Member: m.randId, Kind: Dangling})
+			case ticketRandId != param:
+				drifts = append(drifts, Drift{Key: key, Member: m.randId, Kind: Misplaced,
+					Detail: fmt.Sprintf("comment is on ticket %q", ticketRandId)})
+			}
+		}
+
+		return c.settle(ctx, family, key, drifts, func(drift *Drift) error {
+			errRemove := c.redisClient.ZRem(ctx, key, drift.Member).Err()
+			if errRemove != nil || drift.Kind != Dangling {
+				return errRemove
+			}
+			return c.fetcherPool.BaseComment.MarkAsMissing(ctx, drift.Member)
+		})
+	})
+
+	return family
+}
+
+// checkCategories verifies category-sorted against the category table.
+func (c *Checker) checkCategories(ctx context.Context) *FamilyReport {
+	family := &FamilyReport{Family: "category-sorted"}
+
+	c.eachSortedSet(ctx, family, func(key string, members []member) error {
+		existing, errLoad := c.loadExisting(ctx, "SELECT randid FROM category WHERE randid = ANY($1)", memberRandIds(members))
+		if errLoad != nil {
+			return errLoad
+		}
+
+		var drifts []Drift
+		for _, m := range members {
+			if !existing[m.randId] {
+				drifts = append(drifts, Drift{Key: key, Member: m.randId, Kind: Dangling})
+			}
+		}
+
+		return c.settle(ctx, family, key, drifts, func(drift *Drift) error {
+			errRemove := c.redisClient.ZRem(ctx, key, drift.Member).Err()
+			if errRemove != nil {
+				return errRemove
+			}
+			return c.fetcherPool.BaseCategory.MarkAsMissing(ctx, drift.Member)
+		})
+	})
+
+	return family
+}
+
+// eachSortedSet runs check on the sampled members of every non-empty sorted
+// set of the family, a family ending in * is scanned for its keys. Errors are
+// collected on the family report.
+func (c *Checker) eachSortedSet(ctx context.Context, family *FamilyReport, check func(key string, members []member) error) {
+	keys := []string{family.Family}
+	if strings.HasSuffix(family.Family, "*") {
+		var errScan error
+		keys, errScan = c.scanKeys(ctx, family.Family, "zset")
+		if errScan != nil {
+			family.Error = errScan.Error()
+			return
+		}
+	}
+
+	var errs []error
+	for _, key := range keys {
+		members, errMembers := c.sampleMembers(ctx, key)
+		if errMembers != nil {
+			errs = append(errs, errMembers)
+			continue
+		}
+		if len(members) == 0 {
+			continue
+		}
+		family.Keys++
+		family.Checked += len(members)
+
+		errs = append(errs, check(key, members))
+	}
+
+	if errJoined := errors.Join(errs...); errJoined != nil {
+		family.Error = errJoined.Error()
+	}
+}
+
+func memberRandIds(members []member) []string {
+	randIds := make([]string, 0, len(members))
+	for _, m := range members {
+		randIds = append(randIds, m.randId)
+	}
+	return randIds
+}
+
+// checkBaseTickets compares the cached ticket items, keyed ticket:<randId>,
+// field by field with their rows.
+func (c *Checker) checkBaseTickets(ctx context.Context) *FamilyReport {
+	family := &FamilyReport{Family: "ticket:*"}
+
+	keys, errScan := c.scanKeys(ctx, "ticket:*", "")
+	if errScan != nil {
+		family.Error = errScan.Error()
+		return family
+	}
+
+	var randIds []string
+	for _, key := range keys {
+		randId := strings.TrimPrefix(key, "ticket:")
+		if !strings.Contains(randId, ":") {
+			randIds = append(randIds, randId)
+		}
+	}
+	family.Keys = len(randIds)
+
+	rows, errLoad := c.loadTickets(ctx, randIds)
+	if errLoad != nil {
+		family.Error = errLoad.Error()
+		return family
+	}
+
+	var errs []error
+	for _, randId := range randIds {
+		cached, errGet := c.fetcherPool.BaseTicket.Get(ctx, randId)
+		if errGet != nil {
+			errs = append(errs, errGet)
+			continue
+		}
+		if cached == nil {
+			// marked as missing or expired in between
+			continue
+		}
+		family.Checked++
+
+		key := "ticket:" + randId
+		var drifts []Drift
+		row, exists := rows[randId]
+		if !exists {
+			drifts = append(drifts, Drift{Key: key, Member: randId, Kind: Dangling})
+		} else if detail := diffTicket(cached, row.ticket); detail != "" {
+			drifts = append(drifts, Drift{Key: key, Member: randId, Kind: StaleItem, Detail: detail})
+		}
+
+		errs = append(errs, c.settle(ctx, family, key, drifts, func(drift *Drift) error {
+			if drift.Kind == Dangling {
+				errDel := c.redisClient.Del(ctx, key).Err()
+				if errDel != nil {
+					return errDel
+				}
+				return c.fetcherPool.BaseTicket.MarkAsMissing(ctx, randId)
+			}
+			return c.fetcherPool.BaseTicket.Set(ctx, row.ticket)
+		}))
+	}
+
+	if errJoined := errors.Join(errs...); errJoined != nil {
+		family.Error = errJoined.Error()
+	}
+	return family
+}
+
+// checkAccountPointers verifies every account:pointer:<uuid> still resolves
+// to the account's randId.
+func (c *Checker) checkAccountPointers(ctx context.Context) *FamilyReport {
+	family := &FamilyReport{Family: "account:pointer:*"}
+
+	keys, errScan := c.scanKeys(ctx, "account:pointer:*", "string")
+	if errScan != nil {
+		family.Error = errScan.Error()
+		return family
+	}
+	family.Keys = len(keys)
+	if len(keys) == 0 {
+		return family
+	}
+
+	pointers, errGet := c.redisClient.MGet(ctx, keys...).Result()
+	if errGet != nil {
+		family.Error = errGet.Error()
+		return family
+	}
+
+	uuids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		uuids = append(uuids, strings.TrimPrefix(key, "account:pointer:"))
+	}
+	randIds, errLoad := c.loadAccountRandIds(ctx, uuids)
+	if errLoad != nil {
+		family.Error = errLoad.Error()
+		return family
+	}
+
+	var errs []error
+	for i, key := range keys {
+		pointer, isString := pointers[i].(string)
+		if !isString {
+			continue
+		}
+		family.Checked++
+
+		var drifts []Drift
+		randId, exists := randIds[uuids[i]]
+		if !exists {
+			drifts = append(drifts, Drift{Key: key, Member: pointer, Kind: Dangling})
+		} else if randId != pointer {
+			drifts = append(drifts, Drift{Key: key, Member: pointer, Kind: StaleItem,
+				Detail: fmt.Sprintf("account randid is %q", randId)})
+		}
+
+		// a pointer is rebuilt on its next lookup, deleting it is the repair either way
+		errs = append(errs, c.settle(ctx, family, key, drifts, func(drift *Drift) error {
+			return c.redisClient.Del(ctx, key).Err()
+		}))
+	}
+
+	if errJoined := errors.Join(errs...); errJoined != nil {
+		family.Error = errJoined.Error()
+	}
+	return family
+}
+
+// settle records the drifts of one key and applies the configured fix.
+func (c *Checker) settle(ctx context.Context, family *FamilyReport, key string, drifts []Drift, repair func(*Drift) error) error {
+	if len(drifts) == 0 {
+		return nil
+	}
+
+	var errs []error
+	switch {
+	case c.options.Purge:
+		errPurge := c.redisClient.Del(ctx, key).Err()
+		if errPurge != nil {
+			errs = append(errs, errPurge)
+			break
+		}
+		family.Purged++
+		for i := range drifts {
+			drifts[i].Repaired = true
+		}
+	case c.options.Repair:
+		for i := range drifts {
+			errRepair := repair(&drifts[i])
+			if errRepair != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", key, drifts[i].Member, errRepair))
+				continue
+			}
+			drifts[i].Repaired = true
+		}
+	}
+
+	family.Drifts = append(family.Drifts, drifts...)
+	return errors.Join(errs...)
+}
+
+func (c *Checker) scanKeys(ctx context.Context, pattern string, keyType string) ([]string, error) {
+	var keys []string
+	var iterator *redis.ScanIterator
+	if keyType != "" {
+		iterator = c.redisClient.ScanType(ctx, 0, pattern, scanCount, keyType).Iterator()
+	} else {
+		iterator = c.redisClient.Scan(ctx, 0, pattern, scanCount).Iterator()
+	}
+
+	for iterator.Next(ctx) {
+		keys = append(keys, iterator.Val())
+		if c.options.Sample > 0 && len(keys) >= c.options.Sample {
+			break
+		}
+	}
+
+	return keys, iterator.Err()
+}
+
+func (c *Checker) sampleMembers(ctx context.Context, key string) ([]member, error) {
+	var entries []redis.Z
+	var errRead error
+	if c.options.Sample > 0 {
+		entries, errRead = c.redisClient.ZRandMemberWithScores(ctx, key, c.options.Sample).Result()
+	} else {
+		entries, errRead = c.redisClient.ZRangeWithScores(ctx, key, 0, -1).Result()
+	}
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	members := make([]member, 0, len(entries))
+	for _, entry := range entries {
+		randId, isString := entry.Member.(string)
+		if !isString {
+			continue
+		}
+		members = append(members, member{randId: randId, score: entry.Score})
+	}
+	return members, nil
+}
+
+func (c *Checker) loadTickets(ctx context.Context, randIds []string) (map[string]*ticketRow, error) {
+	rows := make(map[string]*ticketRow, len(randIds))
+	if len(randIds) == 0 {
+		return rows, nil
+	}
+
+	query := `
+		SELECT t.uuid, t.randid, t.created_at, t.updated_at, t.account_uuid, t.description,
+		       t.security_risk, t.category_uuid, t.status, t.assignee_uuid, c.randid
+		FROM ticket t
+		LEFT JOIN category c ON t.category_uuid = c.uuid
+		WHERE t.randid = ANY($1)
+	`
+	result, errQuery := c.db.QueryContext(ctx, query, pq.Array(randIds))
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer result.Close()
+
+	for result.Next() {
+		row := &ticketRow{ticket: model.NewTicket()}
+		var categoryUUID, assigneeUUID, categoryRandId sql.NullString
+		errScan := result.Scan(&row.ticket.UUID, &row.ticket.RandId, &row.ticket.CreatedAt, &row.ticket.UpdatedAt,
+			&row.ticket.AccountUUID, &row.ticket.Description, &row.ticket.SecurityRisk, &categoryUUID,
+			&row.ticket.Status, &assigneeUUID, &categoryRandId)
+		if errScan != nil {
+			return nil, errScan
+		}
+		row.ticket.CategoryUUID = categoryUUID.String
+		row.ticket.AssigneeUUID = assigneeUUID.String
+		row.ticket.CategoryRandId = categoryRandId.String
+		row.categoryRandId = categoryRandId.String
+		rows[row.ticket.GetRandId()] = row
+	}
+
+	return rows, result.Err()
+}
+
+// loadCommentTickets maps each comment randId to the randId of its ticket.
+func (c *Checker) loadCommentTickets(ctx context.Context, randIds []string) (map[string]string, error) {
+	query := `
+		SELECT c.randid, t.randid
+		FROM comment c
+		JOIN ticket t ON c.ticket_uuid = t.uuid
+		WHERE c.randid = ANY($1)
+	`
+	result, errQuery := c.db.QueryContext(ctx, query, pq.Array(randIds))
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer result.Close()
+
+	ticketRandIds := make(map[string]string, len(randIds))
+	for result.Next() {
+		var commentRandId, ticketRandId string
+		if errScan := result.Scan(&commentRandId, &ticketRandId); errScan != nil {
+			return nil, errScan
+		}
+		ticketRandIds[commentRandId] = ticketRandId
+	}
+
+	return ticketRandIds, result.Err()
+}
+
+// loadExisting runs a single column query over values and returns which of
+// them it found.
+func (c *Checker) loadExisting(ctx context.Context, query string, values []string) (map[string]bool, error) {
+	result, errQuery := c.db.QueryContext(ctx, query, pq.Array(values))
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer result.Close()
+
+	existing := make(map[string]bool, len(values))
+	for result.Next() {
+		var value string
+		if errScan := result.Scan(&value); errScan != nil {
+			return nil, errScan
+		}
+		existing[value] = true
+	}
+
+	return existing, result.Err()
+}
+
+func (c *Checker) loadAccountRandIds(ctx context.Context, uuids []string) (map[string]string, error) {
+	result, errQuery := c.db.QueryContext(ctx, "SELECT uuid, randid FROM account WHERE uuid = ANY($1)", pq.Array(uuids))
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer result.Close()
+
+	randIds := make(map[string]string, len(uuids))
+	for result.Next() {
+		var uuid, randId string
+		if errScan := result.Scan(&uuid, &randId); errScan != nil {
+			return nil, errScan
+		}
+		randIds[uuid] = randId
+	}
+
+	return randIds, result.Err()
+}
+
+// diffTicket lists the mutable columns that differ, an empty string means the
+// cached item is current.
+func diffTicket(cached *model.Ticket, row *model.Ticket) string {
+	var fields []string
+	if cached.Description != row.Description {
+		fields = append(fields, "description")
+	}
+	if cached.Status != row.Status {
+		fields = append(fields, "status")
+	}
+	if cached.SecurityRisk != row.SecurityRisk {
+		fields = append(fields, "security_risk")
+	}
+	if cached.CategoryUUID != row.CategoryUUID {
+		fields = append(fields, "category_uuid")
+	}
+	if cached.AssigneeUUID != row.AssigneeUUID {
+		fields = append(fields, "assignee_uuid")
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return "differs in " + strings.Join(fields, ", ")
+}
+
+func NewChecker(db *sql.DB, redisClient redis.UniversalClient, fetcherPool *pools.FetcherPool, options Options) *Checker {
+	return &Checker{
+		db:          db,
+		redisClient: redisClient,
+		fetcherPool: fetcherPool,
+		options:     options,
+	}
+}
@@ -0,0 +1,115 @@
+package category
+
+import (
+	"context"
+	"redifu-example/internal/fetcher"
+	"redifu-example/internal/model"
+	"redifu-example/internal/repository"
+	"redifu-example/pkg/events"
+	"time"
+)
+
+type CategoryService struct {
+	categoryRepository *repository.CategoryRepository
+	ticketRepository   *repository.TicketRepository
+	categoryFetcher    *fetcher.CategoryFetcher
+	publisher          events.Publisher
+}
+
+func (s *CategoryService) InitRepository(categoryRepository *repository.CategoryRepository, ticketRepository *repository.TicketRepository) {
+	s.categoryRepository = categoryRepository
+	s.ticketRepository = ticketRepository
+}
+
+func (s *CategoryService) InitFetcher(categoryFetcher *fetcher.CategoryFetcher) {
+	s.categoryFetcher = categoryFetcher
+}
+
+func (s *CategoryService) InitPublisher(publisher events.Publisher) {
+	s.publisher = publisher
+}
+
+func (s *CategoryService) Create(ctx context.Context, name string) error {
+	category := model.NewCategory()
+	category.SetCategory(name)
+
+	return s.categoryRepository.Create(ctx, category)
+}
+
+func (s *CategoryService) Rename(ctx context.Context, randId string, name string) error {
+	category, errFind := s.categoryRepository.FindByRandId(ctx, randId)
+	if errFind != nil {
+		return errFind
+	}
+
+	category.SetCategory(name)
+	category.SetUpdatedAt(time.Now().In(time.UTC))
+	return s.categoryRepository.Update(ctx, category)
+}
+
+func (s *CategoryService) Delete(ctx context.Context, randId string) error {
+	category, errFind := s.categoryRepository.FindByRandId(ctx, randId)
+	if errFind != nil {
+		return errFind
+	}
+
+	// tickets have to leave the category timeline before the category itself disappears
+	detached, errDetach := s.ticketRepository.DetachCategory(ctx, category.GetUUID(), category.GetRandId())
+	if errDetach != nil {
+		return errDetach
+	}
+
+	// the tickets left the category whether or not the row delete below succeeds
+	for _, ticket := range detached {
+		events.Emit(ctx, s.publisher, events.TicketUpdated{Ticket: events.NewTicket(ticket), Changed: []string{"category_uuid"}})
+	}
+
+	return s.categoryRepository.Delete(ctx, category)
+}
+
+func (s *CategoryService) GetCategory(ctx context.Context, randId string) (*model.Category, bool, error) {
+	isBlank, err := s.categoryFetcher.IsBlank(ctx, randId)
+	if err != nil {
+		return nil, false, err
+	}
+	if isBlank {
+		return nil, true, nil
+	}
+
+	category, errFetch := s.categoryFetcher.Fetch(ctx, randId)
+	if errFetch != nil {
+		return nil, false, errFetch
+	}
+
+	return category, false, nil
+}
+
+func (s *CategoryService) GetCategories(ctx context.Context) ([]*model.Category, bool, error) {
+	categories, errFetch := s.categoryFetcher.FetchAll(ctx)
+	if errFetch != nil {
+		return nil, false, errFetch
+	}
+	if len(categories) == 0 {
+		isSeedRequired, errCheck := s.categoryFetcher.IsSortedSeedingRequired(ctx)
+		if errCheck != nil {
+			return nil, false, errCheck
+		}
+		if isSeedRequired {
+			return nil, true, nil
+		}
+	}
+
+	return categories, false, nil
+}
+
+func (s *CategoryService) SeedCategory(ctx context.Context, randId string) error {
+	return s.categoryRepository.SeedCategory(ctx, randId)
+}
+
+func (s *CategoryService) SeedCategories(ctx context.Context) error {
+	return s.categoryRepository.SeedCategories(ctx)
+}
+
+func NewCategoryService() *CategoryService {
+	return &CategoryService{publisher: events.NewNoopPublisher()}
+}
@@ -0,0 +1,129 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/21strive/redifu"
+	"redifu-example/definition"
+	"redifu-example/internal/fetcher"
+	"redifu-example/internal/model"
+	"redifu-example/internal/repository"
+	"redifu-example/pkg/account"
+	"time"
+)
+
+type CommentService struct {
+	commentRepository *repository.CommentRepository
+	ticketRepository  *repository.TicketRepository
+	accountService    *account.AccountService
+	commentFetcher    *fetcher.CommentFetcher
+}
+
+func (s *CommentService) InitRepository(commentRepository *repository.CommentRepository, ticketRepository *repository.TicketRepository, accountService *account.AccountService) {
+	s.commentRepository = commentRepository
+	s.ticketRepository = ticketRepository
+	s.accountService = accountService
+}
+
+func (s *CommentService) InitFetcher(commentFetcher *fetcher.CommentFetcher) {
+	s.commentFetcher = commentFetcher
+}
+
+func (s *CommentService) Create(ctx context.Context, ticketUUID string, accountUUID string, parentUUID string, body string) error {
+	ticket, errFind := s.ticketRepository.FindByUUID(ctx, ticketUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	// checked up front so an unknown author is a 404 rather than a foreign key violation
+	author, errFindAuthor := s.accountService.Find(accountUUID)
+	if errFindAuthor != nil {
+		if errors.Is(errFindAuthor, definition.NotFound) {
+			return fmt.Errorf("account %s: %w", accountUUID, errFindAuthor)
+		}
+		return errFindAuthor
+	}
+
+	comment := model.NewComment()
+	comment.SetTicketUUID(ticket.GetUUID())
+	comment.SetAccountUUID(author.GetUUID())
+	comment.SetBody(body)
+
+	if parentUUID != "" {
+		parent, errFindParent := s.commentRepository.FindByUUID(ctx, parentUUID)
+		if errFindParent != nil {
+			return errFindParent
+		}
+		// a reply has to stay within the thread of the same ticket
+		if parent.TicketUUID != ticket.GetUUID() {
+			return definition.NotFound
+		}
+		comment.SetParentUUID(parent.GetUUID())
+	}
+
+	return s.commentRepository.Create(ctx, comment, ticket.GetRandId())
+}
+
+func (s *CommentService) UpdateBody(ctx context.Context, commentUUID string, body string) error {
+	comment, errFind := s.commentRepository.FindByUUID(ctx, commentUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	comment.SetBody(body)
+	comment.SetUpdatedAt(time.Now().In(time.UTC))
+	return s.commentRepository.Update(ctx, comment)
+}
+
+func (s *CommentService) Delete(ctx context.Context, commentUUID string) error {
+	comment, errFind := s.commentRepository.FindByUUID(ctx, commentUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	ticket, errFindTicket := s.ticketRepository.FindByUUID(ctx, comment.TicketUUID)
+	if errFindTicket != nil {
+		return errFindTicket
+	}
+
+	return s.commentRepository.Delete(ctx, comment, ticket.GetRandId())
+}
+
+func (s *CommentService) GetComments(ctx context.Context, ticketRandId string, lastRandId []string) ([]*model.Comment, string, string, bool, error) {
+	fetchRes := s.commentFetcher.FetchTimeline(ctx, ticketRandId, lastRandId)
+	if fetchRes.Error() != nil {
+		requiresSeed := false
+		if errors.Is(fetchRes.Error(), redifu.ResetPagination) {
+			requiresSeed = true
+		}
+		return nil, fetchRes.ValidLastId(), fetchRes.Position(), requiresSeed, fetchRes.Error()
+	}
+
+	comments := fetchRes.Items()
+	totalReceivedItems := int64(len(comments))
+	if totalReceivedItems < definition.ItemPerPage {
+		seedRequired, errCheck := s.commentFetcher.IsTimelineSeedingRequired(ctx, ticketRandId, totalReceivedItems)
+		if errCheck != nil {
+			return nil, fetchRes.ValidLastId(), fetchRes.Position(), false, errCheck
+		}
+		if seedRequired {
+			return comments, fetchRes.ValidLastId(), fetchRes.Position(), true, nil
+		}
+	}
+
+	return comments, fetchRes.ValidLastId(), fetchRes.Position(), false, nil
+}
+
+func (s *CommentService) SeedComments(ctx context.Context, subtraction int64, lastRandId string, ticketRandId string) error {
+	ticket, errFind := s.ticketRepository.FindByRandId(ticketRandId)
+	if errFind != nil {
+		return errFind
+	}
+
+	return s.commentRepository.SeedComments(ctx, subtraction, lastRandId, ticketRandId, ticket.GetUUID())
+}
+
+func NewCommentService() *CommentService {
+	return &CommentService{}
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/21strive/item"
+	"redifu-example/internal/logger"
+	"redifu-example/internal/model"
+	"time"
+)
+
+// Version is the envelope schema version. It only moves when a field is
+// removed or changes meaning, new optional fields keep the same version.
+const Version = 1
+
+const (
+	TypeTicketCreated  = "ticket.created"
+	TypeTicketUpdated  = "ticket.updated"
+	TypeTicketResolved = "ticket.resolved"
+	TypeTicketDeleted  = "ticket.deleted"
+	TypeAccountCreated = "account.created"
+)
+
+// Event is implemented by every typed event below.
+type Event interface {
+	EventType() string
+}
+
+// Envelope is the wire form of an event, Data holds the typed event as JSON.
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func NewEnvelope(event Event) (*Envelope, error) {
+	data, errMarshal := json.Marshal(event)
+	if errMarshal != nil {
+		return nil, errMarshal
+	}
+
+	return &Envelope{
+		ID:         item.RandId(),
+		Type:       event.EventType(),
+		Version:    Version,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}, nil
+}
+
+// Ticket is the ticket state carried by ticket events.
+type Ticket struct {
+	UUID         string `json:"uuid"`
+	RandId       string `json:"rand_id"`
+	Description  string `json:"description"`
+	Status       string `json:"status"`
+	SecurityRisk int64  `json:"security_risk"`
+	ReporterUUID string `json:"reporter_uuid"`
+	CategoryUUID string `json:"category_uuid,omitempty"`
+	// CategoryRandId lets consumers without database access match the
+	// categoryRandId filter used by the list endpoints
+	CategoryRandId string    `json:"category_rand_id,omitempty"`
+	AssigneeUUID   string    `json:"assignee_uuid,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func NewTicket(ticket *model.Ticket) Ticket {
+	return Ticket{
+		UUID:           ticket.GetUUID(),
+		RandId:         ticket.GetRandId(),
+		Description:    ticket.Description,
+		Status:         ticket.Status,
+		SecurityRisk:   ticket.SecurityRisk,
+		ReporterUUID:   ticket.AccountUUID,
+		CategoryUUID:   ticket.CategoryUUID,
+		CategoryRandId: ticket.CategoryRandId,
+		AssigneeUUID:   ticket.AssigneeUUID,
+		CreatedAt:      ticket.GetCreatedAt(),
+		UpdatedAt:      ticket.GetUpdatedAt(),
+	}
+}
+
+type TicketCreated struct {
+	Ticket Ticket `json:"ticket"`
+}
+
+func (e TicketCreated) EventType() string { return TypeTicketCreated }
+
+// TicketUpdated lists the fields that changed next to the new state.
+type TicketUpdated struct {
+	Ticket  Ticket   `json:"ticket"`
+	Changed []string `json:"changed"`
+}
+
+func (e TicketUpdated) EventType() string { return TypeTicketUpdated }
+
+type TicketResolved struct {
+	Ticket     Ticket `json:"ticket"`
+	FromStatus string `json:"from_status"`
+	Note       string `json:"note,omitempty"`
+}
+
+func (e TicketResolved) EventType() string { return TypeTicketResolved }
+
+type TicketDeleted struct {
+	Ticket Ticket `json:"ticket"`
+}
+
+func (e TicketDeleted) EventType() string { return TypeTicketDeleted }
+
+type AccountCreated struct {
+	UUID      string    `json:"uuid"`
+	RandId    string    `json:"rand_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (e AccountCreated) EventType() string { return TypeAccountCreated }
+
+func NewAccountCreated(account *model.Account) AccountCreated {
+	return AccountCreated{
+		UUID:      account.GetUUID(),
+		RandId:    account.GetRandId(),
+		Name:      account.Name,
+		Email:     account.Email,
+		CreatedAt: account.GetCreatedAt(),
+	}
+}
+
+// Emit publishes after the write has committed, a failed publish is logged
+// and never fails the write that caused it.
+func Emit(ctx context.Context, publisher Publisher, event Event) {
+	if publisher == nil {
+		return
+	}
+
+	if errPublish := publisher.Publish(ctx, event); errPublish != nil {
+		logger.Logger.Error("event-publish-error", "type", event.EventType(), "error", errPublish.Error())
+	}
+}
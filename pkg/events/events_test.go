@@ -0,0 +1,191 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testTicket = Ticket{
+	UUID:           "7f0c1d5e-0000-4000-8000-000000000001",
+	RandId:         "tkt0001",
+	Description:    "login page returns 500",
+	Status:         "resolved",
+	SecurityRisk:   7,
+	ReporterUUID:   "reporter",
+	CategoryUUID:   "category-uuid",
+	CategoryRandId: "category",
+	CreatedAt:      time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	UpdatedAt:      time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+}
+
+// TestEnvelopeWireFormat pins the JSON consumers parse, a change here needs a
+// Version bump unless it only adds an optional field.
+func TestEnvelopeWireFormat(t *testing.T) {
+	envelope, errEnvelope := NewEnvelope(TicketResolved{Ticket: testTicket, FromStatus: "open", Note: "fixed"})
+	if errEnvelope != nil {
+		t.Fatal(errEnvelope)
+	}
+	if envelope.Version != 1 || envelope.Type != TypeTicketResolved || envelope.ID == "" || envelope.OccurredAt.IsZero() {
+		t.Fatalf("envelope %+v", envelope)
+	}
+
+	encoded, errMarshal := json.Marshal(envelope)
+	if errMarshal != nil {
+		t.Fatal(errMarshal)
+	}
+	var fields map[string]json.RawMessage
+	if errUnmarshal := json.Unmarshal(encoded, &fields); errUnmarshal != nil {
+		t.Fatal(errUnmarshal)
+	}
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	for _, name := range []string{"id", "type", "version", "occurred_at", "data"} {
+		if _, exists := fields[name]; !exists {
+			t.Errorf("envelope has no %q field, got %v", name, names)
+		}
+	}
+	if len(fields) != 5 {
+		t.Errorf("envelope fields %v, want exactly id, type, version, occurred_at and data", names)
+	}
+
+	var data interface{}
+	if errUnmarshal := json.Unmarshal(fields["data"], &data); errUnmarshal != nil {
+		t.Fatal(errUnmarshal)
+	}
+	var want interface{}
+	json.Unmarshal([]byte(`{
+		"ticket": {
+			"uuid": "7f0c1d5e-0000-4000-8000-000000000001",
+			"rand_id": "tkt0001",
+			"description": "login page returns 500",
+			"status": "resolved",
+			"security_risk": 7,
+			"reporter_uuid": "reporter",
+			"category_uuid": "category-uuid",
+			"category_rand_id": "category",
+			"created_at": "2026-03-01T12:00:00Z",
+			"updated_at": "2026-03-02T12:00:00Z"
+		},
+		"from_status": "open",
+		"note": "fixed"
+	}`), &want)
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("data is %s", fields["data"])
+	}
+}
+
+func TestMemoryBus(t *testing.T) {
+	bus := NewMemoryBus()
+	var received []string
+	bus.Subscribe(func(envelope *Envelope) {
+		received = append(received, envelope.Type)
+	})
+
+	ctx := context.Background()
+	for _, event := range []Event{TicketCreated{Ticket: testTicket}, TicketDeleted{Ticket: testTicket}} {
+		if errPublish := bus.Publish(ctx, event); errPublish != nil {
+			t.Fatal(errPublish)
+		}
+	}
+
+	want := []string{TypeTicketCreated, TypeTicketDeleted}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("subscriber received %v, want %v", received, want)
+	}
+	published := bus.Published()
+	if len(published) != 2 || published[0].Type != TypeTicketCreated || published[1].Type != TypeTicketDeleted {
+		t.Errorf("published %d envelopes out of order", len(published))
+	}
+}
+
+type failingPublisher struct{}
+
+func (p failingPublisher) Publish(ctx context.Context, event Event) error {
+	return errors.New("publisher is down")
+}
+
+func TestMultiPublisherKeepsGoing(t *testing.T) {
+	bus := NewMemoryBus()
+	errPublish := NewMultiPublisher(failingPublisher{}, bus).Publish(context.Background(), TicketCreated{Ticket: testTicket})
+	if errPublish == nil {
+		t.Error("the failing publisher's error was dropped")
+	}
+	if len(bus.Published()) != 1 {
+		t.Error("the failing publisher stopped the next one")
+	}
+}
+
+func newTestStream(t *testing.T) (*RedisStreamPublisher, *StreamReader, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisStreamPublisher(client, "ticket-events", 100), NewStreamReader(client, "ticket-events"), server
+}
+
+func TestStreamReaderReadsPublishedEnvelopes(t *testing.T) {
+	publisher, reader, server := newTestStream(t)
+	ctx := context.Background()
+
+	latest, errLatest := reader.Latest(ctx)
+	if errLatest != nil || latest != "0-0" {
+		t.Fatalf("latest of an empty stream is %q (%v), want 0-0", latest, errLatest)
+	}
+
+	if errPublish := publisher.Publish(ctx, TicketCreated{Ticket: testTicket}); errPublish != nil {
+		t.Fatal(errPublish)
+	}
+	server.XAdd("ticket-events", "*", []string{"type", "ticket.created", "envelope", "{"})
+
+	entries, errRead := reader.Read(ctx, "0-0", 10, 10*time.Millisecond)
+	if errRead != nil {
+		t.Fatal(errRead)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("read %d entries, want 2", len(entries))
+	}
+
+	ticket, isTicket := entries[0].Ticket()
+	if entries[0].Envelope == nil || entries[0].Envelope.Type != TypeTicketCreated || !isTicket || ticket.RandId != testTicket.RandId {
+		t.Errorf("first entry %+v", entries[0])
+	}
+	if entries[1].Envelope != nil || entries[1].ID == "" {
+		t.Errorf("the undecodable entry is %+v, want its id without an envelope", entries[1])
+	}
+
+	latest, errLatest = reader.Latest(ctx)
+	if errLatest != nil || latest != entries[1].ID {
+		t.Errorf("latest is %q (%v), want %q", latest, errLatest, entries[1].ID)
+	}
+	after, errAfter := reader.Read(ctx, latest, 10, 10*time.Millisecond)
+	if errAfter != nil || len(after) != 0 {
+		t.Errorf("read %d entries after the latest (%v), want none", len(after), errAfter)
+	}
+}
+
+func TestStreamReaderRejectsInvalidIDs(t *testing.T) {
+	_, reader, _ := newTestStream(t)
+
+	for _, id := range []string{"", "$", ">", "abc", "1-", "-1", "1-2-3", "0-0 1", "1700000000000"} {
+		if IsValidStreamID(id) {
+			t.Errorf("%q is accepted", id)
+		}
+		if _, errRead := reader.Read(context.Background(), id, 10, time.Millisecond); !errors.Is(errRead, InvalidStreamID) {
+			t.Errorf("read after %q: %v, want InvalidStreamID", id, errRead)
+		}
+	}
+	for _, id := range []string{"0-0", "1700000000000-0", "1700000000000-12"} {
+		if !IsValidStreamID(id) {
+			t.Errorf("%q is rejected", id)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/redis/go-redis/v9"
+	"sync"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// NoopPublisher drops every event, it is what services use until a
+// publisher is configured.
+type NoopPublisher struct{}
+
+func (p NoopPublisher) Publish(ctx context.Context, event Event) error {
+	return nil
+}
+
+func NewNoopPublisher() NoopPublisher {
+	return NoopPublisher{}
+}
+
+// RedisStreamPublisher appends each envelope to a Redis stream under the
+// "envelope" field, the stream is trimmed to roughly maxLen entries.
+type RedisStreamPublisher struct {
+	client redis.UniversalClient
+	stream string
+	maxLen int64
+}
+
+func (p *RedisStreamPublisher) Publish(ctx context.Context, event Event) error {
+	envelope, errEnvelope := NewEnvelope(event)
+	if errEnvelope != nil {
+		return errEnvelope
+	}
+
+	encoded, errMarshal := json.Marshal(envelope)
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	return p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: p.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{"type": envelope.Type, "envelope": encoded},
+	}).Err()
+}
+
+func NewRedisStreamPublisher(client redis.UniversalClient, stream string, maxLen int64) *RedisStreamPublisher {
+	return &RedisStreamPublisher{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+// MemoryBus keeps published envelopes in process and hands them to
+// subscribers synchronously, meant for tests, it keeps every envelope so
+// nothing long-running should publish to it.
+type MemoryBus struct {
+	mu          sync.Mutex
+	published   []*Envelope
+	subscribers []func(*Envelope)
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, event Event) error {
+	envelope, errEnvelope := NewEnvelope(event)
+	if errEnvelope != nil {
+		return errEnvelope
+	}
+
+	b.mu.Lock()
+	b.published = append(b.published, envelope)
+	subscribers := append([]func(*Envelope){}, b.subscribers...)
+	b.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(envelope)
+	}
+	return nil
+}
+
+func (b *MemoryBus) Subscribe(subscriber func(*Envelope)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+// Published returns every envelope so far, oldest first.
+func (b *MemoryBus) Published() []*Envelope {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*Envelope{}, b.published...)
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{}
+}
+
+// MultiPublisher hands every event to each publisher in order, one failing
+// publisher does not stop the others.
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, event Event) error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if errPublish := publisher.Publish(ctx, event); errPublish != nil {
+			errs = append(errs, errPublish)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/redis/go-redis/v9"
+	"regexp"
+	"time"
+)
+
+var InvalidStreamID = errors.New("invalid stream id")
+
+var streamIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// StreamEntry is one envelope read back from the stream, ID is the Redis
+// stream id and doubles as the SSE event id consumers resume from.
+type StreamEntry struct {
+	ID       string
+	Envelope *Envelope
+}
+
+// Ticket decodes the ticket carried by ticket.* envelopes.
+func (e *StreamEntry) Ticket() (*Ticket, bool) {
+	var payload struct {
+		Ticket *Ticket `json:"ticket"`
+	}
+	if errUnmarshal := json.Unmarshal(e.Envelope.Data, &payload); errUnmarshal != nil || payload.Ticket == nil {
+		return nil, false
+	}
+	return payload.Ticket, true
+}
+
+// StreamReader tails the stream RedisStreamPublisher writes to. It only needs
+// Redis, so GETTER nodes can serve live feeds without database access.
+type StreamReader struct {
+	client redis.UniversalClient
+	stream string
+}
+
+// Latest returns the id of the newest entry, or "0-0" when the stream is
+// empty, reading after it yields only events published from now on.
+func (r *StreamReader) Latest(ctx context.Context) (string, error) {
+	entries, errRange := r.client.XRevRangeN(ctx, r.stream, "+", "-", 1).Result()
+	if errRange != nil {
+		return "", errRange
+	}
+	if len(entries) == 0 {
+		return "0-0", nil
+	}
+	return entries[0].ID, nil
+}
+
+// Read returns up to count entries after lastID, waiting at most block for
+// the first one. An empty result means the wait timed out.
+func (r *StreamReader) Read(ctx context.Context, lastID string, count int64, block time.Duration) ([]StreamEntry, error) {
+	if !streamIDPattern.MatchString(lastID) {
+		return nil, InvalidStreamID
+	}
+
+	streams, errRead := r.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{r.stream, lastID},
+		Count:   count,
+		Block:   block,
+	}).Result()
+	if errRead != nil {
+		if errors.Is(errRead, redis.Nil) {
+			return nil, nil
+		}
+		return nil, errRead
+	}
+
+	var entries []StreamEntry
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			entry := StreamEntry{ID: message.ID}
+			encoded, _ := message.Values["envelope"].(string)
+			// undecodable entries keep their id so the reader still moves past them
+			var envelope Envelope
+			if errUnmarshal := json.Unmarshal([]byte(encoded), &envelope); errUnmarshal == nil {
+				entry.Envelope = &envelope
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// IsValidStreamID reports whether id can be passed to Read.
+func IsValidStreamID(id string) bool {
+	return streamIDPattern.MatchString(id)
+}
+
+func NewStreamReader(client redis.UniversalClient, stream string) *StreamReader {
+	return &StreamReader{
+		client: client,
+		stream: stream,
+	}
+}
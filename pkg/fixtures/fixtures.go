@@ -0,0 +1,240 @@
+package fixtures
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"math/rand/v2"
+	"redifu-example/internal/model"
+	"strings"
+	"time"
+)
+
+const randIdAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// Config sizes the generated data set. The same Seed and Until always produce
+// the same rows. Skew is the Zipf exponent of tickets per reporter, it has to
+// be above 1 and a higher value piles more tickets onto fewer reporters.
+type Config struct {
+	Accounts   int
+	Categories int
+	Tickets    int
+	Window     time.Duration
+	Until      time.Time
+	Seed       uint64
+	Skew       float64
+}
+
+func (c *Config) Validate() error {
+	if c.Accounts < 1 {
+		return fmt.Errorf("at least one account is required")
+	}
+	if c.Categories < 0 || c.Tickets < 0 {
+		return fmt.Errorf("categories and tickets must not be negative")
+	}
+	if c.Window <= 0 {
+		return fmt.Errorf("window must be positive")
+	}
+	if c.Skew <= 1 {
+		return fmt.Errorf("skew must be greater than 1")
+	}
+	return nil
+}
+
+type Report struct {
+	Accounts   int           `json:"accounts"`
+	Categories int           `json:"categories"`
+	Tickets    int           `json:"tickets"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// Generator bulk loads accounts, categories and tickets with COPY inside one
+// transaction, a failed run leaves nothing behind.
+type Generator struct {
+	config *Config
+	rng    *rand.Rand
+}
+
+func (g *Generator) Generate(ctx context.Context, db *sql.DB) (*Report, error) {
+	startedAt := time.Now()
+
+	tx, errBegin := db.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+	defer tx.Rollback()
+
+	accountUUIDs, errAccounts := g.copyAccounts(ctx, tx)
+	if errAccounts != nil {
+		return nil, fmt.Errorf("copy accounts: %w", errAccounts)
+	}
+
+	categoryUUIDs, errCategories := g.copyCategories(ctx, tx)
+	if errCategories != nil {
+		return nil, fmt.Errorf("copy categories: %w", errCategories)
+	}
+
+	if errTickets := g.copyTickets(ctx, tx, accountUUIDs, categoryUUIDs); errTickets != nil {
+		return nil, fmt.Errorf("copy tickets: %w", errTickets)
+	}
+
+	if errCommit := tx.Commit(); errCommit != nil {
+		return nil, errCommit
+	}
+
+	return &Report{
+		Accounts:   len(accountUUIDs),
+		Categories: len(categoryUUIDs),
+		Tickets:    g.config.Tickets,
+		Duration:   time.Since(startedAt),
+	}, nil
+}
+
+func (g *Generator) copyAccounts(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	createdAt := g.config.Until.Add(-g.config.Window)
+	uuids := make([]string, g.config.Accounts)
+
+	errCopy := copyRows(ctx, tx, pq.CopyIn("account", "uuid", "randid", "created_at", "updated_at", "name", "email"),
+		g.config.Accounts, func(i int) []interface{} {
+			uuids[i] = g.uuid()
+			first, last := pick(g.rng, firstNames), pick(g.rng, lastNames)
+			email := fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), i)
+			return []interface{}{uuids[i], g.randId(), createdAt, createdAt, first + " " + last, email}
+		})
+	return uuids, errCopy
+}
+
+func (g *Generator) copyCategories(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	createdAt := g.config.Until.Add(-g.config.Window)
+	uuids := make([]string, g.config.Categories)
+
+	errCopy := copyRows(ctx, tx, pq.CopyIn("category", "uuid", "randid", "created_at", "updated_at", "category"),
+		g.config.Categories, func(i int) []interface{} {
+			uuids[i] = g.uuid()
+			// category names are unique, later rounds through the list get a suffix
+			name := categoryNames[i%len(categoryNames)]
+			if round := i / len(categoryNames); round > 0 {
+				name = fmt.Sprintf("%s %d", name, round+1)
+			}
+			return []interface{}{uuids[i], g.randId(), createdAt, createdAt, name}
+		})
+	return uuids, errCopy
+}
+
+// copyTickets spreads tickets uniformly over the window. Reporters follow a
+// Zipf distribution, older tickets are more likely to be resolved and the
+// first tenth of the accounts act as the support staff tickets get assigned to.
+func (g *Generator) copyTickets(ctx context.Context, tx *sql.Tx, accountUUIDs []string, categoryUUIDs []string) error {
+	reporters := rand.NewZipf(g.rng, g.config.Skew, 1, uint64(len(accountUUIDs)-1))
+	staff := max(1, len(accountUUIDs)/10)
+	windowStart := g.config.Until.Add(-g.config.Window)
+
+	return copyRows(ctx, tx, pq.CopyIn("ticket", "uuid", "randid", "created_at", "updated_at", "account_uuid",
+		"description", "security_risk", "category_uuid", "status", "assignee_uuid"),
+		g.config.Tickets, func(i int) []interface{} {
+			offset := time.Duration(g.rng.Int64N(int64(g.config.Window)))
+			createdAt := windowStart.Add(offset).Truncate(time.Microsecond)
+			age := 1 - float64(offset)/float64(g.config.Window)
+
+			var categoryUUID interface{}
+			if len(categoryUUIDs) > 0 && g.rng.Float64() >= 0.1 {
+				categoryUUID = pick(g.rng, categoryUUIDs)
+			}
+
+			status := g.status(age)
+			var assigneeUUID interface{}
+			if status != model.StatusOpen && g.rng.Float64() < 0.7 {
+				assigneeUUID = accountUUIDs[g.rng.IntN(staff)]
+			}
+
+			return []interface{}{
+				g.uuid(), g.randId(), createdAt, createdAt,
+				accountUUIDs[reporters.Uint64()], g.description(), g.securityRisk(),
+				categoryUUID, status, assigneeUUID,
+			}
+		})
+}
+
+// status leans towards resolved and closed as age approaches 1, the start of
+// the window.
+func (g *Generator) status(age float64) string {
+	roll := g.rng.Float64()
+	switch {
+	case roll < age*0.6:
+		return model.StatusClosed
+	case roll < age*0.8:
+		return model.StatusResolved
+	}
+	return pick(g.rng, []string{model.StatusOpen, model.StatusOpen, model.StatusTriaged, model.StatusInProgress,
+		model.StatusAwaitingReporter, model.StatusReopened})
+}
+
+// securityRisk is mostly low with a thin tail of critical tickets.
+func (g *Generator) securityRisk() int64 {
+	roll := g.rng.Float64()
+	switch {
+	case roll < 0.70:
+		return g.rng.Int64N(21)
+	case roll < 0.90:
+		return 21 + g.rng.Int64N(40)
+	case roll < 0.98:
+		return 61 + g.rng.Int64N(25)
+	}
+	return 86 + g.rng.Int64N(15)
+}
+
+func (g *Generator) description() string {
+	return fmt.Sprintf("%s %s %s %s", pick(g.rng, subjects), pick(g.rng, symptoms), pick(g.rng, contexts), pick(g.rng, details))
+}
+
+// uuid is a version 4 uuid drawn from the seeded source instead of crypto/rand.
+func (g *Generator) uuid() string {
+	var b [16]byte
+	for i := 0; i < len(b); i += 8 {
+		value := g.rng.Uint64()
+		for j := 0; j < 8; j++ {
+			b[i+j] = byte(value >> (8 * j))
+		}
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (g *Generator) randId() string {
+	var b [16]byte
+	for i := range b {
+		b[i] = randIdAlphabet[g.rng.IntN(len(randIdAlphabet))]
+	}
+	return string(b[:])
+}
+
+func copyRows(ctx context.Context, tx *sql.Tx, copyStatement string, count int, row func(i int) []interface{}) error {
+	stmt, errPrepare := tx.PrepareContext(ctx, copyStatement)
+	if errPrepare != nil {
+		return errPrepare
+	}
+	defer stmt.Close()
+
+	for i := 0; i < count; i++ {
+		if _, errExec := stmt.ExecContext(ctx, row(i)...); errExec != nil {
+			return errExec
+		}
+	}
+
+	// the final empty exec flushes the buffered rows
+	_, errFlush := stmt.ExecContext(ctx)
+	return errFlush
+}
+
+func pick[T any](rng *rand.Rand, values []T) T {
+	return values[rng.IntN(len(values))]
+}
+
+func NewGenerator(config *Config) *Generator {
+	return &Generator{
+		config: config,
+		rng:    rand.New(rand.NewPCG(config.Seed, config.Seed^0x9e3779b97f4a7c15)),
+	}
+}
@@ -0,0 +1,37 @@
+package fixtures
+
+var firstNames = []string{
+	"Ada", "Bima", "Chen", "Dewi", "Elena", "Farhan", "Grace", "Hiro", "Intan", "Jonas",
+	"Kirana", "Luca", "Maya", "Nadia", "Omar", "Putri", "Quinn", "Rizky", "Sofia", "Tomas",
+}
+
+var lastNames = []string{
+	"Anderson", "Budiman", "Costa", "Dubois", "Eriksen", "Fischer", "Gunawan", "Hartono", "Ito", "Kowalski",
+	"Larsen", "Moreau", "Nakamura", "Okafor", "Pratama", "Rossi", "Santoso", "Tanaka", "Wijaya", "Yilmaz",
+}
+
+var categoryNames = []string{
+	"Authentication", "Billing", "Network", "Storage", "Mobile App", "Web App", "API", "Email",
+	"Payments", "Reporting", "Search", "Notifications", "Access Control", "Infrastructure", "Data Export",
+}
+
+var subjects = []string{
+	"Login page", "Password reset", "Invoice download", "File upload", "Dashboard", "Checkout",
+	"Two-factor prompt", "Search results", "Export job", "Session cookie", "Webhook delivery", "Profile form",
+}
+
+var symptoms = []string{
+	"fails with a timeout", "returns a 500 error", "leaks another user's data", "is extremely slow",
+	"shows a blank screen", "accepts an expired token", "crashes the app", "drops the last change",
+	"redirects to an unknown domain", "exposes an internal stack trace",
+}
+
+var contexts = []string{
+	"on Android", "on iOS", "in Safari", "in Firefox", "behind the corporate proxy", "after the latest release",
+	"for accounts in the EU region", "when the network is flaky", "for admin users", "during peak hours",
+}
+
+var details = []string{
+	"since this morning.", "for about a third of the requests.", "every time.", "intermittently.",
+	"after a password change.", "when two tabs are open.", "only with SSO.", "right after sign up.",
+}
@@ -0,0 +1,159 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"net/url"
+	"redifu-example/definition"
+	"redifu-example/internal/pools"
+	"redifu-example/internal/repository"
+	"redifu-example/pkg/ticket"
+	"time"
+)
+
+// sample is the fixture ticket the captured seeds are parameterized with. It
+// sits one page deep in the timeline so the next-page queries start after it.
+type sample struct {
+	randId         string
+	accountUUID    string
+	assigneeUUID   string
+	categoryUUID   string
+	categoryRandId string
+	status         string
+}
+
+// CaptureSeedPlans runs every TicketRepository seeder once against db and
+// returns the SQL redifu built for each, first pages and next pages alike.
+// The seeders write to an in-process Redis that is thrown away afterwards,
+// writes they send to Postgres are recorded but never run.
+func CaptureSeedPlans(ctx context.Context, db *sql.DB) ([]repository.SeedPlan, error) {
+	sampled, errSample := loadSample(ctx, db)
+	if errSample != nil {
+		return nil, errSample
+	}
+
+	server, errServer := miniredis.Run()
+	if errServer != nil {
+		return nil, errServer
+	}
+	defer server.Close()
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer redisClient.Close()
+
+	recordingDB, recorder := repository.NewRecordingDB(db)
+	defer recordingDB.Close()
+
+	fetcherPool := pools.NewFetcherPool(redisClient)
+	seederPool := pools.NewSeederPool()
+	seederPool.InitTicketSeeders(redisClient, recordingDB, fetcherPool)
+	ticketRepo := repository.NewTicketRepository(recordingDB, fetcherPool, seederPool)
+	ticketRepo.InitFilterDecoder(ticket.FilterDecoder)
+
+	combined, errCombined := ticket.ParseFilter(url.Values{
+		"status":         {sampled.status},
+		"categoryRandId": {sampled.categoryRandId},
+		"minRisk":        {"50"},
+	})
+	if errCombined != nil {
+		return nil, errCombined
+	}
+	unresolved, errUnresolved := ticket.ParseFilter(url.Values{
+		"unresolved":   {"true"},
+		"reporterUUID": {sampled.accountUUID},
+	})
+	if errUnresolved != nil {
+		return nil, errUnresolved
+	}
+
+	now := time.Now()
+	subtraction := definition.ItemPerPage
+	seeds := []struct {
+		name string
+		seed func() error
+	}{
+		{"SeedTickets", func() error { return ticketRepo.SeedTickets(ctx, 0, "") }},
+		{"SeedTickets.NextPage", func() error { return ticketRepo.SeedTickets(ctx, subtraction, sampled.randId) }},
+		{"SeedTicketsBySecurityRisk", func() error { return ticketRepo.SeedTicketsBySecurityRisk(ctx, 0, "") }},
+		{"SeedTicketsBySecurityRisk.NextPage", func() error {
+			return ticketRepo.SeedTicketsBySecurityRisk(ctx, subtraction, sampled.randId)
+		}},
+		{"SeedByCategory", func() error {
+			return ticketRepo.SeedByCategory(ctx, 0, "", sampled.categoryRandId, sampled.categoryUUID)
+		}},
+		{"SeedByCategory.NextPage", func() error {
+			return ticketRepo.SeedByCategory(ctx, subtraction, sampled.randId, sampled.categoryRandId, sampled.categoryUUID)
+		}},
+		{"SeedByStatus", func() error { return ticketRepo.SeedByStatus(ctx, 0, "", sampled.status) }},
+		{"SeedByStatus.NextPage", func() error {
+			return ticketRepo.SeedByStatus(ctx, subtraction, sampled.randId, sampled.status)
+		}},
+		{"SeedByFilter", func() error {
+			query, args := combined.Query(sampled.categoryUUID)
+			return ticketRepo.SeedByFilter(ctx, 0, "", combined.Key(), query, args)
+		}},
+		{"SeedByFilter.NextPage", func() error {
+			query, args := combined.Query(sampled.categoryUUID)
+			return ticketRepo.SeedByFilter(ctx, subtraction, sampled.randId, combined.Key(), query, args)
+		}},
+		{"SeedByFilter.Unresolved", func() error {
+			query, args := unresolved.Query("")
+			return ticketRepo.SeedByFilter(ctx, 0, "", unresolved.Key(), query, args)
+		}},
+		{"SeedByAccount", func() error { return ticketRepo.SeedByAccount(ctx, sampled.accountUUID) }},
+		{"SeedByAssignee", func() error { return ticketRepo.SeedByAssignee(ctx, sampled.assigneeUUID) }},
+		{"SeedPage", func() error { return ticketRepo.SeedPage(ctx, 3) }},
+		{"SeedByDate", func() error { return ticketRepo.SeedByDate(ctx, now.Add(-24*time.Hour), now) }},
+		{"SeedSearch", func() error { return ticketRepo.SeedSearch(ctx, "password reset", 1) }},
+	}
+
+	var plans []repository.SeedPlan
+	var errs []error
+	for _, seed := range seeds {
+		errSeed := seed.seed()
+		recorded := recorder.Take(seed.name)
+		if len(recorded) == 0 {
+			errs = append(errs, fmt.Errorf("%s sent no query: %v", seed.name, errSeed))
+			continue
+		}
+		// a next page first looks up the row it starts after, each query is planned on its own
+		if len(recorded) > 1 {
+			for i := range recorded {
+				recorded[i].Name = fmt.Sprintf("%s#%d", seed.name, i+1)
+			}
+		}
+		plans = append(plans, recorded...)
+	}
+
+	return plans, errors.Join(errs...)
+}
+
+// loadSample picks a ticket with a category and an assignee, preferably one
+// page deep so a next page after it is not empty.
+func loadSample(ctx context.Context, db *sql.DB) (*sample, error) {
+	query := `
+		SELECT t.randid, t.account_uuid, t.assignee_uuid, c.uuid, c.randid, t.status
+		FROM ticket t
+		JOIN category c ON t.category_uuid = c.uuid
+		WHERE t.assignee_uuid IS NOT NULL
+		ORDER BY t.created_at DESC
+		OFFSET $1
+		LIMIT 1
+	`
+	for _, offset := range []int64{definition.ItemPerPage, 0} {
+		sampled := &sample{}
+		errScan := db.QueryRowContext(ctx, query, offset).Scan(&sampled.randId, &sampled.accountUUID, &sampled.assigneeUUID,
+			&sampled.categoryUUID, &sampled.categoryRandId, &sampled.status)
+		if errScan == nil {
+			return sampled, nil
+		}
+		if !errors.Is(errScan, sql.ErrNoRows) {
+			return nil, errScan
+		}
+	}
+
+	return nil, errors.New("the fixture needs a ticket with a category and an assignee to capture seeder queries from")
+}
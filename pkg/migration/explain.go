@@ -0,0 +1,102 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"redifu-example/internal/repository"
+	"slices"
+)
+
+// analyzedTables get fresh statistics before explaining, a freshly loaded
+// fixture has none and the planner would guess.
+var analyzedTables = []string{"account", "category", "ticket"}
+
+// PlanReport is the outcome of one seeder query. SeqScans names every
+// relation read sequentially, a scan counts as a failure once the relation
+// holds at least the minimum rows passed to Explain.
+type PlanReport struct {
+	Name     string   `json:"name"`
+	Nodes    []string `json:"nodes"`
+	SeqScans []string `json:"seq_scans,omitempty"`
+	Failed   bool     `json:"failed"`
+	Error    string   `json:"error,omitempty"`
+}
+
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	IndexName    string     `json:"Index Name"`
+	Plans        []planNode `json:"Plans"`
+}
+
+// Explain plans every query without running it.
+func Explain(ctx context.Context, db *sql.DB, plans []repository.SeedPlan, minRows int64) ([]PlanReport, error) {
+	for _, table := range analyzedTables {
+		if _, errAnalyze := db.ExecContext(ctx, "ANALYZE "+table); errAnalyze != nil {
+			return nil, fmt.Errorf("analyze %s: %w", table, errAnalyze)
+		}
+	}
+
+	rowCounts := make(map[string]int64)
+	reports := make([]PlanReport, 0, len(plans))
+	for _, plan := range plans {
+		report := PlanReport{Name: plan.Name}
+
+		var raw []byte
+		errExplain := db.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+plan.Query, plan.Args...).Scan(&raw)
+		if errExplain != nil {
+			report.Failed = true
+			report.Error = errExplain.Error()
+			reports = append(reports, report)
+			continue
+		}
+
+		var explained []struct {
+			Plan planNode `json:"Plan"`
+		}
+		if errDecode := json.Unmarshal(raw, &explained); errDecode != nil || len(explained) == 0 {
+			return nil, fmt.Errorf("decode plan of %s: %v", plan.Name, errDecode)
+		}
+
+		var errRows error
+		walkPlan(explained[0].Plan, func(node planNode) {
+			description := node.NodeType
+			if node.IndexName != "" {
+				description += " using " + node.IndexName
+			} else if node.RelationName != "" {
+				description += " on " + node.RelationName
+			}
+			report.Nodes = append(report.Nodes, description)
+
+			if node.NodeType != "Seq Scan" || slices.Contains(report.SeqScans, node.RelationName) {
+				return
+			}
+			report.SeqScans = append(report.SeqScans, node.RelationName)
+
+			rows, counted := rowCounts[node.RelationName]
+			if !counted {
+				errRows = db.QueryRowContext(ctx, "SELECT reltuples::bigint FROM pg_class WHERE relname = $1", node.RelationName).Scan(&rows)
+				rowCounts[node.RelationName] = rows
+			}
+			if rows >= minRows {
+				report.Failed = true
+			}
+		})
+		if errRows != nil {
+			return nil, fmt.Errorf("count rows for %s: %w", plan.Name, errRows)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func walkPlan(node planNode, visit func(node planNode)) {
+	visit(node)
+	for _, child := range node.Plans {
+		walkPlan(child, visit)
+	}
+}
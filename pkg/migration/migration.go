@@ -0,0 +1,535 @@
+package migration
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"redifu-example/internal/logger"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lockKey serializes migrators across every process sharing the database, it
+// sits next to the outbox relay key.
+const lockKey = 7_310_255
+
+// NoTransaction as the first line of a script runs it outside a transaction,
+// one statement at a time, which CREATE INDEX CONCURRENTLY requires. Each
+// finished statement is recorded, a rerun after a failure resumes at the one
+// that failed.
+const NoTransaction = "-- migrate:no-transaction"
+
+// Directions a script runs in, progress of a no-transaction script is kept per direction.
+const (
+	directionUp   = "up"
+	directionDown = "down"
+)
+
+// States reported by Status.
+const (
+	Applied  = "applied"
+	Pending  = "pending"
+	Missing  = "missing"  // recorded as applied, but its file is gone
+	Modified = "modified" // applied, but its up script changed since
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+var UnknownVersion = errors.New("unknown migration version")
+var ModifiedMigration = errors.New("applied migration was modified")
+
+// concurrentIndex captures the name of an index built by CREATE INDEX CONCURRENTLY.
+var concurrentIndex = regexp.MustCompile(`(?i)^\s*CREATE\s+(?:UNIQUE\s+)?INDEX\s+CONCURRENTLY\s+(?:IF\s+NOT\s+EXISTS\s+)?("?[a-z0-9_]+"?)`)
+
+// Migration is a pair of NNNN_name.up.sql and NNNN_name.down.sql scripts.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+type record struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Load reads every migration at the root of fsys, sorted by version. A down
+// script is optional but a migration without one cannot be rolled back.
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, errRead := fs.ReadDir(fsys, ".")
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %s", entry.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, errContent := fs.ReadFile(fsys, entry.Name())
+		if errContent != nil {
+			return nil, errContent
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("version %d is used by both %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		sum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, migration)
+	}
+	slices.SortFunc(migrations, func(a, b *Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// Migrator applies migrations under a session advisory lock, so concurrent
+// deploys wait for each other instead of racing. Each migration and its
+// schema_migrations row commit in one transaction.
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+// Up applies every pending migration in version order. It refuses to run
+// while an applied migration differs from its file, see Status.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	errLock := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]record) error {
+		if errModified := m.checkModified(applied); errModified != nil {
+			return errModified
+		}
+		for _, migration := range m.migrations {
+			if _, isApplied := applied[migration.Version]; isApplied {
+				continue
+			}
+			if errApply := m.apply(ctx, conn, migration); errApply != nil {
+				return errApply
+			}
+			count++
+		}
+		return nil
+	})
+	return count, errLock
+}
+
+// Down rolls back the n most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	count := 0
+	errLock := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]record) error {
+		for _, version := range appliedVersions(applied, true) {
+			if count == n {
+				break
+			}
+			if errRollback := m.rollback(ctx, conn, version); errRollback != nil {
+				return errRollback
+			}
+			count++
+		}
+		return nil
+	})
+	return count, errLock
+}
+
+// Goto migrates up or down until version is the latest applied one, version 0
+// rolls everything back.
+func (m *Migrator) Goto(ctx context.Context, version int64) (int, error) {
+	if version != 0 && m.find(version) == nil {
+		return 0, fmt.Errorf("%w: %d", UnknownVersion, version)
+	}
+
+	count := 0
+	errLock := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]record) error {
+		if errModified := m.checkModified(applied); errModified != nil {
+			return errModified
+		}
+		for _, appliedVersion := range appliedVersions(applied, true) {
+			if appliedVersion <= version {
+				break
+			}
+			if errRollback := m.rollback(ctx, conn, appliedVersion); errRollback != nil {
+				return errRollback
+			}
+			count++
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, isApplied := applied[migration.Version]; isApplied {
+				continue
+			}
+			if errApply := m.apply(ctx, conn, migration); errApply != nil {
+				return errApply
+			}
+			count++
+		}
+		return nil
+	})
+	return count, errLock
+}
+
+// Status lists every known and every applied migration in version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	errLock := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]record) error {
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name, State: Pending}
+			if row, isApplied := applied[migration.Version]; isApplied {
+				status.State = Applied
+				if row.checksum != migration.Checksum {
+					status.State = Modified
+				}
+				status.AppliedAt = &row.appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+
+		for _, version := range appliedVersions(applied, false) {
+			if m.find(version) != nil {
+				continue
+			}
+			row := applied[version]
+			statuses = append(statuses, Status{Version: version, Name: row.name, State: Missing, AppliedAt: &row.appliedAt})
+		}
+		return nil
+	})
+
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return statuses, errLock
+}
+
+// checkModified fails on the first applied migration whose up script no
+// longer matches the recorded checksum.
+func (m *Migrator) checkModified(applied map[int64]record) error {
+	for _, migration := range m.migrations {
+		if row, isApplied := applied[migration.Version]; isApplied && row.checksum != migration.Checksum {
+			return fmt.Errorf("%w: %d_%s, restore its file or add a new migration", ModifiedMigration, migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// withLock pins one connection for the session lock, creates the history
+// table and hands fn the versions applied so far.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, applied map[int64]record) error) error {
+	conn, errConn := m.db.Conn(ctx)
+	if errConn != nil {
+		return errConn
+	}
+	defer conn.Close()
+
+	if _, errLock := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); errLock != nil {
+		return fmt.Errorf("acquire migration lock: %w", errLock)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+
+	createHistory := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+		    version bigint PRIMARY KEY,
+		    name varchar(255) NOT NULL,
+		    checksum char(64) NOT NULL,
+		    applied_at timestamp NOT NULL DEFAULT NOW()
+		);
+	`
+	if _, errCreate := conn.ExecContext(ctx, createHistory); errCreate != nil {
+		return fmt.Errorf("create schema_migrations: %w", errCreate)
+	}
+
+	createProgress := `
+		CREATE TABLE IF NOT EXISTS schema_migrations_progress (
+		    version bigint NOT NULL,
+		    direction varchar(4) NOT NULL,
+		    statements integer NOT NULL,
+		    PRIMARY KEY (version, direction)
+		);
+	`
+	if _, errCreate := conn.ExecContext(ctx, createProgress); errCreate != nil {
+		return fmt.Errorf("create schema_migrations_progress: %w", errCreate)
+	}
+
+	rows, errQuery := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if errQuery != nil {
+		return errQuery
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]record)
+	for rows.Next() {
+		var version int64
+		var row record
+		if errScan := rows.Scan(&version, &row.name, &row.checksum, &row.appliedAt); errScan != nil {
+			return errScan
+		}
+		applied[version] = row
+	}
+	if errRows := rows.Err(); errRows != nil {
+		return errRows
+	}
+	rows.Close()
+
+	return fn(conn, applied)
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	start := time.Now()
+	errRun := run(ctx, conn, migration.Version, directionUp, migration.Up, func(exec executor) error {
+		_, errRecord := exec.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum)
+		return errRecord
+	})
+	if errRun != nil {
+		return fmt.Errorf("apply %d_%s: %w", migration.Version, migration.Name, errRun)
+	}
+
+	logger.Logger.Info("migration-applied", "version", migration.Version, "name", migration.Name, "duration", time.Since(start).String())
+	return nil
+}
+
+func (m *Migrator) rollback(ctx context.Context, conn *sql.Conn, version int64) error {
+	migration := m.find(version)
+	if migration == nil {
+		return fmt.Errorf("%w: %d is applied but its file is missing", UnknownVersion, version)
+	}
+	if migration.Down == "" {
+		return fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+	}
+
+	start := time.Now()
+	errRun := run(ctx, conn, migration.Version, directionDown, migration.Down, func(exec executor) error {
+		_, errRecord := exec.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+		return errRecord
+	})
+	if errRun != nil {
+		return fmt.Errorf("roll back %d_%s: %w", migration.Version, migration.Name, errRun)
+	}
+
+	logger.Logger.Info("migration-rolled-back", "version", migration.Version, "name", migration.Name, "duration", time.Since(start).String())
+	return nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// run executes script and record in one transaction, or statement by
+// statement when the script opts out of it. Statements of such a script must
+// each end with a semicolon at the end of a line.
+func run(ctx context.Context, conn *sql.Conn, version int64, direction string, script string, record func(exec executor) error) error {
+	if strings.HasPrefix(strings.TrimSpace(script), NoTransaction) {
+		return runStatements(ctx, conn, version, direction, script, record)
+	}
+
+	tx, errBegin := conn.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
+	}
+	defer tx.Rollback()
+
+	if _, errExec := tx.ExecContext(ctx, script); errExec != nil {
+		return errExec
+	}
+	if errRecord := record(tx); errRecord != nil {
+		return errRecord
+	}
+	return tx.Commit()
+}
+
+// runStatements runs a no-transaction script from the first statement not
+// recorded as done. The progress row and record commit together at the end.
+func runStatements(ctx context.Context, conn *sql.Conn, version int64, direction string, script string, record func(exec executor) error) error {
+	done := 0
+	errProgress := conn.QueryRowContext(ctx,
+		"SELECT statements FROM schema_migrations_progress WHERE version = $1 AND direction = $2",
+		version, direction).Scan(&done)
+	if errProgress != nil && !errors.Is(errProgress, sql.ErrNoRows) {
+		return errProgress
+	}
+
+	statements := splitStatements(script)
+	for i := done; i < len(statements); i++ {
+		if errInvalid := dropInvalidIndex(ctx, conn, statements[i]); errInvalid != nil {
+			return errInvalid
+		}
+		if _, errExec := conn.ExecContext(ctx, statements[i]); errExec != nil {
+			return fmt.Errorf("statement %d: %w", i+1, errExec)
+		}
+
+		_, errRecord := conn.ExecContext(ctx, `
+			INSERT INTO schema_migrations_progress (version, direction, statements) VALUES ($1, $2, $3)
+			ON CONFLICT (version, direction) DO UPDATE SET statements = EXCLUDED.statements
+		`, version, direction, i+1)
+		if errRecord != nil {
+			return errRecord
+		}
+	}
+
+	tx, errBegin := conn.BeginTx(ctx, nil)
+	if errBegin != nil {
+		return errBegin
+	}
+	defer tx.Rollback()
+
+	if errRecord := record(tx); errRecord != nil {
+		return errRecord
+	}
+	// both directions are cleared, an up after a down starts from the top again
+	if _, errClear := tx.ExecContext(ctx, "DELETE FROM schema_migrations_progress WHERE version = $1", version); errClear != nil {
+		return errClear
+	}
+	return tx.Commit()
+}
+
+// dropInvalidIndex drops the index a CREATE INDEX CONCURRENTLY statement
+// builds when an earlier, failed run left it behind INVALID. IF NOT EXISTS
+// would otherwise skip it and keep the unusable index for good.
+func dropInvalidIndex(ctx context.Context, conn *sql.Conn, statement string) error {
+	match := concurrentIndex.FindStringSubmatch(statement)
+	if match == nil {
+		return nil
+	}
+	name := strings.Trim(match[1], `"`)
+
+	var isInvalid bool
+	errQuery := conn.QueryRowContext(ctx, `
+		SELECT EXISTS (
+		    SELECT 1 FROM pg_index i
+		    JOIN pg_class c ON c.oid = i.indexrelid
+		    WHERE c.relname = $1 AND pg_table_is_visible(c.oid) AND NOT i.indisvalid
+		)
+	`, name).Scan(&isInvalid)
+	if errQuery != nil || !isInvalid {
+		return errQuery
+	}
+
+	logger.Logger.Info("migration-invalid-index-dropped", "index", name)
+	_, errDrop := conn.ExecContext(ctx, "DROP INDEX CONCURRENTLY IF EXISTS "+pq.QuoteIdentifier(name))
+	return errDrop
+}
+
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, current.String())
+			current.Reset()
+		}
+	}
+	if strings.TrimSpace(current.String()) != "" {
+		statements = append(statements, current.String())
+	}
+	return statements
+}
+
+func appliedVersions(applied map[int64]record, descending bool) []int64 {
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	if descending {
+		slices.Reverse(versions)
+	}
+	return versions
+}
+
+// Create writes empty up and down scripts for the next version into dir and
+// returns their paths.
+func Create(dir string, name string) (string, string, error) {
+	if !migrationName.MatchString(name) {
+		return "", "", fmt.Errorf("migration name %q must be lowercase letters, digits and underscores", name)
+	}
+
+	migrations, errLoad := Load(os.DirFS(dir))
+	if errLoad != nil {
+		return "", "", errLoad
+	}
+	next := int64(1)
+	if len(migrations) > 0 {
+		next = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", next, name))
+	upPath, downPath := base+".up.sql", base+".down.sql"
+	if errUp := os.WriteFile(upPath, []byte("-- "+name+"\n"), 0o644); errUp != nil {
+		return "", "", errUp
+	}
+	if errDown := os.WriteFile(downPath, []byte("-- revert "+name+"\n"), 0o644); errDown != nil {
+		return "", "", errDown
+	}
+	return upPath, downPath, nil
+}
+
+func NewMigrator(db *sql.DB, migrations []*Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
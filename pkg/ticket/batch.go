@@ -0,0 +1,196 @@
+package ticket
+
+import (
+	"context"
+	"fmt"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"redifu-example/internal/repository"
+	"redifu-example/pkg/events"
+	"time"
+)
+
+// Batch operations, see BatchOperation.
+const (
+	BatchResolve         = "resolve"
+	BatchDelete          = "delete"
+	BatchSetSecurityRisk = "set_security_risk"
+	BatchSetCategory     = "set_category"
+)
+
+// BatchOperation is one item of a batch. SecurityRisk is read by
+// set_security_risk only and CategoryRandId by set_category only.
+type BatchOperation struct {
+	Op             string `json:"op"`
+	TicketUUID     string `json:"ticket_uuid"`
+	SecurityRisk   *int64 `json:"security_risk,omitempty"`
+	CategoryRandId string `json:"category_rand_id,omitempty"`
+}
+
+func (o BatchOperation) validate() error {
+	if o.TicketUUID == "" {
+		return fmt.Errorf("%w: ticket_uuid is required", definition.InvalidOperation)
+	}
+
+	switch o.Op {
+	case BatchResolve, BatchDelete:
+		return nil
+	case BatchSetSecurityRisk:
+		if o.SecurityRisk == nil {
+			return fmt.Errorf("%w: security_risk is required", definition.InvalidOperation)
+		}
+		return nil
+	case BatchSetCategory:
+		if o.CategoryRandId == "" {
+			return fmt.Errorf("%w: category_rand_id is required", definition.InvalidOperation)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown op %q", definition.InvalidOperation, o.Op)
+}
+
+// BatchResult is the outcome of the operation at the same index, Err is nil
+// when it was applied.
+type BatchResult struct {
+	Op         string
+	TicketUUID string
+	Err        error
+}
+
+// BatchReport lists every result in request order.
+type BatchReport struct {
+	Results   []BatchResult
+	Succeeded int
+}
+
+// batch carries the state shared by the items of one Batch call.
+type batch struct {
+	service      *TicketService
+	callerUUID   string
+	isPrivileged bool
+	categories   map[string]*model.Category
+	pending      []events.Event
+}
+
+// Batch applies operations in one transaction. Every item is authorized the
+// way the single-ticket routes are, a failing item is rolled back alone and
+// reported in its result. Events are emitted once the batch commits.
+func (s *TicketService) Batch(ctx context.Context, callerUUID string, isPrivileged bool, operations []BatchOperation) (*BatchReport, error) {
+	b := &batch{
+		service:      s,
+		callerUUID:   callerUUID,
+		isPrivileged: isPrivileged,
+		categories:   make(map[string]*model.Category),
+	}
+	report := &BatchReport{Results: make([]BatchResult, len(operations))}
+
+	errBatch := s.ticketRepository.Batch(ctx, func(tx *repository.TicketBatch) error {
+		for i, operation := range operations {
+			report.Results[i] = BatchResult{Op: operation.Op, TicketUUID: operation.TicketUUID}
+			if errValidate := operation.validate(); errValidate != nil {
+				report.Results[i].Err = errValidate
+				continue
+			}
+
+			var event events.Event
+			report.Results[i].Err = tx.Item(ctx, func() error {
+				var errApply error
+				event, errApply = b.apply(ctx, tx, operation)
+				return errApply
+			})
+			if report.Results[i].Err == nil && event != nil {
+				b.pending = append(b.pending, event)
+			}
+		}
+		return nil
+	})
+	if errBatch != nil {
+		return nil, errBatch
+	}
+
+	for _, result := range report.Results {
+		if result.Err == nil {
+			report.Succeeded++
+		}
+	}
+
+	for _, event := range b.pending {
+		events.Emit(ctx, s.publisher, event)
+	}
+	return report, nil
+}
+
+// apply writes one operation and returns the event it announces, nil when the
+// ticket was already in the requested state.
+func (b *batch) apply(ctx context.Context, tx *repository.TicketBatch, operation BatchOperation) (events.Event, error) {
+	ticket, errFind := tx.Find(ctx, operation.TicketUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+	if !b.isPrivileged && ticket.AccountUUID != b.callerUUID {
+		return nil, definition.Forbidden
+	}
+
+	switch operation.Op {
+	case BatchResolve:
+		if !CanTransition(ticket.Status, model.StatusResolved) {
+			return nil, definition.InvalidTransition
+		}
+		transition := model.NewTicketTransition(ticket.GetUUID(), ticket.Status, model.StatusResolved, "")
+		ticket.SetStatus(model.StatusResolved)
+		ticket.SetUpdatedAt(transition.GetCreatedAt())
+		if errTransition := tx.Transition(ctx, ticket, transition); errTransition != nil {
+			return nil, errTransition
+		}
+		return events.TicketResolved{Ticket: b.service.eventTicket(ctx, ticket), FromStatus: transition.FromStatus}, nil
+
+	case BatchDelete:
+		if errDelete := tx.Delete(ctx, ticket); errDelete != nil {
+			return nil, errDelete
+		}
+		return events.TicketDeleted{Ticket: b.service.eventTicket(ctx, ticket)}, nil
+
+	case BatchSetSecurityRisk:
+		if ticket.SecurityRisk == *operation.SecurityRisk {
+			return nil, nil
+		}
+		ticket.SetSecurityRisk(*operation.SecurityRisk)
+		ticket.SetUpdatedAt(time.Now().In(time.UTC))
+		if errUpdate := tx.Update(ctx, ticket); errUpdate != nil {
+			return nil, errUpdate
+		}
+		return events.TicketUpdated{Ticket: b.service.eventTicket(ctx, ticket), Changed: []string{"security_risk"}}, nil
+
+	case BatchSetCategory:
+		category, errCategory := b.category(ctx, operation.CategoryRandId)
+		if errCategory != nil {
+			return nil, errCategory
+		}
+		if ticket.CategoryUUID == category.GetUUID() {
+			return nil, nil
+		}
+		ticket.SetCategory(category)
+		ticket.SetUpdatedAt(time.Now().In(time.UTC))
+		if errUpdate := tx.Update(ctx, ticket); errUpdate != nil {
+			return nil, errUpdate
+		}
+		return events.TicketUpdated{Ticket: b.service.eventTicket(ctx, ticket), Changed: []string{"category_uuid"}}, nil
+	}
+
+	return nil, definition.InvalidOperation
+}
+
+// category looks a category up once per batch, recategorizing many tickets
+// usually moves them all into the same one.
+func (b *batch) category(ctx context.Context, randId string) (*model.Category, error) {
+	if category, exists := b.categories[randId]; exists {
+		return category, nil
+	}
+
+	category, errFind := b.service.categoryRepository.FindByRandId(ctx, randId)
+	if errFind != nil {
+		return nil, fmt.Errorf("category %s: %w", randId, errFind)
+	}
+	b.categories[randId] = category
+	return category, nil
+}
@@ -0,0 +1,250 @@
+package ticket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"time"
+)
+
+type ImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportReport counts every row, Errors keeps the first
+// definition.ImportMaxErrors of the failed ones.
+type ImportReport struct {
+	Imported int           `json:"imported"`
+	Failed   int           `json:"failed"`
+	Errors   []ImportError `json:"errors"`
+}
+
+func (r *ImportReport) fail(line int, err error) {
+	r.Failed++
+	if len(r.Errors) < definition.ImportMaxErrors {
+		r.Errors = append(r.Errors, ImportError{Line: line, Error: err.Error()})
+	}
+}
+
+// importer validates rows against accounts and categories a batch at a time,
+// every lookup is remembered for the rest of the import.
+type importer struct {
+	service    *TicketService
+	report     *ImportReport
+	accounts   map[string]bool
+	categories map[string]*model.Category
+}
+
+// ImportTickets reads rows until the end of the body and inserts the valid
+// ones in batches of definition.ImportBatchSize. Imported tickets are history
+// from another tracker, they do not emit ticket.created. A read error stops
+// the import but keeps the batches already stored, the report says how many.
+func (s *TicketService) ImportTickets(ctx context.Context, rows RowReader) (*ImportReport, error) {
+	imp := &importer{
+		service:    s,
+		report:     &ImportReport{Errors: []ImportError{}},
+		accounts:   make(map[string]bool),
+		categories: make(map[string]*model.Category),
+	}
+
+	errRead := imp.run(ctx, rows)
+	return imp.report, errRead
+}
+
+func (imp *importer) run(ctx context.Context, rows RowReader) error {
+	batch := make([]*TransferRow, 0, definition.ImportBatchSize)
+	for {
+		row, errNext := rows.Next()
+		if errors.Is(errNext, io.EOF) {
+			break
+		}
+		var invalid *InvalidRow
+		if errors.As(errNext, &invalid) {
+			imp.report.fail(invalid.Line, invalid.Err)
+			continue
+		}
+		if errNext != nil {
+			imp.store(ctx, batch)
+			return errNext
+		}
+
+		batch = append(batch, row)
+		if len(batch) == definition.ImportBatchSize {
+			imp.store(ctx, batch)
+			batch = batch[:0]
+		}
+	}
+
+	imp.store(ctx, batch)
+	return nil
+}
+
+// store inserts the valid rows of batch in one transaction, a failed insert
+// fails every row of the batch.
+func (imp *importer) store(ctx context.Context, batch []*TransferRow) {
+	if len(batch) == 0 {
+		return
+	}
+
+	if errLookup := imp.lookup(ctx, batch); errLookup != nil {
+		for _, row := range batch {
+			imp.report.fail(row.line, errLookup)
+		}
+		return
+	}
+
+	tickets := make([]*model.Ticket, 0, len(batch))
+	lines := make([]int, 0, len(batch))
+	for _, row := range batch {
+		ticket, errValidate := imp.ticket(row)
+		if errValidate != nil {
+			imp.report.fail(row.line, errValidate)
+			continue
+		}
+		tickets = append(tickets, ticket)
+		lines = append(lines, row.line)
+	}
+	if len(tickets) == 0 {
+		return
+	}
+
+	if errImport := imp.service.ticketRepository.Import(ctx, tickets); errImport != nil {
+		for _, line := range lines {
+			imp.report.fail(line, errImport)
+		}
+		return
+	}
+	imp.report.Imported += len(tickets)
+}
+
+// lookup resolves the accounts and categories of batch that earlier batches
+// have not already resolved.
+func (imp *importer) lookup(ctx context.Context, batch []*TransferRow) error {
+	var accountUUIDs, categoryRandIds []string
+	for _, row := range batch {
+		for _, accountUUID := range []string{row.ReporterUUID, row.AssigneeUUID} {
+			if _, known := imp.accounts[accountUUID]; accountUUID != "" && !known {
+				imp.accounts[accountUUID] = false
+				accountUUIDs = append(accountUUIDs, accountUUID)
+			}
+		}
+		if _, known := imp.categories[row.CategoryRandId]; row.CategoryRandId != "" && !known {
+			imp.categories[row.CategoryRandId] = nil
+			categoryRandIds = append(categoryRandIds, row.CategoryRandId)
+		}
+	}
+
+	if len(accountUUIDs) > 0 {
+		existing, errAccounts := imp.service.accountService.ExistingAccounts(ctx, accountUUIDs)
+		if errAccounts != nil {
+			imp.forget(accountUUIDs, categoryRandIds)
+			return errAccounts
+		}
+		for accountUUID := range existing {
+			imp.accounts[accountUUID] = true
+		}
+	}
+
+	if len(categoryRandIds) > 0 {
+		categories, errCategories := imp.service.categoryRepository.FindByRandIds(ctx, categoryRandIds)
+		if errCategories != nil {
+			imp.forget(accountUUIDs, categoryRandIds)
+			return errCategories
+		}
+		for randId, category := range categories {
+			imp.categories[randId] = category
+		}
+	}
+
+	return nil
+}
+
+// forget drops lookups that failed so a later batch asks again.
+func (imp *importer) forget(accountUUIDs []string, categoryRandIds []string) {
+	for _, accountUUID := range accountUUIDs {
+		delete(imp.accounts, accountUUID)
+	}
+	for _, randId := range categoryRandIds {
+		delete(imp.categories, randId)
+	}
+}
+
+func (imp *importer) ticket(row *TransferRow) (*model.Ticket, error) {
+	if row.Description == "" {
+		return nil, errors.New("description is required")
+	}
+	if row.ReporterUUID == "" {
+		return nil, errors.New("reporter_uuid is required")
+	}
+	if !imp.accounts[row.ReporterUUID] {
+		return nil, fmt.Errorf("reporter %s: %w", row.ReporterUUID, definition.NotFound)
+	}
+	if row.AssigneeUUID != "" && !imp.accounts[row.AssigneeUUID] {
+		return nil, fmt.Errorf("assignee %s: %w", row.AssigneeUUID, definition.NotFound)
+	}
+
+	ticket := model.NewTicket()
+	ticket.SetDescription(row.Description)
+	ticket.SetAccountUUID(row.ReporterUUID)
+	ticket.SetSecurityRisk(row.SecurityRisk)
+	ticket.SetAssigneeUUID(row.AssigneeUUID)
+
+	if row.Status != "" {
+		if !IsValidStatus(row.Status) {
+			return nil, fmt.Errorf("%w: %s", definition.InvalidStatus, row.Status)
+		}
+		ticket.SetStatus(row.Status)
+	}
+
+	if row.CategoryRandId != "" {
+		category := imp.categories[row.CategoryRandId]
+		if category == nil {
+			return nil, fmt.Errorf("category %s: %w", row.CategoryRandId, definition.NotFound)
+		}
+		ticket.SetCategory(category)
+	}
+
+	// keep the original timestamps so the ticket lands where it belongs on the timelines
+	if !row.CreatedAt.IsZero() {
+		createdAt := row.CreatedAt.In(time.UTC)
+		if createdAt.After(time.Now()) {
+			return nil, errors.New("created_at is in the future")
+		}
+		ticket.CreatedAt = createdAt
+		ticket.UpdatedAt = createdAt
+	}
+
+	return ticket, nil
+}
+
+// Export is a filter resolved against the category table, Each streams its
+// tickets newest first without holding more than one row in memory.
+type Export struct {
+	ticketRepository TicketStore
+	conditions       []string
+	args             []interface{}
+}
+
+func (e *Export) Each(ctx context.Context, each func(ticket *model.Ticket) error) error {
+	return e.ticketRepository.Export(ctx, e.conditions, e.args, each)
+}
+
+// PrepareExport resolves filter up front so an unknown category fails before
+// the response starts streaming.
+func (s *TicketService) PrepareExport(ctx context.Context, filter *Filter) (*Export, error) {
+	var categoryUUID string
+	if filter.CategoryRandId != "" {
+		category, errFind := s.categoryRepository.FindByRandId(ctx, filter.CategoryRandId)
+		if errFind != nil {
+			return nil, errFind
+		}
+		categoryUUID = category.GetUUID()
+	}
+
+	conditions, args := filter.Conditions(categoryUUID)
+	return &Export{ticketRepository: s.ticketRepository, conditions: conditions, args: args}, nil
+}
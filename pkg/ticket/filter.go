@@ -0,0 +1,305 @@
+package ticket
+
+import (
+	"fmt"
+	"github.com/21strive/redifu"
+	"math"
+	"net/url"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"redifu-example/internal/repository"
+	"strconv"
+	"time"
+)
+
+// unresolvedTickets is the ticket table narrowed to tickets still being worked
+// on. Where only compares a column against a placeholder, so the NOT IN goes
+// into the source of the query, Postgres flattens it into the outer scan.
+var unresolvedTickets = fmt.Sprintf("(SELECT * FROM ticket WHERE status NOT IN ('%s', '%s'))", model.StatusResolved, model.StatusClosed)
+
+// Filter is a combination of ticket list criteria, every criterion that is
+// set becomes one Where clause. Tickets are always listed newest first.
+// CreatedAfter and CreatedBefore are whole UTC days, both inclusive, so the
+// cached date windows stay a small set.
+type Filter struct {
+	Status         string
+	Unresolved     bool
+	CategoryRandId string
+	ReporterUUID   string
+	AssigneeUUID   string
+	MinRisk        *int64
+	MaxRisk        *int64
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+}
+
+// ParseFilter reads the filter criteria out of the list query parameters,
+// parameters that are not criteria (lastRandId, page, ...) are ignored.
+func ParseFilter(values url.Values) (*Filter, error) {
+	filter := &Filter{
+		Status:         values.Get("status"),
+		CategoryRandId: values.Get("categoryRandId"),
+		ReporterUUID:   values.Get("reporterUUID"),
+		AssigneeUUID:   values.Get("assigneeUUID"),
+	}
+
+	if filter.Status != "" && !IsValidStatus(filter.Status) {
+		return nil, definition.InvalidStatus
+	}
+
+	if unresolved := values.Get("unresolved"); unresolved != "" {
+		parsed, errParse := strconv.ParseBool(unresolved)
+		if errParse != nil {
+			return nil, fmt.Errorf("incorrect unresolved value-type: %w", errParse)
+		}
+		filter.Unresolved = parsed
+	}
+
+	for name, target := range map[string]**int64{"minRisk": &filter.MinRisk, "maxRisk": &filter.MaxRisk} {
+		if raw := values.Get(name); raw != "" {
+			parsed, errParse := strconv.ParseInt(raw, 10, 64)
+			if errParse != nil {
+				return nil, fmt.Errorf("incorrect %s value-type: %w", name, errParse)
+			}
+			*target = &parsed
+		}
+	}
+
+	for name, target := range map[string]*time.Time{"createdAfter": &filter.CreatedAfter, "createdBefore": &filter.CreatedBefore} {
+		if raw := values.Get(name); raw != "" {
+			parsed, errParse := parseDay(raw)
+			if errParse != nil {
+				return nil, fmt.Errorf("incorrect %s value-type: %w", name, errParse)
+			}
+			*target = parsed
+		}
+	}
+
+	return filter, nil
+}
+
+// parseDay reads a date or an RFC 3339 timestamp and keeps the UTC day it
+// falls on.
+func parseDay(raw string) (time.Time, error) {
+	parsed, errParse := time.Parse(time.DateOnly, raw)
+	if errParse != nil {
+		var errTimestamp error
+		parsed, errTimestamp = time.Parse(time.RFC3339, raw)
+		if errTimestamp != nil {
+			return time.Time{}, errTimestamp
+		}
+	}
+
+	return parsed.UTC().Truncate(24 * time.Hour), nil
+}
+
+// DecodeFilter is the inverse of Key, the seeder uses it to rebuild a filter
+// from the timeline it was asked to fill.
+func DecodeFilter(key string) (*Filter, error) {
+	values, errParse := url.ParseQuery(key)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	return ParseFilter(values)
+}
+
+// FilterDecoder adapts DecodeFilter for the repository, which maintains the
+// cached filter timelines on every write.
+func FilterDecoder(key string) (repository.TicketFilter, error) {
+	filter, errDecode := DecodeFilter(key)
+	if errDecode != nil {
+		return nil, errDecode
+	}
+
+	return filter, nil
+}
+
+// Key is the canonical encoding of the criteria, url.Values sorts by name so
+// the same combination always lands on the same timeline.
+func (f *Filter) Key() string {
+	return f.values().Encode()
+}
+
+// IsCombined reports whether the list request needs a filter timeline, a lone
+// status is already served by the status timeline.
+func (f *Filter) IsCombined() bool {
+	count := len(f.values())
+	return count > 1 || (count == 1 && f.Status == "")
+}
+
+func (f *Filter) values() url.Values {
+	values := url.Values{}
+	if f.Status != "" {
+		values.Set("status", f.Status)
+	}
+	if f.Unresolved {
+		values.Set("unresolved", "true")
+	}
+	if f.CategoryRandId != "" {
+		values.Set("categoryRandId", f.CategoryRandId)
+	}
+	if f.ReporterUUID != "" {
+		values.Set("reporterUUID", f.ReporterUUID)
+	}
+	if f.AssigneeUUID != "" {
+		values.Set("assigneeUUID", f.AssigneeUUID)
+	}
+	if f.MinRisk != nil {
+		values.Set("minRisk", strconv.FormatInt(*f.MinRisk, 10))
+	}
+	if f.MaxRisk != nil {
+		values.Set("maxRisk", strconv.FormatInt(*f.MaxRisk, 10))
+	}
+	if !f.CreatedAfter.IsZero() {
+		values.Set("createdAfter", f.CreatedAfter.Format(time.DateOnly))
+	}
+	if !f.CreatedBefore.IsZero() {
+		values.Set("createdBefore", f.CreatedBefore.Format(time.DateOnly))
+	}
+
+	return values
+}
+
+// Query builds the seeding query, categoryUUID is the resolved form of
+// CategoryRandId since the ticket table only holds the uuid.
+func (f *Filter) Query(categoryUUID string) (*redifu.Query, []interface{}) {
+	source := "ticket"
+	if f.Unresolved {
+		source = unresolvedTickets
+	}
+
+	query := redifu.NewQuery(source, "t").
+		Select("t.*, a.*, c.*").
+		LeftJoin("account", "a", "t.account_uuid = a.uuid").
+		LeftJoin("category", "c", "t.category_uuid = c.uuid")
+	var args []interface{}
+
+	if f.Status != "" {
+		query = query.Where("t.status", redifu.Equal)
+		args = append(args, f.Status)
+	}
+	if f.CategoryRandId != "" {
+		query = query.Where("t.category_uuid", redifu.Equal)
+		args = append(args, categoryUUID)
+	}
+	if f.ReporterUUID != "" {
+		query = query.Where("t.account_uuid", redifu.Equal)
+		args = append(args, f.ReporterUUID)
+	}
+	if f.AssigneeUUID != "" {
+		query = query.Where("t.assignee_uuid", redifu.Equal)
+		args = append(args, f.AssigneeUUID)
+	}
+	if f.MinRisk != nil || f.MaxRisk != nil {
+		minRisk, maxRisk := f.riskBounds()
+		query = query.Where("t.security_risk", redifu.Between)
+		args = append(args, minRisk, maxRisk)
+	}
+	if !f.CreatedAfter.IsZero() || !f.CreatedBefore.IsZero() {
+		createdAfter, createdBefore := f.createdBounds()
+		query = query.Where("t.created_at", redifu.Between)
+		args = append(args, createdAfter, createdBefore)
+	}
+
+	query = query.OrderBy("t.created_at", redifu.Descending)
+	return query, args
+}
+
+// Conditions mirrors Query as plain SQL for the export, which reads Postgres
+// directly instead of a seeded timeline. Placeholders are numbered in order.
+func (f *Filter) Conditions(categoryUUID string) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = len(args)
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if f.Status != "" {
+		add("t.status = $%d", f.Status)
+	}
+	if f.Unresolved {
+		add("t.status NOT IN ($%d, $%d)", model.StatusResolved, model.StatusClosed)
+	}
+	if f.CategoryRandId != "" {
+		add("t.category_uuid = $%d", categoryUUID)
+	}
+	if f.ReporterUUID != "" {
+		add("t.account_uuid = $%d", f.ReporterUUID)
+	}
+	if f.AssigneeUUID != "" {
+		add("t.assignee_uuid = $%d", f.AssigneeUUID)
+	}
+	if f.MinRisk != nil || f.MaxRisk != nil {
+		minRisk, maxRisk := f.riskBounds()
+		add("t.security_risk BETWEEN $%d AND $%d", minRisk, maxRisk)
+	}
+	if !f.CreatedAfter.IsZero() || !f.CreatedBefore.IsZero() {
+		createdAfter, createdBefore := f.createdBounds()
+		add("t.created_at BETWEEN $%d AND $%d", createdAfter, createdBefore)
+	}
+
+	return conditions, args
+}
+
+// Matches mirrors Query in Go so writes can tell which cached filter
+// timelines a ticket enters or leaves.
+func (f *Filter) Matches(ticket *model.Ticket, categoryRandId string) bool {
+	if f.Status != "" && ticket.Status != f.Status {
+		return false
+	}
+	if f.Unresolved && (ticket.Status == model.StatusResolved || ticket.Status == model.StatusClosed) {
+		return false
+	}
+	if f.CategoryRandId != "" && categoryRandId != f.CategoryRandId {
+		return false
+	}
+	if f.ReporterUUID != "" && ticket.AccountUUID != f.ReporterUUID {
+		return false
+	}
+	if f.AssigneeUUID != "" && ticket.AssigneeUUID != f.AssigneeUUID {
+		return false
+	}
+
+	minRisk, maxRisk := f.riskBounds()
+	if ticket.SecurityRisk < minRisk || ticket.SecurityRisk > maxRisk {
+		return false
+	}
+
+	createdAfter, createdBefore := f.createdBounds()
+	createdAt := ticket.GetCreatedAt()
+	if createdAt.Before(createdAfter) || createdAt.After(createdBefore) {
+		return false
+	}
+
+	return true
+}
+
+// riskBounds and createdBounds fill an open side of a range so it can still
+// be expressed as a single BETWEEN, CreatedBefore reaches the end of its day.
+func (f *Filter) riskBounds() (int64, int64) {
+	minRisk, maxRisk := int64(math.MinInt64), int64(math.MaxInt64)
+	if f.MinRisk != nil {
+		minRisk = *f.MinRisk
+	}
+	if f.MaxRisk != nil {
+		maxRisk = *f.MaxRisk
+	}
+	return minRisk, maxRisk
+}
+
+func (f *Filter) createdBounds() (time.Time, time.Time) {
+	createdAfter, createdBefore := time.Unix(0, 0).UTC(), time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	if !f.CreatedAfter.IsZero() {
+		createdAfter = f.CreatedAfter
+	}
+	if !f.CreatedBefore.IsZero() {
+		createdBefore = f.CreatedBefore.Add(24*time.Hour - time.Microsecond)
+	}
+	return createdAfter, createdBefore
+}
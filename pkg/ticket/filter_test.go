@@ -0,0 +1,60 @@
+package ticket
+
+import (
+	"github.com/21strive/redifu"
+	"net/url"
+	"redifu-example/internal/model"
+	"testing"
+	"time"
+)
+
+func TestFilterKeyIsPerDay(t *testing.T) {
+	morning, errMorning := ParseFilter(url.Values{"createdAfter": {"2026-03-01T08:15:00Z"}, "status": {model.StatusOpen}})
+	evening, errEvening := ParseFilter(url.Values{"createdAfter": {"2026-03-01T21:40:00+01:00"}, "status": {model.StatusOpen}})
+	date, errDate := ParseFilter(url.Values{"createdAfter": {"2026-03-01"}, "status": {model.StatusOpen}})
+	if errMorning != nil || errEvening != nil || errDate != nil {
+		t.Fatal(errMorning, errEvening, errDate)
+	}
+
+	if morning.Key() != evening.Key() || morning.Key() != date.Key() {
+		t.Errorf("keys %q, %q and %q differ for the same day", morning.Key(), evening.Key(), date.Key())
+	}
+
+	decoded, errDecode := DecodeFilter(morning.Key())
+	if errDecode != nil {
+		t.Fatal(errDecode)
+	}
+	if decoded.Key() != morning.Key() {
+		t.Errorf("decoded key %q, want %q", decoded.Key(), morning.Key())
+	}
+}
+
+func TestFilterMatchesWholeDays(t *testing.T) {
+	filter, errParse := ParseFilter(url.Values{
+		"createdAfter":  {"2026-03-01"},
+		"createdBefore": {"2026-03-02T06:00:00Z"},
+		"unresolved":    {"true"},
+	})
+	if errParse != nil {
+		t.Fatal(errParse)
+	}
+
+	tests := []struct {
+		createdAt time.Time
+		status    string
+		want      bool
+	}{
+		{time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), model.StatusOpen, true},
+		{time.Date(2026, 3, 2, 23, 59, 59, 0, time.UTC), model.StatusTriaged, true},
+		{time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), model.StatusOpen, false},
+		{time.Date(2026, 2, 28, 23, 59, 59, 0, time.UTC), model.StatusOpen, false},
+		{time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), model.StatusResolved, false},
+		{time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), model.StatusClosed, false},
+	}
+	for _, test := range tests {
+		ticket := &model.Ticket{Record: &redifu.Record{CreatedAt: test.createdAt}, Status: test.status}
+		if got := filter.Matches(ticket, ""); got != test.want {
+			t.Errorf("%s %s matches %t, want %t", test.status, test.createdAt, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package ticket
+
+import "redifu-example/internal/model"
+
+// transitions lists, for every lifecycle status, the statuses a ticket may move to next.
+var transitions = map[string][]string{
+	model.StatusOpen:             {model.StatusTriaged, model.StatusInProgress, model.StatusResolved, model.StatusClosed},
+	model.StatusTriaged:          {model.StatusInProgress, model.StatusAwaitingReporter, model.StatusResolved, model.StatusClosed},
+	model.StatusInProgress:       {model.StatusAwaitingReporter, model.StatusResolved, model.StatusClosed},
+	model.StatusAwaitingReporter: {model.StatusInProgress, model.StatusResolved, model.StatusClosed},
+	model.StatusResolved:         {model.StatusClosed, model.StatusReopened},
+	model.StatusClosed:           {model.StatusReopened},
+	model.StatusReopened:         {model.StatusTriaged, model.StatusInProgress, model.StatusAwaitingReporter, model.StatusResolved, model.StatusClosed},
+}
+
+func IsValidStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+func CanTransition(from string, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
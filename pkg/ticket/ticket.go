@@ -9,17 +9,44 @@ import (
 	"redifu-example/internal/model"
 	"redifu-example/internal/repository"
 	"redifu-example/pkg/account"
+	"redifu-example/pkg/events"
+	"strings"
 	"time"
 )
 
+// TicketStore is the ticket persistence TicketService works through,
+// implemented by repository.TicketRepository.
+type TicketStore interface {
+	Create(ctx context.Context, ticket *model.Ticket) error
+	Update(ctx context.Context, ticket *model.Ticket) error
+	Transition(ctx context.Context, ticket *model.Ticket, transition *model.TicketTransition) error
+	Delete(ctx context.Context, ticket *model.Ticket) error
+	FindByUUID(ctx context.Context, uuid string) (*model.Ticket, error)
+	Batch(ctx context.Context, apply func(batch *repository.TicketBatch) error) error
+	Import(ctx context.Context, tickets []*model.Ticket) error
+	Export(ctx context.Context, conditions []string, args []interface{}, each func(ticket *model.Ticket) error) error
+	SeedTicket(ctx context.Context, randId string) error
+	SeedTickets(ctx context.Context, subtraction int64, lastRandId string) error
+	SeedByCategory(ctx context.Context, subtraction int64, lastRandId string, categoryRandId string, categoryUUID string) error
+	SeedByStatus(ctx context.Context, subtraction int64, lastRandId string, status string) error
+	SeedTicketsBySecurityRisk(ctx context.Context, subtraction int64, lastRandId string) error
+	SeedByAccount(ctx context.Context, reporterUUID string) error
+	SeedByAssignee(ctx context.Context, assigneeUUID string) error
+	SeedPage(ctx context.Context, page int64) error
+	SeedByDate(ctx context.Context, lowerbound time.Time, upperbound time.Time) error
+	SeedByFilter(ctx context.Context, subtraction int64, lastRandId string, key string, query *redifu.Query, args []interface{}) error
+	SeedSearch(ctx context.Context, query string, page int64) error
+}
+
 type TicketService struct {
-	ticketRepository   *repository.TicketRepository
+	ticketRepository   TicketStore
 	categoryRepository *repository.CategoryRepository
 	ticketFetcher      *fetcher.TicketFetcher
 	accountService     *account.AccountService
+	publisher          events.Publisher
 }
 
-func (s *TicketService) InitRepository(ticketRepository *repository.TicketRepository, categoryRepository *repository.CategoryRepository, accountService *account.AccountService) {
+func (s *TicketService) InitRepository(ticketRepository TicketStore, categoryRepository *repository.CategoryRepository, accountService *account.AccountService) {
 	s.ticketRepository = ticketRepository
 	s.categoryRepository = categoryRepository
 	s.accountService = accountService
@@ -29,13 +56,54 @@ func (s *TicketService) InitFetcher(ticketFetcher *fetcher.TicketFetcher) {
 	s.ticketFetcher = ticketFetcher
 }
 
-func (s *TicketService) Create(ctx context.Context, description string, accountUUID string, securityRisk int64) error {
+func (s *TicketService) InitPublisher(publisher events.Publisher) {
+	s.publisher = publisher
+}
+
+// eventTicket builds the event payload, tickets loaded from the table only
+// carry the category uuid so its randid is looked up here.
+func (s *TicketService) eventTicket(ctx context.Context, ticket *model.Ticket) events.Ticket {
+	if ticket.CategoryUUID != "" && ticket.CategoryRandId == "" {
+		category, errFind := s.categoryRepository.FindByUUID(ctx, ticket.CategoryUUID)
+		if errFind == nil {
+			ticket.CategoryRandId = category.GetRandId()
+		}
+	}
+	return events.NewTicket(ticket)
+}
+
+// update persists a mutable-field change and announces which fields moved.
+func (s *TicketService) update(ctx context.Context, ticket *model.Ticket, changed ...string) error {
+	errUpdate := s.ticketRepository.Update(ctx, ticket)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	events.Emit(ctx, s.publisher, events.TicketUpdated{Ticket: s.eventTicket(ctx, ticket), Changed: changed})
+	return nil
+}
+
+func (s *TicketService) Create(ctx context.Context, description string, accountUUID string, securityRisk int64, categoryRandId string) error {
 	ticket := model.NewTicket()
 	ticket.SetDescription(description)
 	ticket.SetAccountUUID(accountUUID)
 	ticket.SetSecurityRisk(securityRisk)
 
-	return s.ticketRepository.Create(ctx, ticket)
+	if categoryRandId != "" {
+		category, errFind := s.categoryRepository.FindByRandId(ctx, categoryRandId)
+		if errFind != nil {
+			return errFind
+		}
+		ticket.SetCategory(category)
+	}
+
+	errCreate := s.ticketRepository.Create(ctx, ticket)
+	if errCreate != nil {
+		return errCreate
+	}
+
+	events.Emit(ctx, s.publisher, events.TicketCreated{Ticket: s.eventTicket(ctx, ticket)})
+	return nil
 }
 
 func (s *TicketService) Find(ctx context.Context, ticketUUID string) (*model.Ticket, error) {
@@ -54,7 +122,52 @@ func (s *TicketService) UpdateDescription(ctx context.Context, ticketUUID string
 	}
 
 	ticket.SetDescription(description)
-	return s.ticketRepository.Update(ctx, ticket)
+	ticket.SetUpdatedAt(time.Now().In(time.UTC))
+	return s.update(ctx, ticket, "description")
+}
+
+func (s *TicketService) UpdateSecurityRisk(ctx context.Context, ticketUUID string, securityRisk int64) error {
+	ticket, errFind := s.Find(ctx, ticketUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	ticket.SetSecurityRisk(securityRisk)
+	ticket.SetUpdatedAt(time.Now().In(time.UTC))
+	return s.update(ctx, ticket, "security_risk")
+}
+
+func (s *TicketService) Assign(ctx context.Context, ticketUUID string, assigneeUUID string) error {
+	ticket, errFind := s.Find(ctx, ticketUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	assignee, errFindAssignee := s.accountService.Find(assigneeUUID)
+	if errFindAssignee != nil {
+		return errFindAssignee
+	}
+	if ticket.AssigneeUUID == assignee.GetUUID() {
+		return nil
+	}
+
+	ticket.SetAssigneeUUID(assignee.GetUUID())
+	ticket.SetUpdatedAt(time.Now().In(time.UTC))
+	return s.update(ctx, ticket, "assignee_uuid")
+}
+
+func (s *TicketService) Unassign(ctx context.Context, ticketUUID string) error {
+	ticket, errFind := s.Find(ctx, ticketUUID)
+	if errFind != nil {
+		return errFind
+	}
+	if ticket.AssigneeUUID == "" {
+		return nil
+	}
+
+	ticket.SetAssigneeUUID("")
+	ticket.SetUpdatedAt(time.Now().In(time.UTC))
+	return s.update(ctx, ticket, "assignee_uuid")
 }
 
 func (s *TicketService) Delete(ctx context.Context, ticketUUID string) error {
@@ -63,17 +176,79 @@ func (s *TicketService) Delete(ctx context.Context, ticketUUID string) error {
 		return errFind
 	}
 
-	return s.ticketRepository.Delete(ctx, ticket)
+	errDelete := s.ticketRepository.Delete(ctx, ticket)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	events.Emit(ctx, s.publisher, events.TicketDeleted{Ticket: s.eventTicket(ctx, ticket)})
+	return nil
+}
+
+// Authorize allows changes to a ticket by its reporter or an admin only.
+func (s *TicketService) Authorize(ctx context.Context, ticketUUID string, callerUUID string, isAdmin bool) error {
+	ticket, errFind := s.Find(ctx, ticketUUID)
+	if errFind != nil {
+		return errFind
+	}
+	if !isAdmin && ticket.AccountUUID != callerUUID {
+		return definition.Forbidden
+	}
+	return nil
 }
 
 func (s *TicketService) ResolveTicket(ctx context.Context, ticketUUID string) error {
+	return s.Transition(ctx, ticketUUID, model.StatusResolved, "")
+}
+
+func (s *TicketService) Transition(ctx context.Context, ticketUUID string, status string, note string) error {
+	if !IsValidStatus(status) {
+		return definition.InvalidStatus
+	}
+
 	ticket, errFind := s.Find(ctx, ticketUUID)
 	if errFind != nil {
 		return errFind
 	}
+	// the status read here may already be stale, the repository only applies
+	// the transition while the row still holds it
+	if !CanTransition(ticket.Status, status) {
+		return definition.InvalidTransition
+	}
 
-	ticket.SetResolved()
-	return s.ticketRepository.Update(ctx, ticket)
+	transition := model.NewTicketTransition(ticket.GetUUID(), ticket.Status, status, note)
+	ticket.SetStatus(status)
+	ticket.SetUpdatedAt(transition.GetCreatedAt())
+	errTransition := s.ticketRepository.Transition(ctx, ticket, transition)
+	if errTransition != nil {
+		return errTransition
+	}
+
+	if status == model.StatusResolved {
+		events.Emit(ctx, s.publisher, events.TicketResolved{Ticket: s.eventTicket(ctx, ticket), FromStatus: transition.FromStatus, Note: note})
+	} else {
+		events.Emit(ctx, s.publisher, events.TicketUpdated{Ticket: s.eventTicket(ctx, ticket), Changed: []string{"status"}})
+	}
+	return nil
+}
+
+func (s *TicketService) SetCategory(ctx context.Context, ticketUUID string, categoryRandId string) error {
+	ticket, errFind := s.Find(ctx, ticketUUID)
+	if errFind != nil {
+		return errFind
+	}
+
+	category, errFindCategory := s.categoryRepository.FindByRandId(ctx, categoryRandId)
+	if errFindCategory != nil {
+		return errFindCategory
+	}
+	if ticket.CategoryUUID == category.GetUUID() {
+		return nil
+	}
+
+	ticket.SetCategory(category)
+	ticket.SetUpdatedAt(time.Now().In(time.UTC))
+	return s.update(ctx, ticket, "category_uuid")
 }
 
 func (s *TicketService) GetTicket(ctx context.Context, randid string) (*model.Ticket, *model.Account, bool, error) {
@@ -116,15 +291,14 @@ func (s *TicketService) GetTickets(ctx context.Context, lastRandId []string) ([]
 	if totalReceivedItems < definition.ItemPerPage {
 		seedRequired, errCheck := s.ticketFetcher.IsTimelineSeedingRequired(ctx, totalReceivedItems)
 		if errCheck != nil {
-			return nil, fetchRes.ValidLastId(), fetchRes.Position(), false, fetchRes.Error()
+			return nil, fetchRes.ValidLastId(), fetchRes.Position(), false, errCheck
 		}
-
 		if seedRequired {
-			return nil, fetchRes.ValidLastId(), fetchRes.Position(), true, fetchRes.Error()
+			return tickets, fetchRes.ValidLastId(), fetchRes.Position(), true, nil
 		}
 	}
 
-	return tickets, fetchRes.ValidLastId(), fetchRes.Position(), false, fetchRes.Error()
+	return tickets, fetchRes.ValidLastId(), fetchRes.Position(), false, nil
 }
 
 func (s *TicketService) GetTicketsByReporter(ctx context.Context, reporterUUID string) ([]*model.Ticket, bool, error) {
@@ -138,7 +312,25 @@ func (s *TicketService) GetTicketsByReporter(ctx context.Context, reporterUUID s
 			return nil, false, errCheck
 		}
 		if isSeedRequired {
-			return nil, true, nil
+			return tickets, true, nil
+		}
+	}
+
+	return tickets, false, nil
+}
+
+func (s *TicketService) GetTicketsByAssignee(ctx context.Context, assigneeUUID string) ([]*model.Ticket, bool, error) {
+	tickets, errFetch := s.ticketFetcher.FetchSortedByAssignee(ctx, assigneeUUID)
+	if errFetch != nil {
+		return nil, false, errFetch
+	}
+	if len(tickets) == 0 {
+		isSeedRequired, errCheck := s.ticketFetcher.IsSortedByAssigneeSeedingRequired(ctx, assigneeUUID)
+		if errCheck != nil {
+			return nil, false, errCheck
+		}
+		if isSeedRequired {
+			return tickets, true, nil
 		}
 	}
 
@@ -170,6 +362,57 @@ func (s *TicketService) GetTicketsByCategory(ctx context.Context, categoryRandId
 	return tickets, fetchRes.ValidLastId(), fetchRes.Position(), false, nil
 }
 
+func (s *TicketService) GetTicketsByStatus(ctx context.Context, status string, lastRandId []string) ([]*model.Ticket, string, string, bool, error) {
+	fetchRes := s.ticketFetcher.FetchTimelineByStatus(ctx, status, lastRandId)
+	if fetchRes.Error() != nil {
+		requiresSeed := false
+		if errors.Is(fetchRes.Error(), redifu.ResetPagination) {
+			requiresSeed = true
+		}
+		return nil, fetchRes.ValidLastId(), fetchRes.Position(), requiresSeed, fetchRes.Error()
+	}
+
+	tickets := fetchRes.Items()
+	totalReceivedItems := int64(len(tickets))
+	if totalReceivedItems < definition.ItemPerPage {
+		seedRequired, errCheck := s.ticketFetcher.IsTimelineByStatusSeedingRequired(ctx, status, totalReceivedItems)
+		if errCheck != nil {
+			return nil, fetchRes.ValidLastId(), fetchRes.Position(), false, errCheck
+		}
+		if seedRequired {
+			return tickets, fetchRes.ValidLastId(), fetchRes.Position(), true, nil
+		}
+	}
+
+	return tickets, fetchRes.ValidLastId(), fetchRes.Position(), false, nil
+}
+
+func (s *TicketService) GetTicketsByFilter(ctx context.Context, filter *Filter, lastRandId []string) ([]*model.Ticket, string, string, bool, error) {
+	filterKey := filter.Key()
+	fetchRes := s.ticketFetcher.FetchTimelineByFilter(ctx, filterKey, lastRandId)
+	if fetchRes.Error() != nil {
+		requiresSeed := false
+		if errors.Is(fetchRes.Error(), redifu.ResetPagination) {
+			requiresSeed = true
+		}
+		return nil, fetchRes.ValidLastId(), fetchRes.Position(), requiresSeed, fetchRes.Error()
+	}
+
+	tickets := fetchRes.Items()
+	totalReceivedItems := int64(len(tickets))
+	if totalReceivedItems < definition.ItemPerPage {
+		seedRequired, errCheck := s.ticketFetcher.IsTimelineByFilterSeedingRequired(ctx, filterKey, totalReceivedItems)
+		if errCheck != nil {
+			return nil, fetchRes.ValidLastId(), fetchRes.Position(), false, errCheck
+		}
+		if seedRequired {
+			return tickets, fetchRes.ValidLastId(), fetchRes.Position(), true, nil
+		}
+	}
+
+	return tickets, fetchRes.ValidLastId(), fetchRes.Position(), false, nil
+}
+
 func (s *TicketService) GetTicketsBySecurityRisk(ctx context.Context, lastRandId []string) ([]*model.Ticket, string, string, bool, error) {
 	fetchRes := s.ticketFetcher.FetchTimelineBySecurityRisk(ctx, lastRandId)
 	if fetchRes.Error() != nil {
@@ -201,8 +444,10 @@ func (s *TicketService) GetTicketsByPage(ctx context.Context, page int64) ([]*mo
 		return nil, false, errFetch
 	}
 
+	// a short page is either the last one or partly cached, the latter is
+	// served as it is while stale-while-revalidate reseeds it
 	totalReceivedItems := int64(len(tickets))
-	if totalReceivedItems == 0 {
+	if totalReceivedItems < definition.ItemPerPage {
 		seedRequired, errCheck := s.ticketFetcher.IsTicketPageSeedRequired(ctx, page)
 		if errCheck != nil {
 			return nil, false, errCheck
@@ -218,6 +463,16 @@ func (s *TicketService) GetTicketsByDate(ctx context.Context, lowerbound time.Ti
 	return s.ticketFetcher.FetchByRange(ctx, lowerbound, upperbound)
 }
 
+// NormalizeSearchQuery folds case and whitespace so equivalent searches share
+// one cache entry.
+func NormalizeSearchQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+func (s *TicketService) SearchTickets(ctx context.Context, query string, page int64) ([]*model.Ticket, bool, error) {
+	return s.ticketFetcher.FetchSearch(ctx, NormalizeSearchQuery(query), page)
+}
+
 func (s *TicketService) SeedTicket(ctx context.Context, randId string) error {
 	errSeedTicket := s.ticketRepository.SeedTicket(ctx, randId)
 	if errSeedTicket != nil {
@@ -246,6 +501,10 @@ func (s *TicketService) SeedTicketsByAccount(ctx context.Context, reporterUUID s
 	return s.ticketRepository.SeedByAccount(ctx, reporterUUID)
 }
 
+func (s *TicketService) SeedTicketsByAssignee(ctx context.Context, assigneeUUID string) error {
+	return s.ticketRepository.SeedByAssignee(ctx, assigneeUUID)
+}
+
 func (s *TicketService) SeedTicketsByCategory(ctx context.Context, subtraction int64, lastRandId string, categoryRandId string) error {
 	category, errFind := s.categoryRepository.FindByRandId(ctx, categoryRandId)
 	if errFind != nil {
@@ -255,6 +514,29 @@ func (s *TicketService) SeedTicketsByCategory(ctx context.Context, subtraction i
 	return s.ticketRepository.SeedByCategory(ctx, subtraction, lastRandId, categoryRandId, category.GetUUID())
 }
 
+func (s *TicketService) SeedTicketsByStatus(ctx context.Context, subtraction int64, lastRandId string, status string) error {
+	return s.ticketRepository.SeedByStatus(ctx, subtraction, lastRandId, status)
+}
+
+func (s *TicketService) SeedTicketsByFilter(ctx context.Context, subtraction int64, lastRandId string, filterKey string) error {
+	filter, errDecode := DecodeFilter(filterKey)
+	if errDecode != nil {
+		return errDecode
+	}
+
+	var categoryUUID string
+	if filter.CategoryRandId != "" {
+		category, errFind := s.categoryRepository.FindByRandId(ctx, filter.CategoryRandId)
+		if errFind != nil {
+			return errFind
+		}
+		categoryUUID = category.GetUUID()
+	}
+
+	query, args := filter.Query(categoryUUID)
+	return s.ticketRepository.SeedByFilter(ctx, subtraction, lastRandId, filter.Key(), query, args)
+}
+
 func (s *TicketService) SeedTicketsBySecurityRisk(ctx context.Context, subtraction int64, lastRandId string) error {
 	return s.ticketRepository.SeedTicketsBySecurityRisk(ctx, subtraction, lastRandId)
 }
@@ -267,6 +549,10 @@ func (s *TicketService) SeedTicketsByDate(ctx context.Context, lowerbound time.T
 	return s.ticketRepository.SeedByDate(ctx, lowerbound, upperbound)
 }
 
+func (s *TicketService) SeedTicketSearch(ctx context.Context, query string, page int64) error {
+	return s.ticketRepository.SeedSearch(ctx, NormalizeSearchQuery(query), page)
+}
+
 func NewTicketService() *TicketService {
-	return &TicketService{}
+	return &TicketService{publisher: events.NewNoopPublisher()}
 }
@@ -0,0 +1,97 @@
+package ticket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"redifu-example/pkg/events"
+	"testing"
+)
+
+// memoryStore keeps tickets in a map, only the writes the events follow are
+// implemented.
+type memoryStore struct {
+	TicketStore
+	tickets map[string]*model.Ticket
+}
+
+func (m *memoryStore) Create(ctx context.Context, ticket *model.Ticket) error {
+	if ticket.GetUUID() == "" {
+		ticket.UUID = fmt.Sprintf("ticket-%d", len(m.tickets)+1)
+	}
+	stored := *ticket
+	m.tickets[ticket.GetUUID()] = &stored
+	return nil
+}
+
+func (m *memoryStore) FindByUUID(ctx context.Context, uuid string) (*model.Ticket, error) {
+	stored, exists := m.tickets[uuid]
+	if !exists {
+		return nil, definition.NotFound
+	}
+	found := *stored
+	return &found, nil
+}
+
+func (m *memoryStore) Transition(ctx context.Context, ticket *model.Ticket, transition *model.TicketTransition) error {
+	stored := *ticket
+	m.tickets[ticket.GetUUID()] = &stored
+	return nil
+}
+
+func (m *memoryStore) Delete(ctx context.Context, ticket *model.Ticket) error {
+	delete(m.tickets, ticket.GetUUID())
+	return nil
+}
+
+func TestTicketLifecycleEvents(t *testing.T) {
+	store := &memoryStore{tickets: map[string]*model.Ticket{}}
+	bus := events.NewMemoryBus()
+	service := NewTicketService()
+	service.InitRepository(store, nil, nil)
+	service.InitPublisher(bus)
+
+	ctx := context.Background()
+	if errCreate := service.Create(ctx, "login page returns 500", "reporter", 7, ""); errCreate != nil {
+		t.Fatal(errCreate)
+	}
+	if len(store.tickets) != 1 {
+		t.Fatalf("stored %d tickets, want 1", len(store.tickets))
+	}
+	var ticketUUID string
+	for uuid := range store.tickets {
+		ticketUUID = uuid
+	}
+	if errResolve := service.ResolveTicket(ctx, ticketUUID); errResolve != nil {
+		t.Fatal(errResolve)
+	}
+	if errDelete := service.Delete(ctx, ticketUUID); errDelete != nil {
+		t.Fatal(errDelete)
+	}
+
+	published := bus.Published()
+	want := []string{events.TypeTicketCreated, events.TypeTicketResolved, events.TypeTicketDeleted}
+	if len(published) != len(want) {
+		t.Fatalf("published %d events, want %d", len(published), len(want))
+	}
+	for i, envelope := range published {
+		if envelope.Type != want[i] {
+			t.Errorf("event %d is %s, want %s", i, envelope.Type, want[i])
+		}
+		var payload struct {
+			Ticket     events.Ticket `json:"ticket"`
+			FromStatus string        `json:"from_status"`
+		}
+		if errUnmarshal := json.Unmarshal(envelope.Data, &payload); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+		if payload.Ticket.UUID != ticketUUID || payload.Ticket.ReporterUUID != "reporter" || payload.Ticket.SecurityRisk != 7 {
+			t.Errorf("%s carries %+v", envelope.Type, payload.Ticket)
+		}
+		if envelope.Type == events.TypeTicketResolved && (payload.FromStatus != model.StatusOpen || payload.Ticket.Status != model.StatusResolved) {
+			t.Errorf("resolved from %q to %q", payload.FromStatus, payload.Ticket.Status)
+		}
+	}
+}
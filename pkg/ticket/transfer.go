@@ -0,0 +1,282 @@
+package ticket
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"redifu-example/internal/model"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats accepted by import and produced by export.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+var UnsupportedFormat = errors.New("unsupported format, expected csv or ndjson")
+
+// maxNDJSONLine bounds one NDJSON row, a longer line stops the import.
+const maxNDJSONLine = 1 << 20
+
+// transferColumns is the CSV header of an export. Import reads the same
+// columns by name and ignores uuid, randid and updated_at, imported tickets
+// always get new identifiers.
+var transferColumns = []string{"uuid", "randid", "created_at", "updated_at", "reporter_uuid", "description",
+	"security_risk", "category_rand_id", "status", "assignee_uuid"}
+
+var requiredImportColumns = []string{"description", "reporter_uuid"}
+
+// TransferRow is one ticket in an import or export. An empty Status imports
+// as open and a zero CreatedAt as the time of the import.
+type TransferRow struct {
+	UUID           string    `json:"uuid,omitempty"`
+	RandId         string    `json:"randid,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	ReporterUUID   string    `json:"reporter_uuid"`
+	Description    string    `json:"description"`
+	SecurityRisk   int64     `json:"security_risk"`
+	CategoryRandId string    `json:"category_rand_id"`
+	Status         string    `json:"status"`
+	AssigneeUUID   string    `json:"assignee_uuid"`
+
+	line int
+}
+
+func NewTransferRow(ticket *model.Ticket) *TransferRow {
+	return &TransferRow{
+		UUID:           ticket.GetUUID(),
+		RandId:         ticket.GetRandId(),
+		CreatedAt:      ticket.GetCreatedAt(),
+		UpdatedAt:      ticket.GetUpdatedAt(),
+		ReporterUUID:   ticket.AccountUUID,
+		Description:    ticket.Description,
+		SecurityRisk:   ticket.SecurityRisk,
+		CategoryRandId: ticket.CategoryRandId,
+		Status:         ticket.Status,
+		AssigneeUUID:   ticket.AssigneeUUID,
+	}
+}
+
+// InvalidRow is returned by RowReader.Next for a row that cannot be parsed,
+// the reader can go on with the next row.
+type InvalidRow struct {
+	Line int
+	Err  error
+}
+
+func (e *InvalidRow) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *InvalidRow) Unwrap() error {
+	return e.Err
+}
+
+// RowReader yields import rows until io.EOF.
+type RowReader interface {
+	Next() (*TransferRow, error)
+}
+
+type csvRowReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// NewCSVRowReader reads the header right away, it fails when a required
+// column is missing.
+func NewCSVRowReader(r io.Reader) (RowReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, errHeader := reader.Read()
+	if errHeader != nil {
+		return nil, fmt.Errorf("read csv header: %w", errHeader)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, required := range requiredImportColumns {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("csv header lacks the %s column", required)
+		}
+	}
+
+	return &csvRowReader{reader: reader, columns: columns}, nil
+}
+
+func (cr *csvRowReader) Next() (*TransferRow, error) {
+	record, errRead := cr.reader.Read()
+	if errRead != nil {
+		var errParse *csv.ParseError
+		if errors.As(errRead, &errParse) {
+			return nil, &InvalidRow{Line: errParse.StartLine, Err: errParse.Err}
+		}
+		return nil, errRead
+	}
+
+	line, _ := cr.reader.FieldPos(0)
+	row := &TransferRow{
+		ReporterUUID:   cr.field(record, "reporter_uuid"),
+		Description:    cr.field(record, "description"),
+		CategoryRandId: cr.field(record, "category_rand_id"),
+		Status:         cr.field(record, "status"),
+		AssigneeUUID:   cr.field(record, "assignee_uuid"),
+		line:           line,
+	}
+
+	if risk := cr.field(record, "security_risk"); risk != "" {
+		parsed, errParse := strconv.ParseInt(risk, 10, 64)
+		if errParse != nil {
+			return nil, &InvalidRow{Line: line, Err: fmt.Errorf("incorrect security_risk value-type: %w", errParse)}
+		}
+		row.SecurityRisk = parsed
+	}
+	if createdAt := cr.field(record, "created_at"); createdAt != "" {
+		parsed, errParse := time.Parse(time.RFC3339, createdAt)
+		if errParse != nil {
+			return nil, &InvalidRow{Line: line, Err: fmt.Errorf("incorrect created_at value-type: %w", errParse)}
+		}
+		row.CreatedAt = parsed
+	}
+
+	return row, nil
+}
+
+func (cr *csvRowReader) field(record []string, column string) string {
+	i, exists := cr.columns[column]
+	if !exists || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+type ndjsonRowReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewNDJSONRowReader(r io.Reader) RowReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+	return &ndjsonRowReader{scanner: scanner}
+}
+
+func (nr *ndjsonRowReader) Next() (*TransferRow, error) {
+	for nr.scanner.Scan() {
+		nr.line++
+		raw := nr.scanner.Bytes()
+		if len(strings.TrimSpace(string(raw))) == 0 {
+			continue
+		}
+
+		var row TransferRow
+		if errUnmarshal := json.Unmarshal(raw, &row); errUnmarshal != nil {
+			return nil, &InvalidRow{Line: nr.line, Err: errUnmarshal}
+		}
+		row.line = nr.line
+		return &row, nil
+	}
+
+	if errScan := nr.scanner.Err(); errScan != nil {
+		return nil, fmt.Errorf("line %d: %w", nr.line+1, errScan)
+	}
+	return nil, io.EOF
+}
+
+// NewRowReader picks the reader for format.
+func NewRowReader(format string, r io.Reader) (RowReader, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVRowReader(r)
+	case FormatNDJSON:
+		return NewNDJSONRowReader(r), nil
+	}
+	return nil, UnsupportedFormat
+}
+
+// RowWriter encodes exported tickets, Flush hands whatever the encoder
+// buffers to the underlying writer.
+type RowWriter interface {
+	Write(ticket *model.Ticket) error
+	Flush() error
+}
+
+type csvRowWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func (cw *csvRowWriter) Write(ticket *model.Ticket) error {
+	row := NewTransferRow(ticket)
+	cw.record = append(cw.record[:0],
+		row.UUID,
+		row.RandId,
+		row.CreatedAt.UTC().Format(time.RFC3339Nano),
+		row.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		row.ReporterUUID,
+		row.Description,
+		strconv.FormatInt(row.SecurityRisk, 10),
+		row.CategoryRandId,
+		row.Status,
+		row.AssigneeUUID,
+	)
+	return cw.writer.Write(cw.record)
+}
+
+func (cw *csvRowWriter) Flush() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+type ndjsonRowWriter struct {
+	encoder *json.Encoder
+}
+
+func (nw *ndjsonRowWriter) Write(ticket *model.Ticket) error {
+	return nw.encoder.Encode(NewTransferRow(ticket))
+}
+
+// Flush has nothing to do, the encoder writes every row through.
+func (nw *ndjsonRowWriter) Flush() error {
+	return nil
+}
+
+// NewRowWriter picks the writer for format, the CSV writer starts with the
+// header.
+func NewRowWriter(format string, w io.Writer) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if errHeader := writer.Write(transferColumns); errHeader != nil {
+			return nil, errHeader
+		}
+		return &csvRowWriter{writer: writer, record: make([]string, 0, len(transferColumns))}, nil
+	case FormatNDJSON:
+		return &ndjsonRowWriter{encoder: json.NewEncoder(w)}, nil
+	}
+	return nil, UnsupportedFormat
+}
+
+// FormatFromContentType maps a request or Accept media type to a format.
+func FormatFromContentType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	switch {
+	case mediaType == "text/csv":
+		return FormatCSV
+	case slices.Contains([]string{"application/x-ndjson", "application/ndjson", "application/jsonl"}, mediaType):
+		return FormatNDJSON
+	}
+	return ""
+}
@@ -40,3 +40,28 @@ func ConnectRedis(host string, username string, password string, isClustered boo
 
 	return client
 }
+
+// ConnectStreamRedis opens a client of its own for the SSE feed. Every open
+// feed parks a blocking XREAD on a connection for a heartbeat interval, on the
+// shared pool enough of them would starve the cache calls. poolSize caps the
+// feeds reading at once.
+func ConnectStreamRedis(host string, username string, password string, poolSize int) redis.UniversalClient {
+	if host == "" {
+		log.Fatal("REDIS_HOST environment variable not set")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     host,
+		Username: username,
+		Password: password,
+		DB:       0,
+		PoolSize: poolSize,
+	})
+
+	_, err := client.Ping(context.Background()).Result()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return client
+}
@@ -0,0 +1,182 @@
+package warmup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"redifu-example/internal/fetcher"
+	"redifu-example/internal/logger"
+	"redifu-example/internal/pools"
+	"redifu-example/internal/repository"
+	"redifu-example/pkg/account"
+	"redifu-example/pkg/category"
+	"redifu-example/pkg/ticket"
+	"strings"
+	"time"
+)
+
+// Config selects which cached structures are seeded. An empty Categories
+// list warms every category timeline, Windows are ranges ending now.
+type Config struct {
+	Timeline         bool
+	SecurityTimeline bool
+	Pages            int64
+	Categories       []string
+	Windows          []time.Duration
+}
+
+// Report is the outcome of seeding one structure.
+type Report struct {
+	Structure string        `json:"structure"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+type Warmer struct {
+	ticketService   *ticket.TicketService
+	categoryService *category.CategoryService
+}
+
+// Run seeds every configured structure in turn, a failing structure is
+// reported and does not stop the rest of the warm-up.
+func (w *Warmer) Run(ctx context.Context, config *Config) []Report {
+	var reports []Report
+
+	if config.Timeline {
+		reports = append(reports, w.warm(ctx, "ticket-timeline", func(ctx context.Context) error {
+			return w.ticketService.SeedTickets(ctx, 0, "")
+		}))
+	}
+
+	if config.SecurityTimeline {
+		reports = append(reports, w.warm(ctx, "ticket-timeline:security-risk", func(ctx context.Context) error {
+			return w.ticketService.SeedTicketsBySecurityRisk(ctx, 0, "")
+		}))
+	}
+
+	for page := int64(1); page <= config.Pages; page++ {
+		reports = append(reports, w.warm(ctx, fmt.Sprintf("ticket-page:%d", page), func(ctx context.Context) error {
+			return w.ticketService.SeedTicketsByPage(ctx, page)
+		}))
+	}
+
+	categoryRandIds := config.Categories
+	if len(categoryRandIds) == 0 {
+		var errList error
+		categoryRandIds, errList = w.allCategories(ctx)
+		if errList != nil {
+			reports = append(reports, Report{Structure: "category", Error: errList.Error()})
+		}
+	}
+	for _, categoryRandId := range categoryRandIds {
+		reports = append(reports, w.warm(ctx, "ticket-timeline:category:"+categoryRandId, func(ctx context.Context) error {
+			return w.ticketService.SeedTicketsByCategory(ctx, 0, "", categoryRandId)
+		}))
+	}
+
+	now := time.Now().UTC()
+	for _, window := range config.Windows {
+		reports = append(reports, w.warm(ctx, "ticket-timeseries:"+window.String(), func(ctx context.Context) error {
+			return w.ticketService.SeedTicketsByDate(ctx, now.Add(-window), now)
+		}))
+	}
+
+	return reports
+}
+
+func (w *Warmer) warm(ctx context.Context, structure string, seed func(context.Context) error) Report {
+	startedAt := time.Now()
+	errSeed := seed(ctx)
+	report := Report{Structure: structure, Duration: time.Since(startedAt)}
+
+	if errSeed != nil {
+		report.Error = errSeed.Error()
+		logger.Logger.Error("warmup-error", "structure", structure, "duration", report.Duration.String(), "error", report.Error)
+		return report
+	}
+
+	logger.Logger.Info("warmup-seeded", "structure", structure, "duration", report.Duration.String())
+	return report
+}
+
+// allCategories seeds the category set first so it can be read back from the cache.
+func (w *Warmer) allCategories(ctx context.Context) ([]string, error) {
+	errSeed := w.categoryService.SeedCategories(ctx)
+	if errSeed != nil {
+		return nil, errSeed
+	}
+
+	categories, _, errFetch := w.categoryService.GetCategories(ctx)
+	if errFetch != nil {
+		return nil, errFetch
+	}
+
+	randIds := make([]string, 0, len(categories))
+	for _, category := range categories {
+		randIds = append(randIds, category.GetRandId())
+	}
+	return randIds, nil
+}
+
+// ParseCategories reads a comma separated list of category randIds.
+func ParseCategories(raw string) []string {
+	var categories []string
+	for _, categoryRandId := range strings.Split(raw, ",") {
+		if categoryRandId = strings.TrimSpace(categoryRandId); categoryRandId != "" {
+			categories = append(categories, categoryRandId)
+		}
+	}
+	return categories
+}
+
+// ParseWindows reads a comma separated list of durations, e.g. "24h,168h".
+func ParseWindows(raw string) ([]time.Duration, error) {
+	var windows []time.Duration
+	for _, window := range strings.Split(raw, ",") {
+		if window = strings.TrimSpace(window); window == "" {
+			continue
+		}
+		parsed, errParse := time.ParseDuration(window)
+		if errParse != nil {
+			return nil, fmt.Errorf("incorrect window value-type: %w", errParse)
+		}
+		windows = append(windows, parsed)
+	}
+	return windows, nil
+}
+
+// NewWarmerFromConnections wires the ticket and category services the same
+// way the API does, for tools that run outside it.
+func NewWarmerFromConnections(db *sql.DB, redisClient redis.UniversalClient) *Warmer {
+	fetcherPool := pools.NewFetcherPool(redisClient)
+	seederPool := pools.NewSeederPool()
+
+	seederPool.InitTicketSeeders(redisClient, db, fetcherPool)
+	seederPool.InitCategorySeeder(redisClient, db, fetcherPool.BaseCategory, fetcherPool.SortedCategory)
+
+	ticketRepo := repository.NewTicketRepository(db, fetcherPool, seederPool)
+	ticketRepo.InitFilterDecoder(ticket.FilterDecoder)
+	accountRepo := repository.NewAccountRepository(db, redisClient, fetcherPool)
+	categoryRepo := repository.NewCategoryRepository(db, fetcherPool, seederPool)
+
+	ticketService := ticket.NewTicketService()
+	accountService := account.NewAccountService()
+	categoryService := category.NewCategoryService()
+
+	ticketService.InitRepository(ticketRepo, categoryRepo, accountService)
+	ticketService.InitFetcher(fetcher.NewTicketFetcher(fetcherPool))
+	accountService.InitRepository(accountRepo, ticketRepo)
+	accountService.InitFetcher(fetcher.NewAccountFetcher(redisClient, fetcherPool))
+	categoryService.InitRepository(categoryRepo, ticketRepo)
+	categoryService.InitFetcher(fetcher.NewCategoryFetcher(fetcherPool))
+
+	return NewWarmer(ticketService, categoryService)
+}
+
+func NewWarmer(ticketService *ticket.TicketService, categoryService *category.CategoryService) *Warmer {
+	return &Warmer{
+		ticketService:   ticketService,
+		categoryService: categoryService,
+	}
+}
@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"redifu-example/internal/model"
+	"redifu-example/internal/repository"
+	"redifu-example/pkg/events"
+	"slices"
+	"time"
+)
+
+// Webhook event names integrators subscribe to, they are narrower than the
+// domain events: a security risk change is one kind of ticket.updated.
+// Deliveries are queued by QueueTicketWrite, not by the event publisher.
+const (
+	EventTicketCreated             = "ticket.created"
+	EventTicketResolved            = "ticket.resolved"
+	EventTicketSecurityRiskChanged = "ticket.security_risk_changed"
+)
+
+var EventTypes = []string{EventTicketCreated, EventTicketResolved, EventTicketSecurityRiskChanged}
+
+var InvalidSubscription = errors.New("invalid webhook subscription")
+
+// WebhookStore is what the service and the Worker need from
+// repository.WebhookRepository.
+type WebhookStore interface {
+	CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, subscriptionUUID string) error
+	FindSubscription(ctx context.Context, subscriptionUUID string) (*model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error)
+	ListSubscriptionsByEventType(ctx context.Context, tx *sql.Tx, eventType string) ([]*model.WebhookSubscription, error)
+	EnqueueDeliveries(ctx context.Context, tx *sql.Tx, deliveries []*model.WebhookDelivery) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, delivery *model.WebhookDelivery, statusCode int) error
+	MarkFailed(ctx context.Context, delivery *model.WebhookDelivery, statusCode int, errDeliver error, retryIn time.Duration, isDead bool) error
+	Redeliver(ctx context.Context, deliveryUUID string) error
+	ListDeliveries(ctx context.Context, subscriptionUUID string, limit int) ([]*model.WebhookDelivery, error)
+	ListDead(ctx context.Context, limit int) ([]*model.WebhookDelivery, error)
+}
+
+type WebhookService struct {
+	webhookRepository WebhookStore
+}
+
+func (s *WebhookService) InitRepository(webhookRepository WebhookStore) {
+	s.webhookRepository = webhookRepository
+}
+
+// Subscribe registers an endpoint and returns it with its signing secret,
+// the only time the secret leaves the service.
+func (s *WebhookService) Subscribe(ctx context.Context, endpoint string, eventTypes []string) (*model.WebhookSubscription, error) {
+	parsed, errParse := url.Parse(endpoint)
+	if errParse != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) url", InvalidSubscription)
+	}
+	if errHost := checkHost(ctx, parsed.Hostname()); errHost != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidSubscription, errHost)
+	}
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("%w: event_types is empty", InvalidSubscription)
+	}
+	for _, eventType := range eventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			return nil, fmt.Errorf("%w: unknown event type %q", InvalidSubscription, eventType)
+		}
+	}
+
+	secret, errSecret := newSecret()
+	if errSecret != nil {
+		return nil, errSecret
+	}
+
+	subscription := model.NewWebhookSubscription(endpoint, secret, eventTypes)
+	errCreate := s.webhookRepository.CreateSubscription(ctx, subscription)
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	return subscription, nil
+}
+
+func (s *WebhookService) Unsubscribe(ctx context.Context, subscriptionUUID string) error {
+	return s.webhookRepository.DeleteSubscription(ctx, subscriptionUUID)
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	return s.webhookRepository.ListSubscriptions(ctx)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, subscriptionUUID string, limit int) ([]*model.WebhookDelivery, error) {
+	_, errFind := s.webhookRepository.FindSubscription(ctx, subscriptionUUID)
+	if errFind != nil {
+		return nil, errFind
+	}
+
+	return s.webhookRepository.ListDeliveries(ctx, subscriptionUUID, limit)
+}
+
+func (s *WebhookService) GetDeadLetters(ctx context.Context, limit int) ([]*model.WebhookDelivery, error) {
+	return s.webhookRepository.ListDead(ctx, limit)
+}
+
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryUUID string) error {
+	return s.webhookRepository.Redeliver(ctx, deliveryUUID)
+}
+
+// QueueTicketWrite is the repository.WriteHook of the ticket repository, it
+// queues a delivery per matching subscription inside the transaction of the
+// write, a write that commits always has its deliveries. The Worker sends them.
+func (s *WebhookService) QueueTicketWrite(ctx context.Context, tx *sql.Tx, write *repository.TicketWrite) error {
+	eventType, event := webhookEvent(write)
+	if event == nil {
+		return nil
+	}
+
+	subscriptions, errList := s.webhookRepository.ListSubscriptionsByEventType(ctx, tx, eventType)
+	if errList != nil {
+		return errList
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	envelope, errEnvelope := events.NewEnvelope(event)
+	if errEnvelope != nil {
+		return errEnvelope
+	}
+	payload, errMarshal := json.Marshal(envelope)
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, model.NewWebhookDelivery(subscription.GetUUID(), envelope.ID, eventType, payload))
+	}
+
+	return s.webhookRepository.EnqueueDeliveries(ctx, tx, deliveries)
+}
+
+// webhookEvent returns the webhook event a write announces with the domain
+// event it carries, or a nil event when no webhook covers the write.
+func webhookEvent(write *repository.TicketWrite) (string, events.Event) {
+	previous, current := write.Previous, write.Current
+	switch {
+	case current == nil:
+		return "", nil
+	case previous == nil:
+		return EventTicketCreated, events.TicketCreated{Ticket: events.NewTicket(current)}
+	case current.Status == model.StatusResolved && previous.Status != model.StatusResolved:
+		note := ""
+		if write.Transition != nil {
+			note = write.Transition.Note
+		}
+		return EventTicketResolved, events.TicketResolved{Ticket: events.NewTicket(current), FromStatus: previous.Status, Note: note}
+	case current.SecurityRisk != previous.SecurityRisk:
+		return EventTicketSecurityRiskChanged, events.TicketUpdated{Ticket: events.NewTicket(current), Changed: changedFields(previous, current)}
+	}
+	return "", nil
+}
+
+// changedFields names the columns a write moved, the way TicketService names
+// them in ticket.updated.
+func changedFields(previous *model.Ticket, current *model.Ticket) []string {
+	var changed []string
+	if previous.Description != current.Description {
+		changed = append(changed, "description")
+	}
+	if previous.SecurityRisk != current.SecurityRisk {
+		changed = append(changed, "security_risk")
+	}
+	if previous.CategoryUUID != current.CategoryUUID {
+		changed = append(changed, "category_uuid")
+	}
+	if previous.AssigneeUUID != current.AssigneeUUID {
+		changed = append(changed, "assignee_uuid")
+	}
+	if previous.Status != current.Status {
+		changed = append(changed, "status")
+	}
+	return changed
+}
+
+// checkHost resolves host and rejects it unless every address it resolves to
+// is public. The Sender checks the dialed address again, DNS may have changed.
+func checkHost(ctx context.Context, host string) error {
+	addresses, errLookup := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if errLookup != nil {
+		return fmt.Errorf("cannot resolve %q", host)
+	}
+
+	for _, address := range addresses {
+		if !isPublicIP(address.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", BlockedAddress, host, address.IP)
+		}
+	}
+	return nil
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func NewWebhookService() *WebhookService {
+	return &WebhookService{}
+}
@@ -0,0 +1,137 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"redifu-example/internal/model"
+	"redifu-example/internal/repository"
+	"redifu-example/pkg/events"
+	"slices"
+	"testing"
+)
+
+// subscriptionStore is the subscription half of WebhookStore, it records what
+// QueueTicketWrite enqueues.
+type subscriptionStore struct {
+	WebhookStore
+	subscriptions []*model.WebhookSubscription
+	queued        []*model.WebhookDelivery
+}
+
+func (m *subscriptionStore) ListSubscriptionsByEventType(ctx context.Context, tx *sql.Tx, eventType string) ([]*model.WebhookSubscription, error) {
+	var matching []*model.WebhookSubscription
+	for _, subscription := range m.subscriptions {
+		if slices.Contains(subscription.EventTypes, eventType) {
+			matching = append(matching, subscription)
+		}
+	}
+	return matching, nil
+}
+
+func (m *subscriptionStore) EnqueueDeliveries(ctx context.Context, tx *sql.Tx, deliveries []*model.WebhookDelivery) error {
+	m.queued = append(m.queued, deliveries...)
+	return nil
+}
+
+func testTicket(status string, securityRisk int64, description string) *model.Ticket {
+	ticket := model.NewTicket()
+	ticket.UUID = "ticket-uuid"
+	ticket.Status = status
+	ticket.SecurityRisk = securityRisk
+	ticket.Description = description
+	return ticket
+}
+
+func TestQueueTicketWrite(t *testing.T) {
+	resolve := model.NewTicketTransition("ticket-uuid", model.StatusOpen, model.StatusResolved, "patched")
+	cases := []struct {
+		name      string
+		write     *repository.TicketWrite
+		eventType string
+		changed   []string
+	}{
+		{
+			name:      "create",
+			write:     &repository.TicketWrite{Current: testTicket(model.StatusOpen, 3, "leak")},
+			eventType: EventTicketCreated,
+		},
+		{
+			name: "resolve",
+			write: &repository.TicketWrite{Previous: testTicket(model.StatusOpen, 3, "leak"), Current: testTicket(model.StatusResolved, 3, "leak"),
+				Transition: resolve},
+			eventType: EventTicketResolved,
+		},
+		{
+			name:      "security risk",
+			write:     &repository.TicketWrite{Previous: testTicket(model.StatusOpen, 3, "leak"), Current: testTicket(model.StatusOpen, 9, "token leak")},
+			eventType: EventTicketSecurityRiskChanged,
+			changed:   []string{"description", "security_risk"},
+		},
+		{
+			name:  "description only",
+			write: &repository.TicketWrite{Previous: testTicket(model.StatusOpen, 3, "leak"), Current: testTicket(model.StatusOpen, 3, "token leak")},
+		},
+		{
+			name:  "delete",
+			write: &repository.TicketWrite{Previous: testTicket(model.StatusOpen, 3, "leak")},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			store := &subscriptionStore{subscriptions: []*model.WebhookSubscription{
+				model.NewWebhookSubscription("https://one.example", "secret", EventTypes),
+				model.NewWebhookSubscription("https://two.example", "secret", []string{EventTicketCreated}),
+			}}
+			service := NewWebhookService()
+			service.InitRepository(store)
+
+			if errQueue := service.QueueTicketWrite(context.Background(), nil, c.write); errQueue != nil {
+				t.Fatal(errQueue)
+			}
+
+			var want int
+			for _, subscription := range store.subscriptions {
+				if c.eventType != "" && slices.Contains(subscription.EventTypes, c.eventType) {
+					want++
+				}
+			}
+			if len(store.queued) != want {
+				t.Fatalf("queued %d deliveries, want %d", len(store.queued), want)
+			}
+
+			for _, delivery := range store.queued {
+				if delivery.EventType != c.eventType || delivery.Status != model.DeliveryPending {
+					t.Errorf("delivery %s is %s", delivery.EventType, delivery.Status)
+				}
+				var envelope events.Envelope
+				if errUnmarshal := json.Unmarshal(delivery.Payload, &envelope); errUnmarshal != nil {
+					t.Fatal(errUnmarshal)
+				}
+				if envelope.ID != delivery.EventID {
+					t.Errorf("envelope %s is delivered as event %s", envelope.ID, delivery.EventID)
+				}
+
+				var data struct {
+					Ticket     events.Ticket `json:"ticket"`
+					Changed    []string      `json:"changed"`
+					FromStatus string        `json:"from_status"`
+					Note       string        `json:"note"`
+				}
+				if errUnmarshal := json.Unmarshal(envelope.Data, &data); errUnmarshal != nil {
+					t.Fatal(errUnmarshal)
+				}
+				if data.Ticket.UUID != "ticket-uuid" || data.Ticket.SecurityRisk != c.write.Current.SecurityRisk {
+					t.Errorf("delivery carries %+v", data.Ticket)
+				}
+				if !slices.Equal(data.Changed, c.changed) {
+					t.Errorf("changed %v, want %v", data.Changed, c.changed)
+				}
+				if c.eventType == EventTicketResolved && (data.FromStatus != model.StatusOpen || data.Note != "patched") {
+					t.Errorf("resolved from %q with note %q", data.FromStatus, data.Note)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"redifu-example/definition"
+	"redifu-example/internal/logger"
+	"redifu-example/internal/model"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// Sign returns the value of the X-Webhook-Signature header. The timestamp is
+// part of the signed message so a captured request cannot be replayed later
+// with a fresh timestamp, receivers recompute it with their secret.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// BlockedAddress is returned for a webhook url pointing at a loopback,
+// link-local, private or otherwise non-public address.
+var BlockedAddress = errors.New("address is not public")
+
+// carrierGradeNAT is 100.64.0.0/10, shared address space net.IP.IsPrivate
+// does not cover.
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || carrierGradeNAT.Contains(ip))
+}
+
+// publicOnly is a net.Dialer Control refusing non-public addresses. It runs
+// on the resolved address of every connection, redirects included, so a
+// hostname rebound to an internal address after Subscribe is still refused.
+func publicOnly(network string, address string, _ syscall.RawConn) error {
+	host, _, errSplit := net.SplitHostPort(address)
+	if errSplit != nil {
+		return errSplit
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s", BlockedAddress, host)
+	}
+	return nil
+}
+
+// Sender performs a single signed POST, it has no database dependency so it
+// can be pointed at an httptest receiver on its own.
+type Sender struct {
+	client *http.Client
+}
+
+// Send returns the receiver's status code, any non-2xx answer is an error.
+func (s *Sender) Send(ctx context.Context, delivery *model.WebhookDelivery) (int, error) {
+	request, errRequest := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if errRequest != nil {
+		return 0, errRequest
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderTimestamp, timestamp)
+	request.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+	request.Header.Set(HeaderEvent, delivery.EventType)
+	request.Header.Set(HeaderDelivery, delivery.GetUUID())
+
+	response, errDo := s.client.Do(request)
+	if errDo != nil {
+		return 0, errDo
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("receiver answered %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// NewSender with a nil client only dials public addresses. A client passed
+// in is used as is.
+func NewSender(client *http.Client) *Sender {
+	if client == nil {
+		dialer := &net.Dialer{Timeout: definition.WebhookTimeout, Control: publicOnly}
+		client = &http.Client{
+			Timeout:   definition.WebhookTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+		}
+	}
+	return &Sender{client: client}
+}
+
+// Worker sends queued deliveries. Failures are retried with exponential
+// backoff until WebhookMaxAttempts, after which the delivery is dead-lettered.
+type Worker struct {
+	webhookRepository WebhookStore
+	sender            *Sender
+	interval          time.Duration
+}
+
+// DeliverDue sends up to WebhookBatchSize due deliveries and returns how many
+// succeeded. Each one is claimed right before it is sent, a lease covering a
+// whole batch would run out while earlier sends in it are still waiting on
+// slow receivers and let another worker send the rest again.
+func (w *Worker) DeliverDue(ctx context.Context) (int, error) {
+	delivered := 0
+	for i := 0; i < definition.WebhookBatchSize; i++ {
+		deliveries, errClaim := w.webhookRepository.ClaimDue(ctx, 1, definition.WebhookTimeout*2)
+		if errClaim != nil {
+			return delivered, errClaim
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		delivery := deliveries[0]
+		statusCode, errSend := w.sender.Send(ctx, delivery)
+		if errSend == nil {
+			if errMark := w.webhookRepository.MarkDelivered(ctx, delivery, statusCode); errMark != nil {
+				return delivered, errMark
+			}
+			delivered++
+			continue
+		}
+
+		attempts := delivery.Attempts + 1
+		isDead := attempts >= definition.WebhookMaxAttempts
+		errMark := w.webhookRepository.MarkFailed(ctx, delivery, statusCode, errSend, Backoff(attempts), isDead)
+		if errMark != nil {
+			return delivered, errMark
+		}
+		if isDead {
+			logger.Logger.Error("webhook-dead-letter", "delivery", delivery.GetUUID(), "subscription", delivery.SubscriptionUUID, "error", errSend.Error())
+		}
+	}
+
+	return delivered, nil
+}
+
+// Run blocks until ctx is done.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, errDeliver := w.DeliverDue(ctx); errDeliver != nil {
+				logger.Logger.Error("webhook-worker-error", "error", errDeliver.Error())
+			}
+		}
+	}
+}
+
+// Backoff doubles from WebhookRetryBase per attempt, capped at WebhookRetryMax.
+func Backoff(attempts int) time.Duration {
+	backoff := definition.WebhookRetryBase
+	for i := 1; i < attempts && backoff < definition.WebhookRetryMax; i++ {
+		backoff *= 2
+	}
+	if backoff > definition.WebhookRetryMax {
+		backoff = definition.WebhookRetryMax
+	}
+	return backoff
+}
+
+func NewWorker(webhookRepository WebhookStore, sender *Sender) *Worker {
+	return &Worker{
+		webhookRepository: webhookRepository,
+		sender:            sender,
+		interval:          definition.WebhookPollInterval,
+	}
+}
@@ -0,0 +1,273 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/21strive/redifu"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"redifu-example/definition"
+	"redifu-example/internal/model"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryStore is the queue half of WebhookStore over a slice, ClaimDue
+// leases the way the repository does by pushing next_attempt_at.
+type memoryStore struct {
+	WebhookStore
+	mu         sync.Mutex
+	deliveries []*model.WebhookDelivery
+	retries    []time.Duration
+}
+
+func (m *memoryStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var claimed []*model.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if delivery.Status == model.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			delivery.NextAttemptAt = now.Add(lease)
+			copied := *delivery
+			claimed = append(claimed, &copied)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *memoryStore) MarkDelivered(ctx context.Context, delivery *model.WebhookDelivery, statusCode int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := m.find(delivery.GetUUID())
+	stored.Status = model.DeliveryDelivered
+	stored.Attempts++
+	stored.LastStatusCode = statusCode
+	return nil
+}
+
+func (m *memoryStore) MarkFailed(ctx context.Context, delivery *model.WebhookDelivery, statusCode int, errDeliver error, retryIn time.Duration, isDead bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := m.find(delivery.GetUUID())
+	stored.Attempts++
+	stored.LastStatusCode = statusCode
+	stored.LastError = errDeliver.Error()
+	stored.NextAttemptAt = time.Now().Add(retryIn)
+	if isDead {
+		stored.Status = model.DeliveryDead
+	}
+	m.retries = append(m.retries, retryIn)
+	return nil
+}
+
+func (m *memoryStore) find(deliveryUUID string) *model.WebhookDelivery {
+	for _, delivery := range m.deliveries {
+		if delivery.GetUUID() == deliveryUUID {
+			return delivery
+		}
+	}
+	return nil
+}
+
+// makeDue skips the backoff of every pending delivery.
+func (m *memoryStore) makeDue() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, delivery := range m.deliveries {
+		delivery.NextAttemptAt = time.Time{}
+	}
+}
+
+func newTestDelivery(i int, url string) *model.WebhookDelivery {
+	delivery := &model.WebhookDelivery{
+		Record:           &redifu.Record{UUID: fmt.Sprintf("delivery-%d", i), RandId: fmt.Sprintf("dlv%d", i)},
+		SubscriptionUUID: "subscription-1",
+		EventID:          fmt.Sprintf("event-%d", i),
+		EventType:        EventTicketCreated,
+		Payload:          []byte(fmt.Sprintf(`{"id":"event-%d"}`, i)),
+		Status:           model.DeliveryPending,
+		URL:              url,
+		Secret:           "test-secret",
+	}
+	return delivery
+}
+
+func TestWorkerSignsDelivery(t *testing.T) {
+	var mu sync.Mutex
+	var requests []*http.Request
+	var bodies [][]byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	store := &memoryStore{deliveries: []*model.WebhookDelivery{newTestDelivery(1, receiver.URL)}}
+	worker := NewWorker(store, NewSender(receiver.Client()))
+
+	delivered, errDeliver := worker.DeliverDue(context.Background())
+	if errDeliver != nil {
+		t.Fatal(errDeliver)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if delivered != 1 || len(requests) != 1 {
+		t.Fatalf("delivered %d over %d requests, want 1", delivered, len(requests))
+	}
+
+	request := requests[0]
+	want := Sign("test-secret", request.Header.Get(HeaderTimestamp), bodies[0])
+	if got := request.Header.Get(HeaderSignature); got != want {
+		t.Errorf("signature %q, want %q", got, want)
+	}
+	if got := request.Header.Get(HeaderEvent); got != EventTicketCreated {
+		t.Errorf("event header %q", got)
+	}
+	if got := request.Header.Get(HeaderDelivery); got != "delivery-1" {
+		t.Errorf("delivery header %q", got)
+	}
+	if got := string(bodies[0]); got != `{"id":"event-1"}` {
+		t.Errorf("body %s", got)
+	}
+	if status := store.deliveries[0].Status; status != model.DeliveryDelivered {
+		t.Errorf("status %q, want %q", status, model.DeliveryDelivered)
+	}
+}
+
+func TestWorkerBacksOffThenDeadLetters(t *testing.T) {
+	var hits atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	store := &memoryStore{deliveries: []*model.WebhookDelivery{newTestDelivery(1, receiver.URL)}}
+	worker := NewWorker(store, NewSender(receiver.Client()))
+
+	for i := 0; i < definition.WebhookMaxAttempts+2; i++ {
+		if _, errDeliver := worker.DeliverDue(context.Background()); errDeliver != nil {
+			t.Fatal(errDeliver)
+		}
+		if i == 0 {
+			// the backoff holds the delivery back until it is due again
+			if _, errDeliver := worker.DeliverDue(context.Background()); errDeliver != nil {
+				t.Fatal(errDeliver)
+			}
+			if sent := hits.Load(); sent != 1 {
+				t.Fatalf("sent %d times before the backoff ran out, want 1", sent)
+			}
+		}
+		store.makeDue()
+	}
+
+	if sent := int(hits.Load()); sent != definition.WebhookMaxAttempts {
+		t.Errorf("sent %d times, want %d", sent, definition.WebhookMaxAttempts)
+	}
+	delivery := store.deliveries[0]
+	if delivery.Status != model.DeliveryDead {
+		t.Errorf("status %q, want %q", delivery.Status, model.DeliveryDead)
+	}
+	if delivery.LastStatusCode != http.StatusServiceUnavailable {
+		t.Errorf("last status code %d", delivery.LastStatusCode)
+	}
+
+	want := definition.WebhookRetryBase
+	for i, retryIn := range store.retries[:len(store.retries)-1] {
+		if retryIn != want {
+			t.Errorf("retry %d after %s, want %s", i+1, retryIn, want)
+		}
+		want = min(want*2, definition.WebhookRetryMax)
+	}
+}
+
+func TestWorkersDeliverOnce(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string]int{}
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// slow enough that both workers are sending at the same time
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		sent[r.Header.Get(HeaderDelivery)]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	store := &memoryStore{}
+	for i := 0; i < 10; i++ {
+		store.deliveries = append(store.deliveries, newTestDelivery(i, receiver.URL))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker := NewWorker(store, NewSender(receiver.Client()))
+			if _, errDeliver := worker.DeliverDue(context.Background()); errDeliver != nil {
+				t.Error(errDeliver)
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, delivery := range store.deliveries {
+		if count := sent[delivery.GetUUID()]; count != 1 {
+			t.Errorf("%s sent %d times, want 1", delivery.GetUUID(), count)
+		}
+	}
+}
+
+func TestSenderRefusesPrivateAddress(t *testing.T) {
+	var hits atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer receiver.Close()
+
+	// the default client dials public addresses only, httptest listens on loopback
+	_, errSend := NewSender(nil).Send(context.Background(), newTestDelivery(1, receiver.URL))
+	if !errors.Is(errSend, BlockedAddress) {
+		t.Errorf("send to %s: %v, want BlockedAddress", receiver.URL, errSend)
+	}
+	if reached := hits.Load(); reached != 0 {
+		t.Errorf("receiver was reached %d times", reached)
+	}
+}
+
+func TestSubscribeRefusesPrivateAddress(t *testing.T) {
+	service := NewWebhookService()
+	service.InitRepository(&memoryStore{})
+
+	for _, endpoint := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://10.0.0.5/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+	} {
+		_, errSubscribe := service.Subscribe(context.Background(), endpoint, []string{EventTicketCreated})
+		if !errors.Is(errSubscribe, InvalidSubscription) {
+			t.Errorf("subscribe %s: %v, want InvalidSubscription", endpoint, errSubscribe)
+		}
+	}
+}